@@ -8,10 +8,27 @@
 # Data Sources
 # ------------------------------------------------------------------------------
 
-data "aws_caller_identity" "current" {}
+data "aws_caller_identity" "current" {
+  lifecycle {
+    precondition {
+      condition     = var.assume_role_arn == "" || var.aws_account_id == "" || split(":", var.assume_role_arn)[4] == var.aws_account_id
+      error_message = "assume_role_arn is in account ${var.assume_role_arn == "" ? "" : split(":", var.assume_role_arn)[4]} but aws_account_id is ${var.aws_account_id}."
+    }
+
+    # Guards against deploying an environment into the wrong account
+    postcondition {
+      condition     = var.aws_account_id == "" || self.account_id == var.aws_account_id
+      error_message = "Credentials resolve to account ${self.account_id}, expected aws_account_id ${var.aws_account_id}."
+    }
+  }
+}
 
 data "aws_region" "current" {}
 
+# Partition and DNS suffix are resolved once here and passed to every module
+# that builds ARNs or service principals
+data "aws_partition" "current" {}
+
 # ------------------------------------------------------------------------------
 # Local Values
 # ------------------------------------------------------------------------------
@@ -23,23 +40,183 @@ locals {
     {
       Name        = "hipaa-compliant-${var.environment}"
       Environment = var.environment
+      CostCenter  = var.cost_center != "" ? var.cost_center : lookup(var.tags, "CostCenter", "")
       ManagedBy   = "Terraform"
       Project     = "HIPAA-Compliant-Document-Management"
       Workspace   = terraform.workspace
-      CreatedAt   = timestamp()
     }
   )
 
+  # Environment label with the optional name_suffix, as the modules build it
+  full_suffix = var.name_suffix == "" ? var.environment : "${var.environment}-${var.name_suffix}"
+
   # Account and region information
   aws_account_id = data.aws_caller_identity.current.account_id
   aws_region     = data.aws_region.current.name
+
+  # Cost allocation tags every billable resource must carry
+  required_cost_tags = ["CostCenter", "Environment"]
+  missing_cost_tags  = [for key in local.required_cost_tags : key if lookup(local.common_tags, key, "") == ""]
+
+  # Multi-region resources are created only when a distinct replica region is set
+  multi_region_enabled = var.replica_region != "" && var.replica_region != var.aws_region
+}
+
+# ------------------------------------------------------------------------------
+# Plan-Time Checks
+# ------------------------------------------------------------------------------
+
+check "cost_allocation_tags" {
+  assert {
+    condition     = length(local.missing_cost_tags) == 0
+    error_message = "Missing cost allocation tags: ${join(", ", local.missing_cost_tags)}. Set cost_center so spend can be attributed."
+  }
+}
+
+# lifecycle.prevent_destroy cannot reference variables, so stateful resources
+# rely on attribute-level guards that allow_destroy relaxes for test teardown
+check "destroy_guards" {
+  assert {
+    condition     = !(var.allow_destroy && var.environment == "production")
+    error_message = "allow_destroy is enabled in production. It removes destroy guards on buckets, RDS and KMS keys and is meant for test teardown only."
+  }
+}
+
+# With egress restricted to the endpoint security group, Bedrock is only
+# reachable when the interface endpoints exist
+check "bedrock_egress_path" {
+  assert {
+    condition     = !var.restrict_bedrock_egress || var.enable_vpc_endpoints
+    error_message = "restrict_bedrock_egress is set but enable_vpc_endpoints is false; the app has no network path to Bedrock."
+  }
+}
+
+# The firewall filters by domain, but traffic only reaches it when the app
+# security group allows HTTPS beyond the endpoint security group
+check "network_firewall_egress_path" {
+  assert {
+    condition     = !var.enable_network_firewall || !var.restrict_bedrock_egress
+    error_message = "enable_network_firewall is set but restrict_bedrock_egress is true; the app cannot reach the domains in allowed_aws_service_domains."
+  }
+}
+
+# ------------------------------------------------------------------------------
+# Service Quota Pre-Flight
+# ------------------------------------------------------------------------------
+# Hitting a quota mid-apply leaves a half-built stack holding PHI resources, so
+# the plan fails instead when existing usage plus what this stack adds exceeds
+# the regional quota. Resources this stack already owns are not counted twice
+# on later plans.
+
+locals {
+  # Every DB instance identifier the RDS module can create (Aurora allows at
+  # most 15 readers); restores and other databases in the environment count
+  # as other usage
+  quota_stack_db_identifiers = concat(
+    [for role in ["primary", "replica", "reporting", "writer"] : "${local.full_suffix}-hipaa-db-${role}"],
+    [for i in range(15) : "${local.full_suffix}-hipaa-db-reader-${i + 1}"]
+  )
+
+  # One Elastic IP per NAT gateway, mirroring the VPC module's nat_gateway_mode precedence
+  planned_nat_gateways = (
+    var.nat_gateway_mode == "none" || (var.nat_gateway_mode == "" && !var.enable_nat_gateway) ? 0 :
+    var.nat_gateway_mode == "single" || var.single_az_mode ? 1 : 3
+  )
+
+  planned_quota_usage = {
+    elastic_ips   = local.planned_nat_gateways
+    vpcs          = 1
+    rds_instances = var.rds_engine_type == "aurora-postgresql" ? 1 + var.aurora_reader_count : 1 + (var.enable_read_replica ? 1 : 0) + (var.enable_reporting_replica ? 1 : 0)
+  }
+
+  service_quotas = {
+    elastic_ips   = { service_code = "ec2", quota_code = "L-0263D0A3", name = "EC2-VPC Elastic IPs" }
+    vpcs          = { service_code = "vpc", quota_code = "L-F678F1CE", name = "VPCs per Region" }
+    rds_instances = { service_code = "rds", quota_code = "L-7B6409FD", name = "DB instances" }
+  }
+}
+
+data "aws_servicequotas_service_quota" "preflight" {
+  for_each = var.enable_quota_preflight ? {
+    for key, quota in local.service_quotas : key => quota if !contains(keys(var.service_quota_overrides), key)
+  } : {}
+
+  service_code = each.value.service_code
+  quota_code   = each.value.quota_code
+}
+
+data "aws_eips" "all" {
+  count = var.enable_quota_preflight ? 1 : 0
+}
+
+data "aws_eips" "stack" {
+  count = var.enable_quota_preflight ? 1 : 0
+
+  filter {
+    name   = "tag:Name"
+    values = [for i in range(3) : "hipaa-nat-eip-${local.full_suffix}-${i + 1}"]
+  }
+}
+
+data "aws_vpcs" "all" {
+  count = var.enable_quota_preflight ? 1 : 0
+}
+
+data "aws_vpcs" "stack" {
+  count = var.enable_quota_preflight ? 1 : 0
+
+  tags = {
+    Name = "hipaa-compliant-vpc-${local.full_suffix}"
+  }
+}
+
+data "aws_db_instances" "all" {
+  count = var.enable_quota_preflight ? 1 : 0
+}
+
+locals {
+  quota_limits = {
+    for key in keys(local.service_quotas) : key => lookup(
+      var.service_quota_overrides,
+      key,
+      try(data.aws_servicequotas_service_quota.preflight[key].value, 0)
+    )
+  }
+
+  # Usage by anything other than this stack
+  quota_other_usage = var.enable_quota_preflight ? {
+    elastic_ips = length(data.aws_eips.all[0].allocation_ids) - length(data.aws_eips.stack[0].allocation_ids)
+    vpcs        = length(data.aws_vpcs.all[0].ids) - length(data.aws_vpcs.stack[0].ids)
+
+    # Database identifiers are matched exactly rather than through a tag filter
+    rds_instances = length(setsubtract(data.aws_db_instances.all[0].instance_identifiers, local.quota_stack_db_identifiers))
+  } : {}
+
+  quota_shortfalls = [
+    for key, other in local.quota_other_usage :
+    "${local.service_quotas[key].name} (${local.service_quotas[key].quota_code}): this stack needs ${local.planned_quota_usage[key]}, ${other} already in use, quota is ${local.quota_limits[key]}"
+    if other + local.planned_quota_usage[key] > local.quota_limits[key]
+  ]
+}
+
+resource "terraform_data" "service_quota_preflight" {
+  count = var.enable_quota_preflight ? 1 : 0
+
+  input = local.planned_quota_usage
+
+  lifecycle {
+    precondition {
+      condition     = length(local.quota_shortfalls) == 0
+      error_message = "Service quota pre-flight failed in ${local.aws_region}; request an increase in Service Quotas or shrink the stack before applying: ${join("; ", local.quota_shortfalls)}."
+    }
+  }
 }
 
 # ------------------------------------------------------------------------------
 # Module: VPC & Networking
 # ------------------------------------------------------------------------------
 # Provisions VPC, subnets, routing, NAT gateways, and VPC endpoints
-# No dependencies - foundational module
+# Interface endpoints take their security group from the networking module
 
 module "vpc" {
   source = "./modules/vpc"
@@ -47,10 +224,19 @@ module "vpc" {
   vpc_cidr             = var.vpc_cidr
   environment          = var.environment
   name_suffix          = var.name_suffix
+  dns_suffix           = data.aws_partition.current.dns_suffix
   availability_zones   = var.availability_zones
+  single_az_mode       = var.single_az_mode
   enable_nat_gateway   = var.enable_nat_gateway
+  nat_gateway_mode     = var.nat_gateway_mode
   enable_vpc_endpoints = var.enable_vpc_endpoints
   tags                 = local.common_tags
+
+  # Endpoints admit HTTPS only from the app security group, not the whole VPC
+  endpoint_security_group_ids = [module.networking.vpc_endpoint_security_group_id]
+
+  enable_network_firewall     = var.enable_network_firewall
+  allowed_aws_service_domains = var.allowed_aws_service_domains
 }
 
 # ------------------------------------------------------------------------------
@@ -62,18 +248,36 @@ module "vpc" {
 module "kms" {
   source = "./modules/kms"
 
+  providers = {
+    aws         = aws
+    aws.replica = aws.replica
+  }
+
   environment         = var.environment
   name_suffix         = var.name_suffix
+  partition           = data.aws_partition.current.partition
+  dns_suffix          = data.aws_partition.current.dns_suffix
   aws_account_id      = local.aws_account_id
   enable_key_rotation = var.enable_key_rotation
+  create_replica_key  = local.multi_region_enabled
+  allow_destroy       = var.allow_destroy
   tags                = local.common_tags
+
+  enable_backup_service_access     = var.enable_aws_backup
+  enable_ebs_encryption_by_default = var.enable_ebs_encryption_by_default
+  create_backup_key                = var.use_separate_bucket_keys
+  create_log_key                   = var.create_log_key
+
+  key_administrator_arns = var.kms_key_administrator_arns
+  key_user_arns          = var.kms_key_user_arns
 }
 
 # ------------------------------------------------------------------------------
 # Module: Security Groups
 # ------------------------------------------------------------------------------
 # Configures security groups with least-privilege rules
-# Depends on: VPC module
+# Depends on: VPC module (vpc_id only; a module-level depends_on would form a
+# cycle with the interface endpoints that use vpc_endpoint_security_group_id)
 
 module "networking" {
   source = "./modules/networking"
@@ -84,7 +288,44 @@ module "networking" {
   railway_ip_ranges = var.railway_ip_ranges
   tags              = local.common_tags
 
-  depends_on = [module.vpc]
+  restrict_bedrock_egress = var.restrict_bedrock_egress
+}
+
+# ------------------------------------------------------------------------------
+# Module: WAF (Conditional)
+# ------------------------------------------------------------------------------
+# Central allow-list of Railway egress ranges for protected endpoints
+
+module "waf" {
+  count  = var.enable_waf ? 1 : 0
+  source = "./modules/waf"
+
+  environment       = var.environment
+  name_suffix       = var.name_suffix
+  railway_ip_ranges = var.railway_ip_ranges
+  tags              = local.common_tags
+
+  protected_resource_arns = var.waf_protected_resource_arns
+}
+
+# ------------------------------------------------------------------------------
+# Module: PrivateLink (Conditional)
+# ------------------------------------------------------------------------------
+# Partner access over an endpoint service instead of the internet
+# Depends on: VPC module
+
+module "privatelink" {
+  count  = var.enable_privatelink ? 1 : 0
+  source = "./modules/privatelink"
+
+  environment = var.environment
+  name_suffix = var.name_suffix
+  vpc_id      = module.vpc.vpc_id
+  subnet_ids  = module.vpc.private_subnet_ids
+  tags        = local.common_tags
+
+  target_ip_addresses    = var.privatelink_target_ip_addresses
+  allowed_principal_arns = var.privatelink_allowed_principal_arns
 }
 
 # ------------------------------------------------------------------------------
@@ -96,17 +337,85 @@ module "networking" {
 module "s3" {
   source = "./modules/s3"
 
+  providers = {
+    aws         = aws
+    aws.replica = aws.replica
+  }
+
   environment               = var.environment
   name_suffix               = var.name_suffix
+  partition                 = data.aws_partition.current.partition
+  dns_suffix                = data.aws_partition.current.dns_suffix
   aws_account_id            = local.aws_account_id
   kms_key_id                = module.kms.kms_master_key_id
   enable_lifecycle_policies = var.enable_lifecycle_policies
   documents_bucket_name     = var.documents_bucket_name
-  tags                      = local.common_tags
+  secondary_kms_key_arn     = var.documents_secondary_kms_key_arn
+  backups_kms_key_arn       = var.use_separate_bucket_keys ? module.kms.backup_kms_key_arn : ""
+  deny_unencrypted_uploads  = var.s3_deny_unencrypted_uploads
+  documents_active_kms_key  = var.documents_active_kms_key
+  create_canary_bucket      = var.create_canary_bucket
+  create_quarantine_bucket  = var.create_quarantine_bucket
+  quarantine_role_arn       = var.quarantine_role_arn
+  canary_alert_email        = var.sns_alert_email
+
+  enable_quarantine_automation = var.enable_quarantine_automation
+  quarantine_alert_email       = var.sns_alert_email
+
+  enable_intelligent_tiering = var.enable_intelligent_tiering
+
+  enable_request_metrics = var.enable_s3_request_metrics
+  request_alarm_actions  = [module.monitoring.alarm_topic_arn]
+
+  enable_eventbridge_notifications = var.enable_s3_eventbridge_notifications
+
+  enable_replication  = local.multi_region_enabled
+  replica_kms_key_arn = module.kms.kms_replica_key_arn
+  allow_destroy       = var.allow_destroy
+  tags                = local.common_tags
 
   depends_on = [module.kms]
 }
 
+# ------------------------------------------------------------------------------
+# RDS Encryption Key Guard
+# ------------------------------------------------------------------------------
+# RDS cannot re-key a database in place: changing kms_key_id (for example by
+# toggling use_separate_bucket_keys) destroys the primary and creates an empty
+# one. Fail the plan when the existing primary is encrypted under a different
+# key unless the replacement is acknowledged.
+
+data "aws_db_instances" "existing_primary" {
+  filter {
+    name   = "db-instance-id"
+    values = ["${local.full_suffix}-hipaa-db-primary"]
+  }
+}
+
+data "aws_db_instance" "existing_primary" {
+  count = length(data.aws_db_instances.existing_primary.instance_identifiers)
+
+  db_instance_identifier = "${local.full_suffix}-hipaa-db-primary"
+}
+
+locals {
+  existing_primary_kms_key_arn = one(data.aws_db_instance.existing_primary[*].kms_key_id)
+}
+
+resource "terraform_data" "rds_kms_key_guard" {
+  input = var.use_separate_bucket_keys
+
+  lifecycle {
+    precondition {
+      # The existing primary is on the master key exactly when separate keys are off
+      condition = local.existing_primary_kms_key_arn == null ? true : (
+        var.allow_rds_kms_key_replacement || (local.existing_primary_kms_key_arn == module.kms.kms_master_key_arn) != var.use_separate_bucket_keys
+      )
+      error_message = "${local.full_suffix}-hipaa-db-primary is encrypted with ${coalesce(local.existing_primary_kms_key_arn, "none")}; use_separate_bucket_keys = ${var.use_separate_bucket_keys} would replace it with an empty database. Migrate through a re-encrypted snapshot (see \"Changing the Encryption Key\" in modules/rds/README.md) or set allow_rds_kms_key_replacement = true."
+    }
+  }
+}
+
 # ------------------------------------------------------------------------------
 # Module: RDS Database
 # ------------------------------------------------------------------------------
@@ -116,19 +425,85 @@ module "s3" {
 module "rds" {
   source = "./modules/rds"
 
+  providers = {
+    aws         = aws
+    aws.replica = aws.replica
+  }
+
   environment           = var.environment
+  name_suffix           = var.name_suffix
+  partition             = data.aws_partition.current.partition
+  dns_suffix            = data.aws_partition.current.dns_suffix
   private_subnet_ids    = module.vpc.private_subnet_ids
   security_group_id     = module.networking.rds_security_group_id
-  kms_key_id            = module.kms.kms_master_key_id
+  kms_key_id            = module.kms.backup_kms_key_id
   instance_class        = var.rds_instance_class
+  tenancy               = var.rds_tenancy
   allocated_storage     = var.rds_allocated_storage
-  multi_az              = var.rds_multi_az
+  multi_az              = var.single_az_mode ? false : var.rds_multi_az
+  availability_zone     = var.single_az_mode ? module.vpc.active_availability_zones[0] : ""
   enable_read_replica   = var.enable_read_replica
   backup_retention_days = var.backup_retention_days
   deletion_protection   = var.deletion_protection
+  allow_destroy         = var.allow_destroy
   tags                  = local.common_tags
 
-  depends_on = [module.vpc, module.networking, module.kms]
+  enable_reporting_replica             = var.enable_reporting_replica
+  reporting_allowed_security_group_ids = var.reporting_allowed_security_group_ids
+  reporting_replica_availability_zone  = var.single_az_mode ? module.vpc.active_availability_zones[0] : ""
+
+  enable_rds_proxy                = var.enable_rds_proxy
+  proxy_require_tls               = var.proxy_require_tls
+  proxy_connection_borrow_timeout = var.proxy_connection_borrow_timeout
+
+  enable_cross_region_backups = local.multi_region_enabled
+  replica_kms_key_arn         = module.kms.kms_replica_key_arn
+
+  snapshot_copy_account_id = var.snapshot_copy_account_id
+
+  enable_scheduling         = var.enable_rds_scheduling
+  schedule_stop_expression  = var.rds_stop_schedule
+  schedule_start_expression = var.rds_start_schedule
+
+  expected_connections = var.expected_connections
+  expected_storage_gb  = var.expected_storage_gb
+
+  engine_type         = var.rds_engine_type
+  aurora_reader_count = var.aurora_reader_count
+
+  master_password_length = var.rds_master_password_length
+  ca_cert_identifier     = var.rds_ca_cert_identifier
+
+  depends_on = [module.vpc, module.networking, module.kms, terraform_data.rds_kms_key_guard]
+}
+
+# ------------------------------------------------------------------------------
+# Module: Data Masking (Conditional)
+# ------------------------------------------------------------------------------
+# Holds production snapshots restored into dev or staging in an isolation
+# security group until their PHI columns are masked, then attaches the RDS
+# security group the app reaches
+# Depends on: VPC, Networking, KMS, RDS modules
+
+module "data_masking" {
+  count  = var.enable_data_masking ? 1 : 0
+  source = "./modules/data_masking"
+
+  environment = var.environment
+  name_suffix = var.name_suffix
+  partition   = data.aws_partition.current.partition
+  dns_suffix  = data.aws_partition.current.dns_suffix
+  vpc_id      = module.vpc.vpc_id
+  subnet_ids  = module.vpc.private_subnet_ids
+  tags        = local.common_tags
+
+  exposed_security_group_ids = [module.networking.rds_security_group_id]
+  master_username_parameter  = module.rds.rds_username_ssm_parameter
+  master_password_parameter  = module.rds.rds_password_ssm_parameter
+  kms_key_arn                = module.kms.backup_kms_key_arn
+  database_name              = module.rds.rds_db_name
+  masking_rules              = var.data_masking_rules
+  driver_layer_arns          = var.data_masking_driver_layer_arns
 }
 
 # ------------------------------------------------------------------------------
@@ -142,11 +517,34 @@ module "iam" {
 
   environment              = var.environment
   name_suffix              = var.name_suffix
+  partition                = data.aws_partition.current.partition
+  dns_suffix               = data.aws_partition.current.dns_suffix
   s3_bucket_documents_arn  = module.s3.s3_bucket_documents_arn
   s3_bucket_backups_arn    = module.s3.s3_bucket_backups_arn
   s3_bucket_audit_logs_arn = module.s3.s3_bucket_audit_logs_arn
   kms_master_key_arn       = module.kms.kms_master_key_arn
-  tags                     = local.common_tags
+  require_secure_transport = var.app_require_secure_transport
+  allowed_vpce_ids         = var.app_restrict_s3_to_vpc_endpoint && var.enable_vpc_endpoints ? [module.vpc.vpc_endpoint_s3_id] : []
+  deny_unapproved_kms_keys = var.app_deny_unapproved_kms_keys
+
+  enable_presigned_urls            = var.app_enable_presigned_urls
+  presigned_url_max_expiry_seconds = var.app_presigned_url_max_expiry_seconds
+
+  # Keys the app may use besides the master key
+  secondary_kms_key_arns = concat(
+    local.multi_region_enabled ? [module.kms.kms_replica_key_arn] : [],
+    var.use_separate_bucket_keys ? [module.kms.backup_kms_key_arn] : []
+  )
+
+  tags = local.common_tags
+
+  manage_account_password_policy = var.manage_account_password_policy
+
+  # Database credentials referenced by the Railway env file
+  ssm_parameter_names = [
+    module.rds.rds_username_ssm_parameter,
+    module.rds.rds_password_ssm_parameter
+  ]
 
   depends_on = [module.s3, module.kms, module.rds]
 }
@@ -155,16 +553,155 @@ module "iam" {
 # Module: AWS Config Compliance
 # ------------------------------------------------------------------------------
 # Deploys AWS Config for continuous compliance monitoring
-# Depends on: S3 module
+# Depends on: S3, KMS modules
 
 module "config" {
   source = "./modules/config"
 
   environment          = var.environment
   name_suffix          = var.name_suffix
+  partition            = data.aws_partition.current.partition
+  dns_suffix           = data.aws_partition.current.dns_suffix
   s3_bucket_audit_logs = module.s3.s3_bucket_audit_logs
-  sns_alert_email      = var.sns_alert_email
-  tags                 = local.common_tags
+  delivery_kms_key_arn = var.create_log_key ? module.kms.log_kms_key_arn : ""
+
+  config_snapshot_bucket = var.config_snapshot_bucket
+  sns_alert_email        = var.sns_alert_email
+  tags                   = local.common_tags
+
+  enable_auto_remediation = var.enable_config_auto_remediation
+  remediation_kms_key_arn = module.kms.kms_master_key_arn
+
+  enable_config_aggregator = var.enable_config_aggregator
+  aggregator_account_ids   = var.config_aggregator_account_ids
+  aggregator_regions       = var.config_aggregator_regions
+
+  aggregator_authorized_account_id = var.config_aggregator_authorized_account_id
+  aggregator_authorized_regions    = var.config_aggregator_authorized_regions
 
   depends_on = [module.s3]
 }
+
+# ------------------------------------------------------------------------------
+# Module: CloudTrail Audit Trail
+# ------------------------------------------------------------------------------
+# Records API activity to the audit logs bucket with KMS encryption and
+# log file validation
+# Depends on: S3, KMS modules
+
+module "cloudtrail" {
+  source = "./modules/cloudtrail"
+
+  environment          = var.environment
+  name_suffix          = var.name_suffix
+  dns_suffix           = data.aws_partition.current.dns_suffix
+  s3_bucket_audit_logs = module.s3.s3_bucket_audit_logs
+  kms_key_arn          = module.kms.log_kms_key_arn
+  tags                 = local.common_tags
+
+  enable_cloudwatch_logs        = var.enable_cloudtrail_cloudwatch_logs
+  cloudtrail_log_retention_days = var.cloudtrail_log_retention_days
+
+  data_event_bucket_arns = concat(
+    var.enable_cloudtrail_data_events ? [module.s3.s3_bucket_documents_arn, module.s3.s3_bucket_backups_arn] : [],
+    var.create_canary_bucket ? [module.s3.canary_bucket_arn] : []
+  )
+
+  depends_on = [module.s3, module.kms]
+}
+
+# ------------------------------------------------------------------------------
+# Module: Monitoring
+# ------------------------------------------------------------------------------
+# Critical CloudWatch alarms, optionally fanned out to a central-region topic
+# Depends on: RDS module
+
+module "monitoring" {
+  source = "./modules/monitoring"
+
+  providers = {
+    aws         = aws
+    aws.central = aws.central
+  }
+
+  environment             = var.environment
+  name_suffix             = var.name_suffix
+  dns_suffix              = data.aws_partition.current.dns_suffix
+  rds_instance_identifier = module.rds.rds_identifier
+  alarm_email             = var.sns_alert_email
+  central_alarm_region    = var.central_alarm_region
+  tags                    = local.common_tags
+
+  rds_ca_cert_identifier   = module.rds.rds_ca_cert_identifier
+  acm_certificate_arns     = var.acm_certificate_arns
+  cert_expiry_warning_days = var.cert_expiry_warning_days
+
+  # Findings land beside the audit trail, encrypted with the master key
+  enable_siem_forwarding        = var.enable_siem_forwarding
+  siem_destination              = var.siem_destination
+  siem_s3_bucket_arn            = module.s3.s3_bucket_audit_logs_arn
+  siem_kms_key_arn              = module.kms.kms_master_key_arn
+  siem_http_endpoint_url        = var.siem_http_endpoint_url
+  siem_http_endpoint_access_key = var.siem_http_endpoint_access_key
+
+  enable_xray_tracing = var.enable_xray_tracing
+  xray_kms_key_arn    = var.enable_xray_tracing ? module.kms.kms_master_key_arn : ""
+  xray_sampling_rate  = var.xray_sampling_rate
+}
+
+# ------------------------------------------------------------------------------
+# Module: Dashboard (Conditional)
+# ------------------------------------------------------------------------------
+# Stack health dashboard for operators
+# Depends on: RDS, S3 modules
+
+module "dashboard" {
+  count  = var.enable_dashboard ? 1 : 0
+  source = "./modules/dashboard"
+
+  environment             = var.environment
+  name_suffix             = var.name_suffix
+  rds_instance_identifier = module.rds.rds_identifier
+  tags                    = local.common_tags
+
+  s3_bucket_names = {
+    documents  = module.s3.s3_bucket_documents
+    backups    = module.s3.s3_bucket_backups
+    audit_logs = module.s3.s3_bucket_audit_logs
+  }
+  documents_bucket_name = var.enable_s3_request_metrics ? module.s3.s3_bucket_documents : ""
+}
+
+# ------------------------------------------------------------------------------
+# Module: Railway Environment File
+# ------------------------------------------------------------------------------
+# Renders connection settings as a dotenv file for the Railway deploy step;
+# credentials are referenced by SSM parameter path, never written in plaintext
+# Depends on: RDS, S3, KMS modules
+
+module "railway_env" {
+  source = "./modules/railway_env"
+
+  env_vars = merge(
+    {
+      AWS_REGION          = local.aws_region
+      DATABASE_HOST       = module.rds.rds_address
+      DATABASE_PORT       = tostring(module.rds.rds_port)
+      DATABASE_NAME       = module.rds.rds_db_name
+      S3_DOCUMENTS_BUCKET = module.s3.s3_bucket_documents
+      KMS_MASTER_KEY_ARN  = module.kms.kms_master_key_arn
+      IAM_ROLE_ARN        = module.iam.app_iam_role_arn
+    },
+    var.app_enable_presigned_urls ? {
+      PRESIGNED_URL_MAX_EXPIRY_SECONDS = tostring(module.iam.presigned_url_max_expiry_seconds)
+    } : {}
+  )
+
+  secret_ssm_parameters = {
+    DATABASE_USERNAME = module.rds.rds_username_ssm_parameter
+    DATABASE_PASSWORD = module.rds.rds_password_ssm_parameter
+  }
+
+  write_env_file = var.write_env_file
+  env_file_path  = var.env_file_path != "" ? var.env_file_path : "${path.root}/.env.railway.${var.environment}"
+}
@@ -0,0 +1,136 @@
+# ==============================================================================
+# CloudTrail Module - Tamper-Evident Audit Trail
+# ==============================================================================
+# Purpose: Record API activity across all regions to the audit logs bucket,
+# encrypted with the KMS master key and protected by log file validation
+# Dependencies: Requires KMS master key and S3 audit logs bucket
+# ==============================================================================
+
+locals {
+  # Construct environment label with optional suffix for test isolation
+  env_label   = var.environment
+  full_suffix = var.name_suffix == "" ? local.env_label : "${local.env_label}-${var.name_suffix}"
+
+  trail_name = "hipaa-trail-${local.full_suffix}"
+
+  cloudwatch_log_group_name = "/aws/cloudtrail/${local.trail_name}"
+
+  common_tags = merge(
+    var.tags,
+    {
+      Module      = "cloudtrail"
+      Environment = var.environment
+      Context     = var.name_suffix
+      ManagedBy   = "Terraform"
+    }
+  )
+}
+
+# ------------------------------------------------------------------------------
+# CloudWatch Logs Delivery (Conditional)
+# ------------------------------------------------------------------------------
+# The S3 copy is the long-term record; the log group only needs to live long
+# enough for alarms and investigations, so retention is always finite.
+resource "aws_cloudwatch_log_group" "cloudtrail" {
+  count = var.enable_cloudwatch_logs ? 1 : 0
+
+  name              = local.cloudwatch_log_group_name
+  retention_in_days = var.cloudtrail_log_retention_days
+  kms_key_id        = var.kms_key_arn
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = local.cloudwatch_log_group_name
+    }
+  )
+}
+
+resource "aws_iam_role" "cloudtrail_logs" {
+  count = var.enable_cloudwatch_logs ? 1 : 0
+
+  name        = "${local.trail_name}-logs-role"
+  description = "Allows CloudTrail ${local.trail_name} to deliver events to CloudWatch Logs"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Principal = {
+          Service = "cloudtrail.${var.dns_suffix}"
+        }
+        Action = "sts:AssumeRole"
+      }
+    ]
+  })
+
+  tags = local.common_tags
+}
+
+resource "aws_iam_role_policy" "cloudtrail_logs" {
+  count = var.enable_cloudwatch_logs ? 1 : 0
+
+  name = "${local.trail_name}-logs"
+  role = aws_iam_role.cloudtrail_logs[0].id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid    = "DeliverTrailEvents"
+        Effect = "Allow"
+        Action = [
+          "logs:CreateLogStream",
+          "logs:PutLogEvents"
+        ]
+        Resource = "${aws_cloudwatch_log_group.cloudtrail[0].arn}:log-stream:*"
+      }
+    ]
+  })
+}
+
+# ------------------------------------------------------------------------------
+# CloudTrail Trail
+# ------------------------------------------------------------------------------
+resource "aws_cloudtrail" "main" {
+  name           = local.trail_name
+  s3_bucket_name = var.s3_bucket_audit_logs
+  s3_key_prefix  = "cloudtrail"
+  kms_key_id     = var.kms_key_arn
+
+  # Tamper evidence: digest files let auditors prove logs were not modified
+  enable_log_file_validation    = true
+  is_multi_region_trail         = var.is_multi_region_trail
+  include_global_service_events = true
+  enable_logging                = true
+
+  # Optional near-real-time copy in CloudWatch Logs for metric filters and alarms
+  cloud_watch_logs_group_arn = var.enable_cloudwatch_logs ? "${aws_cloudwatch_log_group.cloudtrail[0].arn}:*" : null
+  cloud_watch_logs_role_arn  = var.enable_cloudwatch_logs ? aws_iam_role.cloudtrail_logs[0].arn : null
+
+  # Object-level (data event) logging for PHI buckets; management events stay on
+  dynamic "event_selector" {
+    for_each = length(var.data_event_bucket_arns) > 0 ? [1] : []
+
+    content {
+      read_write_type           = "All"
+      include_management_events = true
+
+      data_resource {
+        type   = "AWS::S3::Object"
+        values = [for arn in var.data_event_bucket_arns : "${arn}/"]
+      }
+    }
+  }
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = local.trail_name
+    }
+  )
+
+  # CloudTrail validates the role can write to the group when the trail is updated
+  depends_on = [aws_iam_role_policy.cloudtrail_logs]
+}
@@ -0,0 +1,28 @@
+# ==============================================================================
+# CloudTrail Module - Output Values
+# ==============================================================================
+
+output "cloudtrail_name" {
+  value       = aws_cloudtrail.main.name
+  description = "Name of the CloudTrail trail"
+}
+
+output "cloudtrail_arn" {
+  value       = aws_cloudtrail.main.arn
+  description = "ARN of the CloudTrail trail"
+}
+
+output "data_event_bucket_arns" {
+  value       = var.data_event_bucket_arns
+  description = "S3 bucket ARNs with object-level data event logging"
+}
+
+output "cloudwatch_log_group_name" {
+  value       = var.enable_cloudwatch_logs ? aws_cloudwatch_log_group.cloudtrail[0].name : ""
+  description = "CloudWatch Logs group receiving trail events (empty if disabled)"
+}
+
+output "cloudtrail_home_region" {
+  value       = aws_cloudtrail.main.home_region
+  description = "Region in which the trail was created"
+}
@@ -0,0 +1,95 @@
+# ==============================================================================
+# CloudTrail Module - Input Variables
+# ==============================================================================
+
+variable "environment" {
+  type        = string
+  description = "Deployment tier (dev, staging, production)"
+
+  validation {
+    condition     = contains(["dev", "staging", "production"], var.environment)
+    error_message = "Environment must be one of dev, staging, production."
+  }
+}
+
+variable "name_suffix" {
+  type        = string
+  default     = ""
+  description = "Optional suffix for resource names (tests/ephemeral runs)"
+
+  validation {
+    condition     = can(regex("^[a-z0-9-]*$", var.name_suffix))
+    error_message = "name_suffix may contain only lowercase letters, digits, and hyphens."
+  }
+}
+
+variable "s3_bucket_audit_logs" {
+  type        = string
+  description = "S3 bucket name for CloudTrail log delivery (must allow cloudtrail.amazonaws.com writes under cloudtrail/)"
+
+  validation {
+    condition     = can(regex("^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$", var.s3_bucket_audit_logs))
+    error_message = "s3_bucket_audit_logs must be an S3 bucket name, not empty or an ARN"
+  }
+}
+
+variable "kms_key_arn" {
+  type        = string
+  description = "ARN of the KMS key used to encrypt CloudTrail log files"
+
+  validation {
+    condition     = can(regex("^arn:aws[a-z-]*:kms:[a-z0-9-]+:[0-9]{12}:key/.+$", var.kms_key_arn))
+    error_message = "Must be a valid KMS key ARN"
+  }
+}
+
+variable "is_multi_region_trail" {
+  type        = bool
+  description = "Capture API activity from all regions (recommended for HIPAA audit coverage)"
+  default     = true
+}
+
+variable "data_event_bucket_arns" {
+  type        = list(string)
+  description = "S3 bucket ARNs whose object-level reads and writes are recorded as CloudTrail data events"
+  default     = []
+
+  validation {
+    condition     = alltrue([for arn in var.data_event_bucket_arns : can(regex("^arn:aws[a-z-]*:s3:::[a-z0-9.-]+$", arn))])
+    error_message = "data_event_bucket_arns must contain S3 bucket ARNs (arn:aws:s3:::bucket-name)."
+  }
+}
+
+variable "enable_cloudwatch_logs" {
+  type        = bool
+  description = "Also deliver trail events to a KMS-encrypted CloudWatch Logs group for metric filters and alarms"
+  default     = false
+}
+
+variable "cloudtrail_log_retention_days" {
+  type        = number
+  description = "Retention (days) of the CloudTrail CloudWatch Logs group; must be finite, the S3 copy is the long-term record"
+  default     = 365
+
+  validation {
+    condition     = contains([1, 3, 5, 7, 14, 30, 60, 90, 120, 150, 180, 365, 400, 545, 731, 1096, 1827, 2192, 2557, 2922, 3288, 3653], var.cloudtrail_log_retention_days)
+    error_message = "cloudtrail_log_retention_days must be a CloudWatch Logs retention value (1-3653 days); unbounded retention is not allowed"
+  }
+}
+
+variable "dns_suffix" {
+  type        = string
+  description = "DNS suffix of the partition used to build service principals (amazonaws.com.cn in China)"
+  default     = "amazonaws.com"
+
+  validation {
+    condition     = contains(["amazonaws.com", "amazonaws.com.cn"], var.dns_suffix)
+    error_message = "dns_suffix must be amazonaws.com or amazonaws.com.cn"
+  }
+}
+
+variable "tags" {
+  type        = map(string)
+  description = "Additional resource tags"
+  default     = {}
+}
@@ -10,6 +10,36 @@ locals {
   env_label   = var.environment
   full_suffix = var.name_suffix == "" ? local.env_label : "${local.env_label}-${var.name_suffix}"
 
+  # Encryption and public-access rules evaluate on every configuration change
+  # for fast detection; account-level checks run periodically.
+  # config_rule_evaluation_mode overrides individual rules.
+  default_rule_evaluation_modes = {
+    s3_encryption       = "configuration_change"
+    rds_encryption      = "configuration_change"
+    rds_public_access   = "configuration_change"
+    iam_no_admin_access = "configuration_change"
+    cloudtrail_enabled  = "periodic"
+    vpc_sg_authorized   = "configuration_change"
+    s3_public_access    = "configuration_change"
+    log_group_encrypted = "periodic"
+  }
+  rule_evaluation_modes = merge(local.default_rule_evaluation_modes, var.config_rule_evaluation_mode)
+
+  # Periodic rules run daily; null leaves change-triggered rules without a schedule
+  rule_execution_frequency = {
+    for rule, mode in local.rule_evaluation_modes : rule => mode == "periodic" ? "TwentyFour_Hours" : null
+  }
+
+  # Snapshots and history go to the audit bucket unless a separate
+  # compliance bucket is named
+  snapshot_bucket = var.config_snapshot_bucket != "" ? var.config_snapshot_bucket : var.s3_bucket_audit_logs
+
+  # Service principals use the partition's DNS suffix (amazonaws.com.cn in China)
+  service_principals = {
+    config = "config.${var.dns_suffix}"
+    ssm    = "ssm.${var.dns_suffix}"
+  }
+
   common_tags = merge(
     var.tags,
     {
@@ -33,7 +63,7 @@ resource "aws_iam_role" "config" {
       {
         Effect = "Allow"
         Principal = {
-          Service = "config.amazonaws.com"
+          Service = local.service_principals["config"]
         }
         Action = "sts:AssumeRole"
       }
@@ -51,7 +81,7 @@ resource "aws_iam_role" "config" {
 # Attach AWS managed Config policy
 resource "aws_iam_role_policy_attachment" "config_managed_policy" {
   role       = aws_iam_role.config.name
-  policy_arn = "arn:aws:iam::aws:policy/service-role/ConfigRole"
+  policy_arn = "arn:${var.partition}:iam::aws:policy/service-role/ConfigRole"
 }
 
 # Custom policy for S3 bucket access
@@ -61,14 +91,14 @@ resource "aws_iam_role_policy" "config_s3_policy" {
 
   policy = jsonencode({
     Version = "2012-10-17"
-    Statement = [
+    Statement = concat([
       {
         Effect = "Allow"
         Action = [
           "s3:PutObject",
           "s3:PutObjectAcl"
         ]
-        Resource = "arn:aws:s3:::${var.s3_bucket_audit_logs}/*"
+        Resource = "arn:${var.partition}:s3:::${local.snapshot_bucket}/*"
         Condition = {
           StringLike = {
             "s3:x-amz-acl" = "bucket-owner-full-control"
@@ -80,9 +110,18 @@ resource "aws_iam_role_policy" "config_s3_policy" {
         Action = [
           "s3:GetBucketVersioning"
         ]
-        Resource = "arn:aws:s3:::${var.s3_bucket_audit_logs}"
+        Resource = "arn:${var.partition}:s3:::${local.snapshot_bucket}"
       }
-    ]
+      ], var.delivery_kms_key_arn != "" ? [
+      {
+        Effect = "Allow"
+        Action = [
+          "kms:Decrypt",
+          "kms:GenerateDataKey"
+        ]
+        Resource = var.delivery_kms_key_arn
+      }
+    ] : [])
   })
 }
 
@@ -104,7 +143,8 @@ resource "aws_config_configuration_recorder" "main" {
 # ------------------------------------------------------------------------------
 resource "aws_config_delivery_channel" "main" {
   name           = "${local.full_suffix}-config-delivery-channel"
-  s3_bucket_name = var.s3_bucket_audit_logs
+  s3_bucket_name = local.snapshot_bucket
+  s3_kms_key_arn = var.delivery_kms_key_arn != "" ? var.delivery_kms_key_arn : null
 
   snapshot_delivery_properties {
     delivery_frequency = "TwentyFour_Hours"
@@ -150,7 +190,7 @@ resource "aws_sns_topic_policy" "config_alerts" {
       {
         Effect = "Allow"
         Principal = {
-          Service = "config.amazonaws.com"
+          Service = local.service_principals["config"]
         }
         Action   = "SNS:Publish"
         Resource = aws_sns_topic.config_alerts.arn
@@ -181,6 +221,8 @@ resource "aws_config_config_rule" "s3_bucket_encryption" {
     source_identifier = "S3_BUCKET_SERVER_SIDE_ENCRYPTION_ENABLED"
   }
 
+  maximum_execution_frequency = local.rule_execution_frequency["s3_encryption"]
+
   depends_on = [aws_config_configuration_recorder_status.main]
 
   tags = merge(
@@ -202,6 +244,8 @@ resource "aws_config_config_rule" "rds_storage_encrypted" {
     source_identifier = "RDS_STORAGE_ENCRYPTED"
   }
 
+  maximum_execution_frequency = local.rule_execution_frequency["rds_encryption"]
+
   depends_on = [aws_config_configuration_recorder_status.main]
 
   tags = merge(
@@ -223,6 +267,8 @@ resource "aws_config_config_rule" "rds_public_access" {
     source_identifier = "RDS_INSTANCE_PUBLIC_ACCESS_CHECK"
   }
 
+  maximum_execution_frequency = local.rule_execution_frequency["rds_public_access"]
+
   depends_on = [aws_config_configuration_recorder_status.main]
 
   tags = merge(
@@ -244,6 +290,8 @@ resource "aws_config_config_rule" "iam_policy_no_admin_access" {
     source_identifier = "IAM_POLICY_NO_STATEMENTS_WITH_ADMIN_ACCESS"
   }
 
+  maximum_execution_frequency = local.rule_execution_frequency["iam_no_admin_access"]
+
   depends_on = [aws_config_configuration_recorder_status.main]
 
   tags = merge(
@@ -265,6 +313,8 @@ resource "aws_config_config_rule" "cloudtrail_enabled" {
     source_identifier = "CLOUD_TRAIL_ENABLED"
   }
 
+  maximum_execution_frequency = local.rule_execution_frequency["cloudtrail_enabled"]
+
   depends_on = [aws_config_configuration_recorder_status.main]
 
   tags = merge(
@@ -286,6 +336,8 @@ resource "aws_config_config_rule" "vpc_sg_authorized_ports" {
     source_identifier = "VPC_SG_OPEN_ONLY_TO_AUTHORIZED_PORTS"
   }
 
+  maximum_execution_frequency = local.rule_execution_frequency["vpc_sg_authorized"]
+
   scope {
     compliance_resource_types = ["AWS::EC2::SecurityGroup"]
   }
@@ -304,3 +356,253 @@ resource "aws_config_config_rule" "vpc_sg_authorized_ports" {
     }
   )
 }
+
+# Rule 7: S3 Bucket Level Public Access Prohibited
+resource "aws_config_config_rule" "s3_bucket_public_access" {
+  name        = "${local.full_suffix}-s3-bucket-level-public-access-prohibited"
+  description = "Checks that S3 buckets block public access at the bucket level"
+
+  source {
+    owner             = "AWS"
+    source_identifier = "S3_BUCKET_LEVEL_PUBLIC_ACCESS_PROHIBITED"
+  }
+
+  maximum_execution_frequency = local.rule_execution_frequency["s3_public_access"]
+
+  depends_on = [aws_config_configuration_recorder_status.main]
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name       = "${local.full_suffix}-s3-bucket-level-public-access-prohibited"
+      Compliance = "HIPAA"
+    }
+  )
+}
+
+# Rule 8: CloudWatch Log Group Encrypted
+# Managed rule only supports periodic evaluation
+resource "aws_config_config_rule" "cloudwatch_log_group_encrypted" {
+  name        = "${local.full_suffix}-cloudwatch-log-group-encrypted"
+  description = "Checks that CloudWatch log groups are encrypted with a KMS key"
+
+  source {
+    owner             = "AWS"
+    source_identifier = "CLOUDWATCH_LOG_GROUP_ENCRYPTED"
+  }
+
+  maximum_execution_frequency = local.rule_execution_frequency["log_group_encrypted"]
+
+  depends_on = [aws_config_configuration_recorder_status.main]
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name       = "${local.full_suffix}-cloudwatch-log-group-encrypted"
+      Compliance = "HIPAA"
+    }
+  )
+}
+
+# ------------------------------------------------------------------------------
+# Auto-Remediation (Conditional)
+# ------------------------------------------------------------------------------
+# SSM Automation re-applies encryption and the public access block on
+# non-compliant buckets and turns off public access on RDS instances.
+# Disabled by default; enable after validating in dev.
+
+resource "aws_iam_role" "remediation" {
+  count       = var.enable_auto_remediation ? 1 : 0
+  name        = "${local.full_suffix}-config-remediation-role"
+  description = "IAM role assumed by SSM Automation for Config remediation"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Principal = {
+          Service = local.service_principals["ssm"]
+        }
+        Action = "sts:AssumeRole"
+      }
+    ]
+  })
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${local.full_suffix}-config-remediation-role"
+    }
+  )
+}
+
+resource "aws_iam_role_policy" "remediation" {
+  count = var.enable_auto_remediation ? 1 : 0
+  name  = "${local.full_suffix}-config-remediation-policy"
+  role  = aws_iam_role.remediation[0].id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = concat([
+      {
+        Effect = "Allow"
+        Action = [
+          "s3:GetEncryptionConfiguration",
+          "s3:PutEncryptionConfiguration",
+          "s3:GetBucketPublicAccessBlock",
+          "s3:PutBucketPublicAccessBlock"
+        ]
+        Resource = "arn:${var.partition}:s3:::*"
+      },
+      {
+        Effect = "Allow"
+        Action = [
+          "rds:DescribeDBInstances",
+          "rds:ModifyDBInstance"
+        ]
+        Resource = "*"
+      }
+      ], var.remediation_kms_key_arn != "" ? [
+      {
+        Effect = "Allow"
+        Action = [
+          "kms:DescribeKey",
+          "kms:GenerateDataKey"
+        ]
+        Resource = var.remediation_kms_key_arn
+      }
+    ] : [])
+  })
+}
+
+resource "aws_config_remediation_configuration" "s3_bucket_encryption" {
+  count            = var.enable_auto_remediation ? 1 : 0
+  config_rule_name = aws_config_config_rule.s3_bucket_encryption.name
+  resource_type    = "AWS::S3::Bucket"
+  target_type      = "SSM_DOCUMENT"
+  target_id        = "AWS-EnableS3BucketEncryption"
+
+  parameter {
+    name         = "AutomationAssumeRole"
+    static_value = aws_iam_role.remediation[0].arn
+  }
+
+  parameter {
+    name           = "BucketName"
+    resource_value = "RESOURCE_ID"
+  }
+
+  parameter {
+    name         = "SSEAlgorithm"
+    static_value = var.remediation_kms_key_arn != "" ? "aws:kms" : "AES256"
+  }
+
+  dynamic "parameter" {
+    for_each = var.remediation_kms_key_arn != "" ? [var.remediation_kms_key_arn] : []
+    content {
+      name         = "KMSMasterKey"
+      static_value = parameter.value
+    }
+  }
+
+  automatic                  = true
+  maximum_automatic_attempts = var.remediation_max_attempts
+  retry_attempt_seconds      = 60
+}
+
+resource "aws_config_remediation_configuration" "s3_bucket_public_access" {
+  count            = var.enable_auto_remediation ? 1 : 0
+  config_rule_name = aws_config_config_rule.s3_bucket_public_access.name
+  resource_type    = "AWS::S3::Bucket"
+  target_type      = "SSM_DOCUMENT"
+  target_id        = "AWSConfigRemediation-ConfigureS3BucketPublicAccessBlock"
+
+  parameter {
+    name         = "AutomationAssumeRole"
+    static_value = aws_iam_role.remediation[0].arn
+  }
+
+  parameter {
+    name           = "BucketName"
+    resource_value = "RESOURCE_ID"
+  }
+
+  automatic                  = true
+  maximum_automatic_attempts = var.remediation_max_attempts
+  retry_attempt_seconds      = 60
+}
+
+resource "aws_config_remediation_configuration" "rds_public_access" {
+  count            = var.enable_auto_remediation ? 1 : 0
+  config_rule_name = aws_config_config_rule.rds_public_access.name
+  resource_type    = "AWS::RDS::DBInstance"
+  target_type      = "SSM_DOCUMENT"
+  target_id        = "AWSConfigRemediation-DisablePublicAccessToRDSInstance"
+
+  parameter {
+    name         = "AutomationAssumeRole"
+    static_value = aws_iam_role.remediation[0].arn
+  }
+
+  # Config records RDS instances by their DbiResourceId
+  parameter {
+    name           = "DbiResourceId"
+    resource_value = "RESOURCE_ID"
+  }
+
+  automatic                  = true
+  maximum_automatic_attempts = var.remediation_max_attempts
+  retry_attempt_seconds      = 60
+}
+
+# ------------------------------------------------------------------------------
+# Configuration Aggregator (Conditional)
+# ------------------------------------------------------------------------------
+# Rolls up compliance data from other accounts/regions into this account.
+# Each source account must authorize this account (aggregate authorization)
+# before its data appears.
+
+resource "aws_config_configuration_aggregator" "main" {
+  count = var.enable_config_aggregator ? 1 : 0
+  name  = "${local.full_suffix}-config-aggregator"
+
+  account_aggregation_source {
+    account_ids = var.aggregator_account_ids
+    all_regions = length(var.aggregator_regions) == 0
+    regions     = length(var.aggregator_regions) > 0 ? var.aggregator_regions : null
+  }
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${local.full_suffix}-config-aggregator"
+    }
+  )
+
+  lifecycle {
+    precondition {
+      condition     = length(var.aggregator_account_ids) > 0
+      error_message = "enable_config_aggregator requires at least one account in aggregator_account_ids."
+    }
+  }
+}
+
+# ------------------------------------------------------------------------------
+# Aggregation Authorization (Conditional)
+# ------------------------------------------------------------------------------
+# Source-account side of the rollup: lets the central aggregator account
+# collect this account's Config data from each listed region.
+
+resource "aws_config_aggregate_authorization" "central" {
+  for_each = var.aggregator_authorized_account_id == "" ? toset([]) : toset(var.aggregator_authorized_regions)
+
+  account_id = var.aggregator_authorized_account_id
+  region     = each.value
+
+  tags = local.common_tags
+}
+
+# ==============================================================================
+# Data Sources
+# ==============================================================================
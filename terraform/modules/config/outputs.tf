@@ -22,6 +22,11 @@ output "config_delivery_channel_name" {
   description = "Name of the AWS Config delivery channel"
 }
 
+output "config_snapshot_bucket" {
+  value       = aws_config_delivery_channel.main.s3_bucket_name
+  description = "Bucket Config delivers snapshots and history to (the audit bucket unless config_snapshot_bucket is set)"
+}
+
 output "config_rules" {
   value = {
     s3_encryption       = aws_config_config_rule.s3_bucket_encryption.name
@@ -30,6 +35,42 @@ output "config_rules" {
     iam_no_admin_access = aws_config_config_rule.iam_policy_no_admin_access.name
     cloudtrail_enabled  = aws_config_config_rule.cloudtrail_enabled.name
     vpc_sg_authorized   = aws_config_config_rule.vpc_sg_authorized_ports.name
+    s3_public_access    = aws_config_config_rule.s3_bucket_public_access.name
+    log_group_encrypted = aws_config_config_rule.cloudwatch_log_group_encrypted.name
   }
   description = "Map of AWS Config rule names for HIPAA compliance monitoring"
 }
+
+output "config_rule_evaluation_modes" {
+  value       = local.rule_evaluation_modes
+  description = "Map of rule key to evaluation mode (configuration_change or periodic)"
+}
+
+output "remediation_configuration_ids" {
+  value = var.enable_auto_remediation ? {
+    s3_encryption     = aws_config_remediation_configuration.s3_bucket_encryption[0].id
+    s3_public_access  = aws_config_remediation_configuration.s3_bucket_public_access[0].id
+    rds_public_access = aws_config_remediation_configuration.rds_public_access[0].id
+  } : {}
+  description = "Map of rule key to remediation configuration ID (empty if auto-remediation is disabled)"
+}
+
+output "remediation_role_arn" {
+  value       = var.enable_auto_remediation ? aws_iam_role.remediation[0].arn : ""
+  description = "ARN of the IAM role assumed by SSM Automation for remediation (empty if disabled)"
+}
+
+output "config_aggregator_arn" {
+  value       = var.enable_config_aggregator ? aws_config_configuration_aggregator.main[0].arn : ""
+  description = "ARN of the Config configuration aggregator (empty if disabled)"
+}
+
+output "config_aggregate_authorization_arns" {
+  value       = { for region, authorization in aws_config_aggregate_authorization.central : region => authorization.arn }
+  description = "Aggregation authorization ARNs for the central account, keyed by region (empty if not authorized)"
+}
+
+output "service_principals" {
+  value       = local.service_principals
+  description = "Partition-aware service principals used in Config and remediation trust policies"
+}
@@ -26,6 +26,33 @@ variable "name_suffix" {
 variable "s3_bucket_audit_logs" {
   type        = string
   description = "S3 bucket name for AWS Config snapshots and configuration history"
+
+  validation {
+    condition     = can(regex("^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$", var.s3_bucket_audit_logs))
+    error_message = "s3_bucket_audit_logs must be an S3 bucket name, not empty or an ARN"
+  }
+}
+
+variable "config_snapshot_bucket" {
+  type        = string
+  description = "Separate compliance bucket for Config snapshots and history (empty delivers to s3_bucket_audit_logs). Its bucket policy must allow config.amazonaws.com delivery"
+  default     = ""
+
+  validation {
+    condition     = var.config_snapshot_bucket == "" || can(regex("^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$", var.config_snapshot_bucket))
+    error_message = "config_snapshot_bucket must be an S3 bucket name, not an ARN"
+  }
+}
+
+variable "delivery_kms_key_arn" {
+  type        = string
+  description = "KMS key ARN Config uses to encrypt delivered snapshots and history (empty uses the bucket's default encryption)"
+  default     = ""
+
+  validation {
+    condition     = var.delivery_kms_key_arn == "" || can(regex("^arn:aws[a-z-]*:kms:[a-z0-9-]+:[0-9]{12}:key/.+$", var.delivery_kms_key_arn))
+    error_message = "delivery_kms_key_arn must be a valid KMS key ARN"
+  }
 }
 
 variable "sns_alert_email" {
@@ -34,6 +61,115 @@ variable "sns_alert_email" {
   default     = ""
 }
 
+variable "config_rule_evaluation_mode" {
+  type        = map(string)
+  description = "Per-rule evaluation mode override keyed like the config_rules output: configuration_change or periodic (daily)"
+  default     = {}
+
+  validation {
+    condition     = alltrue([for mode in values(var.config_rule_evaluation_mode) : contains(["configuration_change", "periodic"], mode)])
+    error_message = "config_rule_evaluation_mode values must be configuration_change or periodic"
+  }
+
+  validation {
+    condition = alltrue([
+      for rule in keys(var.config_rule_evaluation_mode) :
+      contains(["s3_encryption", "rds_encryption", "rds_public_access", "iam_no_admin_access", "cloudtrail_enabled", "vpc_sg_authorized", "s3_public_access"], rule)
+    ])
+    error_message = "config_rule_evaluation_mode keys must match the config_rules output keys"
+  }
+}
+
+variable "enable_auto_remediation" {
+  type        = bool
+  description = "Attach SSM Automation remediation to the S3 encryption, S3 public access and RDS public access rules"
+  default     = false
+}
+
+variable "remediation_kms_key_arn" {
+  type        = string
+  description = "KMS key ARN used when remediation re-enables bucket encryption (empty uses AES256)"
+  default     = ""
+
+  validation {
+    condition     = var.remediation_kms_key_arn == "" || can(regex("^arn:aws[a-z-]*:kms:[a-z0-9-]+:[0-9]{12}:key/.+$", var.remediation_kms_key_arn))
+    error_message = "remediation_kms_key_arn must be a valid KMS key ARN"
+  }
+}
+
+variable "remediation_max_attempts" {
+  type        = number
+  description = "Maximum automatic remediation attempts per non-compliant resource"
+  default     = 3
+
+  validation {
+    condition     = var.remediation_max_attempts >= 1 && var.remediation_max_attempts <= 25
+    error_message = "remediation_max_attempts must be between 1 and 25"
+  }
+}
+
+variable "enable_config_aggregator" {
+  type        = bool
+  description = "Create a Config aggregator that rolls up compliance data from aggregator_account_ids into this account"
+  default     = false
+}
+
+variable "aggregator_account_ids" {
+  type        = list(string)
+  description = "Source account IDs for the Config aggregator"
+  default     = []
+
+  validation {
+    condition     = alltrue([for id in var.aggregator_account_ids : can(regex("^[0-9]{12}$", id))])
+    error_message = "aggregator_account_ids must be 12-digit AWS account IDs"
+  }
+}
+
+variable "aggregator_regions" {
+  type        = list(string)
+  description = "Source regions for the Config aggregator (empty aggregates all regions)"
+  default     = []
+}
+
+variable "aggregator_authorized_account_id" {
+  type        = string
+  description = "Central account allowed to aggregate this account's Config data (empty skips authorization)"
+  default     = ""
+
+  validation {
+    condition     = var.aggregator_authorized_account_id == "" || can(regex("^[0-9]{12}$", var.aggregator_authorized_account_id))
+    error_message = "aggregator_authorized_account_id must be a 12-digit AWS account ID"
+  }
+}
+
+variable "aggregator_authorized_regions" {
+  type        = list(string)
+  description = "Regions of the central aggregator authorized by aggregator_authorized_account_id"
+  default     = []
+}
+
+variable "partition" {
+  type        = string
+  description = "AWS partition used to build ARNs (aws, aws-us-gov or aws-cn)"
+  default     = "aws"
+
+  validation {
+    condition     = contains(["aws", "aws-us-gov", "aws-cn"], var.partition)
+    error_message = "partition must be aws, aws-us-gov or aws-cn"
+  }
+}
+
+variable "dns_suffix" {
+  type        = string
+  description = "DNS suffix of the partition used to build service principals (amazonaws.com.cn in China)"
+  default     = "amazonaws.com"
+
+  validation {
+    condition     = contains(["amazonaws.com", "amazonaws.com.cn"], var.dns_suffix)
+    error_message = "dns_suffix must be amazonaws.com or amazonaws.com.cn"
+  }
+}
+
 variable "tags" {
   type        = map(string)
   description = "Additional resource tags to apply to all Config resources"
@@ -0,0 +1,151 @@
+# ==============================================================================
+# Dashboard Module - Stack Health
+# ==============================================================================
+# Purpose: One CloudWatch dashboard for operators covering RDS health, S3
+# storage and request errors, and GuardDuty finding volume
+# Dependencies: RDS instance and S3 buckets (referenced by name only)
+# ==============================================================================
+
+locals {
+  # Construct environment label with optional suffix for test isolation
+  env_label   = var.environment
+  full_suffix = var.name_suffix == "" ? local.env_label : "${local.env_label}-${var.name_suffix}"
+
+  dashboard_name = "hipaa-stack-health-${local.full_suffix}"
+
+  region = data.aws_region.current.name
+
+  rds_widgets = [
+    {
+      type   = "metric"
+      width  = 8
+      height = 6
+      properties = {
+        title   = "RDS CPU Utilization"
+        region  = local.region
+        stat    = "Average"
+        period  = 300
+        metrics = [["AWS/RDS", "CPUUtilization", "DBInstanceIdentifier", var.rds_instance_identifier]]
+      }
+    },
+    {
+      type   = "metric"
+      width  = 8
+      height = 6
+      properties = {
+        title   = "RDS Database Connections"
+        region  = local.region
+        stat    = "Average"
+        period  = 300
+        metrics = [["AWS/RDS", "DatabaseConnections", "DBInstanceIdentifier", var.rds_instance_identifier]]
+      }
+    },
+    {
+      type   = "metric"
+      width  = 8
+      height = 6
+      properties = {
+        title   = "RDS Free Storage Space"
+        region  = local.region
+        stat    = "Minimum"
+        period  = 300
+        metrics = [["AWS/RDS", "FreeStorageSpace", "DBInstanceIdentifier", var.rds_instance_identifier]]
+      }
+    }
+  ]
+
+  # Storage metrics are published daily for every bucket without configuration
+  s3_storage_widgets = length(var.s3_bucket_names) > 0 ? [
+    {
+      type   = "metric"
+      width  = 12
+      height = 6
+      properties = {
+        title  = "S3 Bucket Size"
+        region = local.region
+        stat   = "Average"
+        period = 86400
+        metrics = [
+          for label, bucket in var.s3_bucket_names :
+          ["AWS/S3", "BucketSizeBytes", "BucketName", bucket, "StorageType", "StandardStorage", { label = label }]
+        ]
+      }
+    }
+  ] : []
+
+  s3_request_widgets = var.documents_bucket_name != "" ? [
+    {
+      type   = "metric"
+      width  = 12
+      height = 6
+      properties = {
+        title  = "S3 Documents Requests and Errors"
+        region = local.region
+        stat   = "Sum"
+        period = 300
+        metrics = [
+          for metric in ["AllRequests", "4xxErrors", "5xxErrors"] :
+          ["AWS/S3", metric, "BucketName", var.documents_bucket_name, "FilterId", var.documents_request_metrics_filter]
+        ]
+      }
+    }
+  ] : []
+
+  # GuardDuty publishes no CloudWatch metrics; findings are counted as
+  # matches of the rule below
+  security_widgets = [
+    {
+      type   = "metric"
+      width  = 24
+      height = 6
+      properties = {
+        title   = "GuardDuty Findings"
+        region  = local.region
+        stat    = "Sum"
+        period  = 3600
+        metrics = [["AWS/Events", "MatchedEvents", "RuleName", aws_cloudwatch_event_rule.guardduty_findings.name]]
+      }
+    }
+  ]
+
+  common_tags = merge(
+    var.tags,
+    {
+      Module      = "dashboard"
+      Environment = var.environment
+      Context     = var.name_suffix
+      ManagedBy   = "Terraform"
+    }
+  )
+}
+
+data "aws_region" "current" {}
+
+# ------------------------------------------------------------------------------
+# GuardDuty Finding Counter
+# ------------------------------------------------------------------------------
+# Target-less rule: its MatchedEvents metric is the finding count
+
+resource "aws_cloudwatch_event_rule" "guardduty_findings" {
+  name        = "hipaa-dashboard-guardduty-${local.full_suffix}"
+  description = "Counts GuardDuty findings for the stack health dashboard"
+
+  event_pattern = jsonencode({
+    source      = ["aws.guardduty"]
+    detail-type = ["GuardDuty Finding"]
+  })
+
+  tags = local.common_tags
+}
+
+# ------------------------------------------------------------------------------
+# Stack Health Dashboard
+# ------------------------------------------------------------------------------
+
+resource "aws_cloudwatch_dashboard" "main" {
+  dashboard_name = local.dashboard_name
+
+  dashboard_body = jsonencode({
+    widgets = concat(local.rds_widgets, local.s3_storage_widgets, local.s3_request_widgets, local.security_widgets)
+  })
+}
@@ -0,0 +1,18 @@
+# ==============================================================================
+# Dashboard Module - Output Values
+# ==============================================================================
+
+output "dashboard_name" {
+  value       = aws_cloudwatch_dashboard.main.dashboard_name
+  description = "Name of the stack health CloudWatch dashboard"
+}
+
+output "dashboard_arn" {
+  value       = aws_cloudwatch_dashboard.main.dashboard_arn
+  description = "ARN of the stack health CloudWatch dashboard"
+}
+
+output "guardduty_event_rule_name" {
+  value       = aws_cloudwatch_event_rule.guardduty_findings.name
+  description = "EventBridge rule whose MatchedEvents metric counts GuardDuty findings"
+}
@@ -0,0 +1,58 @@
+# ==============================================================================
+# Dashboard Module - Input Variables
+# ==============================================================================
+
+variable "environment" {
+  type        = string
+  description = "Deployment tier (dev, staging, production)"
+
+  validation {
+    condition     = contains(["dev", "staging", "production"], var.environment)
+    error_message = "Environment must be one of dev, staging, production."
+  }
+}
+
+variable "name_suffix" {
+  type        = string
+  default     = ""
+  description = "Optional suffix for resource names (tests/ephemeral runs)"
+
+  validation {
+    condition     = can(regex("^[a-z0-9-]*$", var.name_suffix))
+    error_message = "name_suffix may contain only lowercase letters, digits, and hyphens."
+  }
+}
+
+variable "rds_instance_identifier" {
+  type        = string
+  description = "Identifier of the primary RDS instance shown on the dashboard"
+
+  validation {
+    condition     = can(regex("^[a-z][a-z0-9-]{0,62}$", var.rds_instance_identifier))
+    error_message = "rds_instance_identifier must be an RDS instance identifier, not empty or an ARN"
+  }
+}
+
+variable "s3_bucket_names" {
+  type        = map(string)
+  description = "Buckets whose storage metrics are graphed, keyed by display label (e.g. documents, backups)"
+  default     = {}
+}
+
+variable "documents_bucket_name" {
+  type        = string
+  description = "Documents bucket whose request metrics are graphed (empty omits the request widget)"
+  default     = ""
+}
+
+variable "documents_request_metrics_filter" {
+  type        = string
+  description = "Request metrics filter ID on the documents bucket; request metrics only exist when a filter is configured"
+  default     = "EntireBucket"
+}
+
+variable "tags" {
+  type        = map(string)
+  description = "Additional resource tags"
+  default     = {}
+}
@@ -0,0 +1,141 @@
+"""Mask PHI in databases restored into a non-production environment.
+
+Invoked by EventBridge "RDS DB Instance Event" notifications for instances
+named with RESTORE_PREFIX, in two passes:
+
+1. Restore finished (RESTORE_EVENT_IDS): move the instance into the isolation
+   security group, which only this function can reach, and reset its master
+   password to this environment's so production credentials never work here.
+2. Master password reset (RESET_EVENT_IDS): connect, anonymize every column in
+   MASKING_RULES in one transaction, then swap in EXPOSED_SECURITY_GROUP_IDS
+   so the app can reach the now-masked database.
+
+A failed masking pass leaves the instance isolated. Each step is recorded in
+the MaskingStatus tag on the instance and as JSON in STATUS_PARAMETER.
+"""
+
+import json
+import os
+from datetime import datetime, timezone
+
+import boto3
+import pg8000.native
+
+rds = boto3.client("rds")
+ssm = boto3.client("ssm")
+
+RESTORE_PREFIX = os.environ["RESTORE_PREFIX"]
+RESTORE_EVENT_IDS = os.environ["RESTORE_EVENT_IDS"].split(",")
+RESET_EVENT_IDS = os.environ["RESET_EVENT_IDS"].split(",")
+ISOLATION_SECURITY_GROUP_ID = os.environ["ISOLATION_SECURITY_GROUP_ID"]
+EXPOSED_SECURITY_GROUP_IDS = os.environ["EXPOSED_SECURITY_GROUP_IDS"].split(",")
+MASTER_USERNAME_PARAMETER = os.environ["MASTER_USERNAME_PARAMETER"]
+MASTER_PASSWORD_PARAMETER = os.environ["MASTER_PASSWORD_PARAMETER"]
+DATABASE_NAME = os.environ["DATABASE_NAME"]
+MASKING_RULES = json.loads(os.environ["MASKING_RULES"])
+STATUS_PARAMETER = os.environ["STATUS_PARAMETER"]
+
+# Replacement expressions per strategy; {col} is the quoted column. Hashes are
+# stable so masked values still join across tables.
+STRATEGIES = {
+    "redact": "'REDACTED'",
+    "null": "NULL",
+    "hash": "md5({col}::text)",
+    "email": "'masked+' || left(md5({col}::text), 12) || '@example.invalid'",
+    "phone": "'555-01' || lpad((abs(hashtext({col}::text)) % 100)::text, 2, '0')",
+    "date_year": "date_trunc('year', {col})",
+}
+
+
+def _quote(identifier):
+    return ".".join('"' + part.replace('"', '""') + '"' for part in identifier.split("."))
+
+
+def _parameter(name):
+    return ssm.get_parameter(Name=name, WithDecryption=True)["Parameter"]["Value"]
+
+
+def _record(instance, status, **details):
+    rds.add_tags_to_resource(ResourceName=instance["DBInstanceArn"], Tags=[{"Key": "MaskingStatus", "Value": status}])
+    job = {
+        "status": status,
+        "db_instance": instance["DBInstanceIdentifier"],
+        "updated_at": datetime.now(timezone.utc).isoformat(),
+        **details,
+    }
+    ssm.put_parameter(Name=STATUS_PARAMETER, Value=json.dumps(job), Type="String", Overwrite=True)
+    return job
+
+
+def _isolate(instance):
+    rds.modify_db_instance(
+        DBInstanceIdentifier=instance["DBInstanceIdentifier"],
+        VpcSecurityGroupIds=[ISOLATION_SECURITY_GROUP_ID],
+        MasterUserPassword=_parameter(MASTER_PASSWORD_PARAMETER),
+        PubliclyAccessible=False,
+        ApplyImmediately=True,
+    )
+    return _record(instance, "isolated")
+
+
+def _mask(instance):
+    tags = {tag["Key"]: tag["Value"] for tag in instance.get("TagList", [])}
+    if tags.get("MaskingStatus") != "isolated":
+        # Password resets on restores this function did not isolate, or already masked
+        return {"action": "ignored", "reason": f"masking status {tags.get('MaskingStatus', 'unset')}"}
+
+    groups = {group["VpcSecurityGroupId"] for group in instance["VpcSecurityGroups"]}
+    if groups != {ISOLATION_SECURITY_GROUP_ID}:
+        # Never connect to (or expose) a restore that other groups can still reach
+        return _record(instance, "failed", reason=f"not isolated: security groups {sorted(groups)}")
+    if instance["DBInstanceStatus"] != "available":
+        # Raising lets EventBridge's asynchronous retry try again once modification settles
+        raise RuntimeError(f"{instance['DBInstanceIdentifier']} is {instance['DBInstanceStatus']}")
+
+    _record(instance, "masking")
+    try:
+        connection = pg8000.native.Connection(
+            user=_parameter(MASTER_USERNAME_PARAMETER),
+            password=_parameter(MASTER_PASSWORD_PARAMETER),
+            host=instance["Endpoint"]["Address"],
+            port=instance["Endpoint"]["Port"],
+            database=DATABASE_NAME,
+            ssl_context=True,
+        )
+        rows = {}
+        try:
+            connection.run("BEGIN")
+            for rule in MASKING_RULES:
+                column = _quote(rule["column"])
+                replacement = STRATEGIES[rule["strategy"]].format(col=column)
+                connection.run(f"UPDATE {_quote(rule['table'])} SET {column} = {replacement} WHERE {column} IS NOT NULL")
+                rows[f"{rule['table']}.{rule['column']}"] = connection.row_count
+            connection.run("COMMIT")
+        finally:
+            connection.close()
+    except Exception as error:
+        # Any failure leaves the restore isolated; rerun by restoring again
+        return _record(instance, "failed", reason=str(error))
+
+    rds.modify_db_instance(
+        DBInstanceIdentifier=instance["DBInstanceIdentifier"],
+        VpcSecurityGroupIds=EXPOSED_SECURITY_GROUP_IDS,
+        ApplyImmediately=True,
+    )
+    return _record(instance, "completed", rules_applied=len(MASKING_RULES), rows_masked=rows)
+
+
+def handler(event, _context):
+    detail = event.get("detail", {})
+    identifier = detail.get("SourceIdentifier", "")
+    event_id = detail.get("EventID", "")
+    if not identifier.startswith(RESTORE_PREFIX):
+        return {"action": "ignored", "reason": "instance outside the restore prefix"}
+
+    instance = rds.describe_db_instances(DBInstanceIdentifier=identifier)["DBInstances"][0]
+
+    if event_id in RESTORE_EVENT_IDS:
+        return _isolate(instance)
+    if event_id in RESET_EVENT_IDS:
+        return _mask(instance)
+    return {"action": "ignored", "reason": f"event {event_id}"}
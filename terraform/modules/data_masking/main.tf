@@ -0,0 +1,284 @@
+# ==============================================================================
+# Data Masking Module - Masked Restores for Non-Production
+# ==============================================================================
+# Purpose: Keep real PHI out of dev and staging when they are seeded from
+# production snapshots. Restores named with the restore prefix are held in an
+# isolation security group only the masking Lambda can reach, their master
+# password is reset to this environment's, PHI columns are anonymized per
+# masking_rules, and only then are the app-facing security groups attached.
+# Dependencies: VPC (subnets), networking (exposed RDS security group), RDS
+# (credential parameters), KMS
+# ==============================================================================
+
+locals {
+  # Construct environment label with optional suffix for test isolation
+  env_label   = var.environment
+  full_suffix = var.name_suffix == "" ? local.env_label : "${local.env_label}-${var.name_suffix}"
+
+  restore_identifier_prefix = var.restore_identifier_prefix != "" ? var.restore_identifier_prefix : "${local.full_suffix}-hipaa-db-restore"
+  function_name             = "${local.full_suffix}-data-masking"
+  status_parameter_name     = "/hipaa/${local.full_suffix}/data-masking/status"
+
+  # RDS-EVENT-0043 and RDS-EVENT-0019 mark snapshot and point-in-time restores
+  # finishing; RDS-EVENT-0016 marks the master password reset that follows
+  # isolation and starts the masking pass
+  restore_event_ids = ["RDS-EVENT-0043", "RDS-EVENT-0019"]
+  reset_event_ids   = ["RDS-EVENT-0016"]
+
+  common_tags = merge(
+    var.tags,
+    {
+      Module      = "data_masking"
+      Environment = var.environment
+      Context     = var.name_suffix
+      ManagedBy   = "Terraform"
+    }
+  )
+}
+
+data "aws_region" "current" {}
+
+data "aws_caller_identity" "current" {}
+
+# ------------------------------------------------------------------------------
+# Security Groups
+# ------------------------------------------------------------------------------
+# Restores start in the isolation group, which admits PostgreSQL only from the
+# masking Lambda. The app-facing groups are swapped in after masking succeeds.
+
+resource "aws_security_group" "isolation" {
+  name        = "hipaa-masking-isolation-${local.full_suffix}"
+  description = "Holds restored databases until PHI is masked; reachable only by the masking Lambda"
+  vpc_id      = var.vpc_id
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "hipaa-masking-isolation-${local.full_suffix}"
+    }
+  )
+}
+
+resource "aws_security_group" "lambda" {
+  name        = "hipaa-masking-lambda-${local.full_suffix}"
+  description = "Masking Lambda: PostgreSQL to isolated restores and HTTPS to the RDS and SSM APIs"
+  vpc_id      = var.vpc_id
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "hipaa-masking-lambda-${local.full_suffix}"
+    }
+  )
+}
+
+resource "aws_vpc_security_group_ingress_rule" "isolation_from_lambda" {
+  security_group_id            = aws_security_group.isolation.id
+  description                  = "PostgreSQL from the masking Lambda only"
+  ip_protocol                  = "tcp"
+  from_port                    = 5432
+  to_port                      = 5432
+  referenced_security_group_id = aws_security_group.lambda.id
+}
+
+resource "aws_vpc_security_group_egress_rule" "lambda_to_isolation" {
+  security_group_id            = aws_security_group.lambda.id
+  description                  = "PostgreSQL to isolated restores"
+  ip_protocol                  = "tcp"
+  from_port                    = 5432
+  to_port                      = 5432
+  referenced_security_group_id = aws_security_group.isolation.id
+}
+
+resource "aws_vpc_security_group_egress_rule" "lambda_https" {
+  security_group_id = aws_security_group.lambda.id
+  description       = "HTTPS to the RDS and SSM APIs through NAT or VPC endpoints"
+  ip_protocol       = "tcp"
+  from_port         = 443
+  to_port           = 443
+  cidr_ipv4         = "0.0.0.0/0"
+}
+
+# ------------------------------------------------------------------------------
+# Masking Status
+# ------------------------------------------------------------------------------
+# The Lambda overwrites this parameter with the latest job as JSON; Terraform
+# only creates it and reads it back on refresh for the masking_job_status output
+
+resource "aws_ssm_parameter" "status" {
+  name        = local.status_parameter_name
+  description = "Latest data masking job for ${local.restore_identifier_prefix}* restores"
+  type        = "String"
+  value       = jsonencode({ status = "never_run" })
+
+  tags = local.common_tags
+
+  lifecycle {
+    ignore_changes = [value]
+  }
+}
+
+# ------------------------------------------------------------------------------
+# Masking Lambda
+# ------------------------------------------------------------------------------
+
+data "archive_file" "masking" {
+  type        = "zip"
+  source_file = "${path.module}/functions/data_masking.py"
+  output_path = "${path.module}/.build/data_masking.zip"
+}
+
+resource "aws_iam_role" "masking" {
+  name        = "${local.function_name}-role"
+  description = "IAM role for the restore data masking Lambda in ${var.environment}"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Principal = {
+          Service = "lambda.${var.dns_suffix}"
+        }
+        Action = "sts:AssumeRole"
+      }
+    ]
+  })
+
+  tags = local.common_tags
+}
+
+resource "aws_iam_role_policy_attachment" "masking_vpc" {
+  role       = aws_iam_role.masking.name
+  policy_arn = "arn:${var.partition}:iam::aws:policy/service-role/AWSLambdaVPCAccessExecutionRole"
+}
+
+resource "aws_iam_role_policy" "masking" {
+  name = local.function_name
+  role = aws_iam_role.masking.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid      = "DescribeRestores"
+        Effect   = "Allow"
+        Action   = ["rds:DescribeDBInstances", "rds:ListTagsForResource"]
+        Resource = "*"
+      },
+      {
+        Sid    = "IsolateMaskAndExposeRestores"
+        Effect = "Allow"
+        Action = [
+          "rds:ModifyDBInstance",
+          "rds:AddTagsToResource"
+        ]
+        Resource = "arn:${var.partition}:rds:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:db:${local.restore_identifier_prefix}*"
+      },
+      {
+        Sid    = "ReadCredentials"
+        Effect = "Allow"
+        Action = [
+          "ssm:GetParameter"
+        ]
+        Resource = [
+          "arn:${var.partition}:ssm:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:parameter/${trimprefix(var.master_username_parameter, "/")}",
+          "arn:${var.partition}:ssm:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:parameter/${trimprefix(var.master_password_parameter, "/")}"
+        ]
+      },
+      {
+        Sid      = "DecryptCredentials"
+        Effect   = "Allow"
+        Action   = "kms:Decrypt"
+        Resource = var.kms_key_arn
+      },
+      {
+        Sid      = "WriteStatus"
+        Effect   = "Allow"
+        Action   = "ssm:PutParameter"
+        Resource = aws_ssm_parameter.status.arn
+      },
+      {
+        Sid    = "WriteLogs"
+        Effect = "Allow"
+        Action = [
+          "logs:CreateLogGroup",
+          "logs:CreateLogStream",
+          "logs:PutLogEvents"
+        ]
+        Resource = "arn:${var.partition}:logs:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:log-group:/aws/lambda/${local.function_name}:*"
+      }
+    ]
+  })
+}
+
+resource "aws_lambda_function" "masking" {
+  function_name    = local.function_name
+  description      = "Masks PHI in ${local.restore_identifier_prefix}* restores before exposing them to the app"
+  role             = aws_iam_role.masking.arn
+  runtime          = "python3.12"
+  handler          = "data_masking.handler"
+  filename         = data.archive_file.masking.output_path
+  source_code_hash = data.archive_file.masking.output_base64sha256
+  layers           = var.driver_layer_arns
+  timeout          = 900
+
+  vpc_config {
+    subnet_ids         = var.subnet_ids
+    security_group_ids = [aws_security_group.lambda.id]
+  }
+
+  environment {
+    variables = {
+      RESTORE_PREFIX              = local.restore_identifier_prefix
+      RESTORE_EVENT_IDS           = join(",", local.restore_event_ids)
+      RESET_EVENT_IDS             = join(",", local.reset_event_ids)
+      ISOLATION_SECURITY_GROUP_ID = aws_security_group.isolation.id
+      EXPOSED_SECURITY_GROUP_IDS  = join(",", var.exposed_security_group_ids)
+      MASTER_USERNAME_PARAMETER   = var.master_username_parameter
+      MASTER_PASSWORD_PARAMETER   = var.master_password_parameter
+      DATABASE_NAME               = var.database_name
+      MASKING_RULES               = jsonencode(var.masking_rules)
+      STATUS_PARAMETER            = aws_ssm_parameter.status.name
+    }
+  }
+
+  tags = local.common_tags
+
+  depends_on = [aws_iam_role_policy_attachment.masking_vpc]
+}
+
+# ------------------------------------------------------------------------------
+# Restore Events
+# ------------------------------------------------------------------------------
+# One rule drives both passes: restore completion isolates the instance and
+# resets its password, and the password reset event runs the masking
+
+resource "aws_cloudwatch_event_rule" "restored" {
+  name        = "${local.function_name}-restored"
+  description = "Restores of ${local.restore_identifier_prefix}* and their password resets, routed to the masking Lambda"
+
+  event_pattern = jsonencode({
+    source      = ["aws.rds"]
+    detail-type = ["RDS DB Instance Event"]
+    detail = {
+      EventID          = concat(local.restore_event_ids, local.reset_event_ids)
+      SourceIdentifier = [{ prefix = local.restore_identifier_prefix }]
+    }
+  })
+
+  tags = local.common_tags
+}
+
+resource "aws_cloudwatch_event_target" "masking" {
+  rule = aws_cloudwatch_event_rule.restored.name
+  arn  = aws_lambda_function.masking.arn
+}
+
+resource "aws_lambda_permission" "masking" {
+  statement_id  = "AllowEventBridgeInvoke"
+  action        = "lambda:InvokeFunction"
+  function_name = aws_lambda_function.masking.function_name
+  principal     = "events.${var.dns_suffix}"
+  source_arn    = aws_cloudwatch_event_rule.restored.arn
+}
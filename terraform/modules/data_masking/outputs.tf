@@ -0,0 +1,33 @@
+# ==============================================================================
+# Data Masking Module - Output Values
+# ==============================================================================
+
+output "masking_job_status" {
+  value       = jsondecode(aws_ssm_parameter.status.value).status
+  description = "Status of the latest masking job as of the last refresh: never_run, isolated, masking, completed or failed"
+}
+
+output "status_parameter_name" {
+  value       = aws_ssm_parameter.status.name
+  description = "SSM parameter holding the latest masking job as JSON (status, db_instance, updated_at, rows_masked or reason)"
+}
+
+output "isolation_security_group_id" {
+  value       = aws_security_group.isolation.id
+  description = "Security group restores must be created in; only the masking Lambda can reach it"
+}
+
+output "restore_identifier_prefix" {
+  value       = local.restore_identifier_prefix
+  description = "DB instance identifier prefix of restores that are masked"
+}
+
+output "masking_function_arn" {
+  value       = aws_lambda_function.masking.arn
+  description = "ARN of the masking Lambda"
+}
+
+output "restore_event_rule_arn" {
+  value       = aws_cloudwatch_event_rule.restored.arn
+  description = "EventBridge rule routing restore and password reset events to the masking Lambda"
+}
@@ -0,0 +1,153 @@
+# ==============================================================================
+# Data Masking Module - Input Variables
+# ==============================================================================
+
+variable "environment" {
+  type        = string
+  description = "Non-production tier the restored databases live in (dev or staging); production keeps real PHI and is rejected"
+
+  validation {
+    condition     = contains(["dev", "staging"], var.environment)
+    error_message = "Data masking runs in dev or staging only; production databases hold real PHI and must never be masked in place."
+  }
+}
+
+variable "name_suffix" {
+  type        = string
+  default     = ""
+  description = "Optional suffix for resource names (tests/ephemeral runs)"
+
+  validation {
+    condition     = can(regex("^[a-z0-9-]*$", var.name_suffix))
+    error_message = "name_suffix may contain only lowercase letters, digits, and hyphens."
+  }
+}
+
+variable "vpc_id" {
+  type        = string
+  description = "VPC the restored databases and the masking Lambda live in"
+}
+
+variable "subnet_ids" {
+  type        = list(string)
+  description = "Private subnet IDs for the masking Lambda; they need a route to the RDS and SSM APIs (NAT or VPC endpoints)"
+
+  validation {
+    condition     = length(var.subnet_ids) > 0
+    error_message = "subnet_ids must contain at least one subnet"
+  }
+}
+
+variable "restore_identifier_prefix" {
+  type        = string
+  default     = ""
+  description = "DB instance identifier prefix of restores to mask (empty uses <environment>[-<name_suffix>]-hipaa-db-restore). Restores must use this prefix and isolation_security_group_id"
+
+  validation {
+    condition     = can(regex("^([a-z][a-z0-9-]*)?$", var.restore_identifier_prefix))
+    error_message = "restore_identifier_prefix must be a valid lowercase DB instance identifier prefix."
+  }
+}
+
+variable "exposed_security_group_ids" {
+  type        = list(string)
+  description = "Security groups attached once masking succeeds, such as the networking module's RDS group the app can reach"
+
+  validation {
+    condition     = length(var.exposed_security_group_ids) > 0
+    error_message = "exposed_security_group_ids must contain at least one security group"
+  }
+}
+
+variable "master_username_parameter" {
+  type        = string
+  description = "SSM SecureString parameter holding this environment's master username (rds module rds_username_ssm_parameter)"
+}
+
+variable "master_password_parameter" {
+  type        = string
+  description = "SSM SecureString parameter holding this environment's master password; restored databases are reset to it so production credentials never work outside production"
+}
+
+variable "kms_key_arn" {
+  type        = string
+  description = "KMS key encrypting the credential parameters"
+}
+
+variable "database_name" {
+  type        = string
+  default     = "hipaa_db"
+  description = "Database inside the restored instance whose tables are masked"
+
+  validation {
+    condition     = can(regex("^[a-zA-Z][a-zA-Z0-9_]*$", var.database_name))
+    error_message = "database_name must start with a letter and contain only letters, digits, and underscores."
+  }
+}
+
+variable "masking_rules" {
+  type = list(object({
+    table    = string
+    column   = string
+    strategy = string
+  }))
+  description = "PHI columns to anonymize: table (optionally schema-qualified), column and strategy (redact, null, hash, email, phone or date_year)"
+
+  validation {
+    condition     = length(var.masking_rules) > 0
+    error_message = "masking_rules must list at least one PHI column; an empty ruleset would expose restored PHI unmasked."
+  }
+
+  validation {
+    condition = alltrue([
+      for rule in var.masking_rules : contains(["redact", "null", "hash", "email", "phone", "date_year"], rule.strategy)
+    ])
+    error_message = "Each masking rule strategy must be one of redact, null, hash, email, phone, date_year."
+  }
+
+  validation {
+    condition = alltrue([
+      for rule in var.masking_rules :
+      can(regex("^[A-Za-z_][A-Za-z0-9_]*(\\.[A-Za-z_][A-Za-z0-9_]*)?$", rule.table)) && can(regex("^[A-Za-z_][A-Za-z0-9_]*$", rule.column))
+    ])
+    error_message = "Masking rule tables must be [schema.]table and columns plain identifiers (letters, digits, underscores)."
+  }
+}
+
+variable "driver_layer_arns" {
+  type        = list(string)
+  description = "Lambda layers providing the pure-Python pg8000 PostgreSQL driver the masking function imports"
+
+  validation {
+    condition     = length(var.driver_layer_arns) > 0
+    error_message = "driver_layer_arns must include a layer providing pg8000."
+  }
+}
+
+variable "partition" {
+  type        = string
+  description = "AWS partition used to build ARNs (aws, aws-us-gov or aws-cn)"
+  default     = "aws"
+
+  validation {
+    condition     = contains(["aws", "aws-us-gov", "aws-cn"], var.partition)
+    error_message = "partition must be aws, aws-us-gov or aws-cn"
+  }
+}
+
+variable "dns_suffix" {
+  type        = string
+  description = "DNS suffix of the partition used to build service principals (amazonaws.com.cn in China)"
+  default     = "amazonaws.com"
+
+  validation {
+    condition     = contains(["amazonaws.com", "amazonaws.com.cn"], var.dns_suffix)
+    error_message = "dns_suffix must be amazonaws.com or amazonaws.com.cn"
+  }
+}
+
+variable "tags" {
+  type        = map(string)
+  default     = {}
+  description = "Additional resource tags"
+}
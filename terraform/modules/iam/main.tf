@@ -12,6 +12,55 @@ locals {
 
   role_name = "hipaa-app-backend-${local.full_suffix}"
 
+  # Optional data-access conditions limiting the blast radius of a leaked
+  # app credential: TLS-only requests and (optionally) the S3 VPC endpoint
+  secure_transport_condition = var.require_secure_transport ? {
+    Bool = {
+      "aws:SecureTransport" = ["true"]
+    }
+  } : {}
+
+  source_vpce_condition = length(var.allowed_vpce_ids) > 0 ? {
+    StringEquals = {
+      "aws:SourceVpce" = var.allowed_vpce_ids
+    }
+  } : {}
+
+  # S3 statements get both; KMS statements only get the transport condition
+  # because S3 calls KMS on the app's behalf outside the VPC endpoint path
+  s3_access_conditions  = merge(local.secure_transport_condition, local.source_vpce_condition)
+  kms_access_conditions = local.secure_transport_condition
+
+  # Keys PHI may be encrypted under when deny_unapproved_kms_keys is set
+  approved_kms_key_arns = concat([var.kms_master_key_arn], var.secondary_kms_key_arns)
+
+  documents_prefix_patterns = [for prefix in var.s3_allowed_prefixes : "${prefix}*"]
+
+  # s3:signatureAge is measured in milliseconds
+  presigned_url_max_age_ms = var.presigned_url_max_expiry_seconds * 1000
+
+  # Privileged (auditor / break-glass admin) roles are assumable from this
+  # account only, and only with an MFA-authenticated session when required
+  mfa_condition = var.require_mfa ? {
+    Bool = {
+      "aws:MultiFactorAuthPresent" = ["true"]
+    }
+  } : {}
+
+  privileged_trust_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Principal = {
+          AWS = "arn:${var.partition}:iam::${data.aws_caller_identity.current.account_id}:root"
+        }
+        Action    = "sts:AssumeRole"
+        Condition = local.mfa_condition
+      }
+    ]
+  })
+
   common_tags = merge(
     var.tags,
     {
@@ -38,7 +87,7 @@ resource "aws_iam_role" "backend_app" {
       {
         Effect = "Allow"
         Principal = {
-          AWS = "arn:aws:iam::${data.aws_caller_identity.current.account_id}:root"
+          AWS = "arn:${var.partition}:iam::${data.aws_caller_identity.current.account_id}:root"
         }
         Action = "sts:AssumeRole"
         Condition = {
@@ -86,13 +135,11 @@ resource "aws_iam_policy" "s3_access" {
         Resource = [
           var.s3_bucket_documents_arn
         ]
-        Condition = {
+        Condition = merge(local.s3_access_conditions, {
           StringLike = {
-            "s3:prefix" = [
-              "tenants/*"
-            ]
+            "s3:prefix" = local.documents_prefix_patterns
           }
-        }
+        })
       },
       {
         Sid    = "ManageDocumentsInTenantFolders"
@@ -103,8 +150,9 @@ resource "aws_iam_policy" "s3_access" {
           "s3:DeleteObject"
         ]
         Resource = [
-          "${var.s3_bucket_documents_arn}/tenants/*"
+          for pattern in local.documents_prefix_patterns : "${var.s3_bucket_documents_arn}/${pattern}"
         ]
+        Condition = local.s3_access_conditions
       },
       {
         Sid    = "ListBackupsBucket"
@@ -115,6 +163,7 @@ resource "aws_iam_policy" "s3_access" {
         Resource = [
           var.s3_bucket_backups_arn
         ]
+        Condition = local.s3_access_conditions
       },
       {
         Sid    = "WriteBackups"
@@ -125,6 +174,7 @@ resource "aws_iam_policy" "s3_access" {
         Resource = [
           "${var.s3_bucket_backups_arn}/*"
         ]
+        Condition = local.s3_access_conditions
       },
       {
         Sid    = "ListAuditLogsBucket"
@@ -135,6 +185,7 @@ resource "aws_iam_policy" "s3_access" {
         Resource = [
           var.s3_bucket_audit_logs_arn
         ]
+        Condition = local.s3_access_conditions
       },
       {
         Sid    = "AppendAuditLogs"
@@ -145,6 +196,7 @@ resource "aws_iam_policy" "s3_access" {
         Resource = [
           "${var.s3_bucket_audit_logs_arn}/application-logs/*"
         ]
+        Condition = local.s3_access_conditions
       }
     ]
   })
@@ -157,6 +209,132 @@ resource "aws_iam_policy" "s3_access" {
   )
 }
 
+# ==============================================================================
+# Pre-Signed Document URLs (Conditional)
+# ==============================================================================
+# A pre-signed URL carries the app role's authority to whoever holds it, so
+# the URL-signed (REST-QUERY-STRING) path gets its own rules: TLS always,
+# whatever require_secure_transport says, and a signature age cap. The denies
+# also bind the app's general GetObject grant, which would otherwise honour a
+# URL signed for up to seven days.
+
+resource "aws_iam_policy" "presigned_url_access" {
+  count       = var.enable_presigned_urls ? 1 : 0
+  name        = "${local.full_suffix}-presigned-url-policy"
+  description = "Short-lived, TLS-only pre-signed document downloads for backend application in ${local.full_suffix}"
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid    = "PresignedDocumentDownloads"
+        Effect = "Allow"
+        Action = [
+          "s3:GetObject"
+        ]
+        Resource = [
+          for pattern in local.documents_prefix_patterns : "${var.s3_bucket_documents_arn}/${pattern}"
+        ]
+        Condition = {
+          Bool = {
+            "aws:SecureTransport" = ["true"]
+          }
+          StringEquals = {
+            "s3:authType" = "REST-QUERY-STRING"
+          }
+          NumericLessThanEquals = {
+            "s3:signatureAge" = local.presigned_url_max_age_ms
+          }
+        }
+      },
+      {
+        Sid    = "DenyExpiredPresignedUrls"
+        Effect = "Deny"
+        Action = [
+          "s3:GetObject"
+        ]
+        Resource = [
+          "${var.s3_bucket_documents_arn}/*"
+        ]
+        Condition = {
+          StringEquals = {
+            "s3:authType" = "REST-QUERY-STRING"
+          }
+          NumericGreaterThan = {
+            "s3:signatureAge" = local.presigned_url_max_age_ms
+          }
+        }
+      },
+      {
+        Sid    = "DenyPresignedUrlsWithoutTLS"
+        Effect = "Deny"
+        Action = [
+          "s3:GetObject"
+        ]
+        Resource = [
+          "${var.s3_bucket_documents_arn}/*"
+        ]
+        Condition = {
+          Bool = {
+            "aws:SecureTransport" = ["false"]
+          }
+          StringEquals = {
+            "s3:authType" = "REST-QUERY-STRING"
+          }
+        }
+      }
+    ]
+  })
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${local.full_suffix}-presigned-url-policy"
+    }
+  )
+}
+
+# ==============================================================================
+# Audit Log Protection - Separation of Duties
+# ==============================================================================
+# The app may append its own logs but must never read or remove the audit
+# trail; an explicit deny holds even if a broader allow is attached later.
+
+resource "aws_iam_policy" "audit_logs_protection" {
+  name        = "${local.full_suffix}-audit-logs-protection-policy"
+  description = "Denies the backend application read and delete access to audit logs in ${local.full_suffix}"
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid    = "DenyReadAndDeleteAuditLogs"
+        Effect = "Deny"
+        Action = [
+          "s3:GetObject",
+          "s3:GetObjectVersion",
+          "s3:GetObjectAcl",
+          "s3:GetObjectAttributes",
+          "s3:DeleteObject",
+          "s3:DeleteObjectVersion",
+          "s3:PutObjectAcl",
+          "s3:RestoreObject"
+        ]
+        Resource = [
+          "${var.s3_bucket_audit_logs_arn}/*"
+        ]
+      }
+    ]
+  })
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${local.full_suffix}-audit-logs-protection-policy"
+    }
+  )
+}
+
 # ==============================================================================
 # KMS Access Policy - Encryption Operations
 # ==============================================================================
@@ -167,7 +345,7 @@ resource "aws_iam_policy" "kms_access" {
 
   policy = jsonencode({
     Version = "2012-10-17"
-    Statement = [
+    Statement = concat([
       {
         Sid    = "UseMasterKey"
         Effect = "Allow"
@@ -180,6 +358,7 @@ resource "aws_iam_policy" "kms_access" {
         Resource = [
           var.kms_master_key_arn
         ]
+        Condition = local.kms_access_conditions
       },
       {
         Sid    = "CreateTenantKeys"
@@ -206,13 +385,13 @@ resource "aws_iam_policy" "kms_access" {
           "kms:EnableKeyRotation",
           "kms:GetKeyRotationStatus"
         ]
-        Resource = "arn:aws:kms:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:key/*"
-        Condition = {
+        Resource = "arn:${var.partition}:kms:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:key/*"
+        Condition = merge(local.kms_access_conditions, {
           StringEquals = {
             "kms:ResourceTag/Environment" = var.environment
             "kms:ResourceTag/ManagedBy"   = "Application"
           }
-        }
+        })
       },
       {
         Sid    = "ListKeys"
@@ -223,7 +402,25 @@ resource "aws_iam_policy" "kms_access" {
         ]
         Resource = "*"
       }
-    ]
+      ],
+      # Funnel all new PHI encryption through the governed, rotated master key.
+      # The explicit deny overrides ManageTenantKeys and any allow attached
+      # later; Decrypt stays allowed so data under older keys remains readable.
+      var.deny_unapproved_kms_keys ? [
+        {
+          Sid    = "DenyUnapprovedKeyEncryption"
+          Effect = "Deny"
+          Action = [
+            "kms:Encrypt",
+            "kms:GenerateDataKey",
+            "kms:GenerateDataKeyWithoutPlaintext",
+            "kms:GenerateDataKeyPair",
+            "kms:GenerateDataKeyPairWithoutPlaintext",
+            "kms:ReEncryptTo"
+          ]
+          NotResource = local.approved_kms_key_arns
+        }
+    ] : [])
   })
 
   tags = merge(
@@ -252,7 +449,7 @@ resource "aws_iam_policy" "bedrock_access" {
           "bedrock:InvokeModel"
         ]
         Resource = [
-          "arn:aws:bedrock:${data.aws_region.current.name}::foundation-model/anthropic.claude-*"
+          "arn:${var.partition}:bedrock:${data.aws_region.current.name}::foundation-model/anthropic.claude-*"
         ]
       }
     ]
@@ -266,6 +463,43 @@ resource "aws_iam_policy" "bedrock_access" {
   )
 }
 
+# ==============================================================================
+# SSM Parameter Access Policy (Conditional)
+# ==============================================================================
+# Lets the application resolve secrets referenced by SSM path (e.g., the
+# database credentials listed in the Railway env file)
+
+resource "aws_iam_policy" "ssm_access" {
+  count       = length(var.ssm_parameter_names) > 0 ? 1 : 0
+  name        = "${local.full_suffix}-ssm-access-policy"
+  description = "Read access to application secrets in SSM Parameter Store for ${local.full_suffix}"
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid    = "ReadApplicationSecrets"
+        Effect = "Allow"
+        Action = [
+          "ssm:GetParameter",
+          "ssm:GetParameters"
+        ]
+        Resource = [
+          for name in var.ssm_parameter_names :
+          "arn:${var.partition}:ssm:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:parameter${name}"
+        ]
+      }
+    ]
+  })
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${local.full_suffix}-ssm-access-policy"
+    }
+  )
+}
+
 # ==============================================================================
 # RDS Enhanced Monitoring Role (Conditional)
 # ==============================================================================
@@ -281,7 +515,7 @@ resource "aws_iam_role" "rds_monitoring" {
       {
         Effect = "Allow"
         Principal = {
-          Service = "monitoring.rds.amazonaws.com"
+          Service = "monitoring.rds.${var.dns_suffix}"
         }
         Action = "sts:AssumeRole"
       }
@@ -299,7 +533,75 @@ resource "aws_iam_role" "rds_monitoring" {
 resource "aws_iam_role_policy_attachment" "rds_monitoring" {
   count      = var.enable_rds_monitoring ? 1 : 0
   role       = aws_iam_role.rds_monitoring[0].name
-  policy_arn = "arn:aws:iam::aws:policy/service-role/AmazonRDSEnhancedMonitoringRole"
+  policy_arn = "arn:${var.partition}:iam::aws:policy/service-role/AmazonRDSEnhancedMonitoringRole"
+}
+
+# ==============================================================================
+# Privileged Roles (Conditional)
+# ==============================================================================
+# Auditor (read-only security review) and break-glass admin roles for humans.
+# Both share the MFA-gated trust policy above.
+
+resource "aws_iam_role" "auditor" {
+  count                = var.create_auditor_role ? 1 : 0
+  name                 = "hipaa-auditor-${local.full_suffix}"
+  description          = "Read-only security audit role for ${local.full_suffix}"
+  max_session_duration = 3600
+  assume_role_policy   = local.privileged_trust_policy
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "hipaa-auditor-${local.full_suffix}"
+    }
+  )
+}
+
+resource "aws_iam_role_policy_attachment" "auditor" {
+  count      = var.create_auditor_role ? 1 : 0
+  role       = aws_iam_role.auditor[0].name
+  policy_arn = "arn:${var.partition}:iam::aws:policy/SecurityAudit"
+}
+
+resource "aws_iam_role" "admin" {
+  count                = var.create_admin_role ? 1 : 0
+  name                 = "hipaa-break-glass-admin-${local.full_suffix}"
+  description          = "Break-glass administrator role for ${local.full_suffix} - every use is reviewed"
+  max_session_duration = 3600
+  assume_role_policy   = local.privileged_trust_policy
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "hipaa-break-glass-admin-${local.full_suffix}"
+    }
+  )
+}
+
+resource "aws_iam_role_policy_attachment" "admin" {
+  count      = var.create_admin_role ? 1 : 0
+  role       = aws_iam_role.admin[0].name
+  policy_arn = "arn:${var.partition}:iam::aws:policy/AdministratorAccess"
+}
+
+# ==============================================================================
+# Account Password Policy (Conditional)
+# ==============================================================================
+# Account-wide singleton - enable in exactly one configuration per account.
+# We avoid IAM users, but auditors (and Security Hub IAM.7) still check it.
+
+resource "aws_iam_account_password_policy" "strict" {
+  count = var.manage_account_password_policy ? 1 : 0
+
+  minimum_password_length        = var.password_minimum_length
+  require_lowercase_characters   = true
+  require_uppercase_characters   = true
+  require_numbers                = true
+  require_symbols                = true
+  allow_users_to_change_password = true
+  max_password_age               = var.password_max_age_days
+  # 24 is both the HIPAA baseline minimum and the AWS maximum, so it is not configurable
+  password_reuse_prevention = 24
 }
 
 # ==============================================================================
@@ -311,6 +613,17 @@ resource "aws_iam_role_policy_attachment" "s3_access" {
   policy_arn = aws_iam_policy.s3_access.arn
 }
 
+resource "aws_iam_role_policy_attachment" "presigned_url_access" {
+  count      = var.enable_presigned_urls ? 1 : 0
+  role       = aws_iam_role.backend_app.name
+  policy_arn = aws_iam_policy.presigned_url_access[0].arn
+}
+
+resource "aws_iam_role_policy_attachment" "audit_logs_protection" {
+  role       = aws_iam_role.backend_app.name
+  policy_arn = aws_iam_policy.audit_logs_protection.arn
+}
+
 resource "aws_iam_role_policy_attachment" "kms_access" {
   role       = aws_iam_role.backend_app.name
   policy_arn = aws_iam_policy.kms_access.arn
@@ -320,3 +633,9 @@ resource "aws_iam_role_policy_attachment" "bedrock_access" {
   role       = aws_iam_role.backend_app.name
   policy_arn = aws_iam_policy.bedrock_access.arn
 }
+
+resource "aws_iam_role_policy_attachment" "ssm_access" {
+  count      = length(var.ssm_parameter_names) > 0 ? 1 : 0
+  role       = aws_iam_role.backend_app.name
+  policy_arn = aws_iam_policy.ssm_access[0].arn
+}
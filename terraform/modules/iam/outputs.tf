@@ -22,12 +22,75 @@ output "s3_policy_arn" {
   description = "ARN of the S3 access policy"
 }
 
+output "s3_policy_document" {
+  value       = aws_iam_policy.s3_access.policy
+  description = "JSON document of the S3 access policy (for condition auditing)"
+}
+
+output "presigned_url_policy_document" {
+  value       = var.enable_presigned_urls ? aws_iam_policy.presigned_url_access[0].policy : ""
+  description = "JSON document of the pre-signed document URL policy (empty if disabled)"
+}
+
+output "presigned_url_max_expiry_seconds" {
+  value       = var.enable_presigned_urls ? var.presigned_url_max_expiry_seconds : 0
+  description = "Longest the app may set ExpiresIn on a pre-signed document URL (0 if pre-signed URLs are disabled)"
+}
+
+output "audit_logs_protection_policy_document" {
+  value       = aws_iam_policy.audit_logs_protection.policy
+  description = "JSON document of the policy denying the app read and delete access to audit logs"
+}
+
 output "kms_policy_arn" {
   value       = aws_iam_policy.kms_access.arn
   description = "ARN of the KMS access policy"
 }
 
+output "kms_policy_document" {
+  value       = aws_iam_policy.kms_access.policy
+  description = "JSON KMS access policy attached to the app role (for compliance verification)"
+}
+
 output "bedrock_policy_arn" {
   value       = aws_iam_policy.bedrock_access.arn
   description = "ARN of the Bedrock access policy"
 }
+
+output "ssm_policy_arn" {
+  value       = length(var.ssm_parameter_names) > 0 ? aws_iam_policy.ssm_access[0].arn : ""
+  description = "ARN of the SSM parameter access policy (empty if no parameters)"
+}
+
+output "account_password_policy" {
+  value = var.manage_account_password_policy ? {
+    minimum_password_length   = aws_iam_account_password_policy.strict[0].minimum_password_length
+    require_lowercase         = aws_iam_account_password_policy.strict[0].require_lowercase_characters
+    require_uppercase         = aws_iam_account_password_policy.strict[0].require_uppercase_characters
+    require_numbers           = aws_iam_account_password_policy.strict[0].require_numbers
+    require_symbols           = aws_iam_account_password_policy.strict[0].require_symbols
+    max_password_age          = aws_iam_account_password_policy.strict[0].max_password_age
+    password_reuse_prevention = aws_iam_account_password_policy.strict[0].password_reuse_prevention
+  } : {}
+  description = "Effective account password policy settings (empty if not managed)"
+}
+
+output "auditor_role_arn" {
+  value       = var.create_auditor_role ? aws_iam_role.auditor[0].arn : ""
+  description = "ARN of the security auditor role (empty if disabled)"
+}
+
+output "admin_role_arn" {
+  value       = var.create_admin_role ? aws_iam_role.admin[0].arn : ""
+  description = "ARN of the break-glass admin role (empty if disabled)"
+}
+
+output "privileged_roles_require_mfa" {
+  value       = var.require_mfa
+  description = "Whether the auditor and admin role trust policies require MFA"
+}
+
+output "privileged_role_trust_policy" {
+  value       = local.privileged_trust_policy
+  description = "JSON trust policy shared by the auditor and admin roles (for condition auditing)"
+}
@@ -28,7 +28,7 @@ variable "s3_bucket_documents_arn" {
   description = "ARN of the S3 bucket for PHI document storage"
 
   validation {
-    condition     = can(regex("^arn:aws:s3:::.+$", var.s3_bucket_documents_arn))
+    condition     = can(regex("^arn:aws[a-z-]*:s3:::.+$", var.s3_bucket_documents_arn))
     error_message = "Must be a valid S3 bucket ARN"
   }
 }
@@ -38,7 +38,7 @@ variable "s3_bucket_backups_arn" {
   description = "ARN of the S3 bucket for database and application backups"
 
   validation {
-    condition     = can(regex("^arn:aws:s3:::.+$", var.s3_bucket_backups_arn))
+    condition     = can(regex("^arn:aws[a-z-]*:s3:::.+$", var.s3_bucket_backups_arn))
     error_message = "Must be a valid S3 bucket ARN"
   }
 }
@@ -48,7 +48,7 @@ variable "s3_bucket_audit_logs_arn" {
   description = "ARN of the S3 bucket for audit logs and compliance trail"
 
   validation {
-    condition     = can(regex("^arn:aws:s3:::.+$", var.s3_bucket_audit_logs_arn))
+    condition     = can(regex("^arn:aws[a-z-]*:s3:::.+$", var.s3_bucket_audit_logs_arn))
     error_message = "Must be a valid S3 bucket ARN"
   }
 }
@@ -58,11 +58,28 @@ variable "kms_master_key_arn" {
   description = "ARN of the KMS master key for infrastructure encryption"
 
   validation {
-    condition     = can(regex("^arn:aws:kms:[a-z0-9-]+:[0-9]{12}:key/.+$", var.kms_master_key_arn))
+    condition     = can(regex("^arn:aws[a-z-]*:kms:[a-z0-9-]+:[0-9]{12}:key/.+$", var.kms_master_key_arn))
     error_message = "Must be a valid KMS key ARN"
   }
 }
 
+variable "deny_unapproved_kms_keys" {
+  type        = bool
+  description = "Explicitly deny the app role encrypting (Encrypt, GenerateDataKey*, ReEncryptTo) under any key other than the master key and secondary_kms_key_arns; tenant keys stay usable for Decrypt only"
+  default     = false
+}
+
+variable "secondary_kms_key_arns" {
+  type        = list(string)
+  description = "Additional approved key ARNs (e.g. the multi-region replica of the master key) exempt from deny_unapproved_kms_keys"
+  default     = []
+
+  validation {
+    condition     = alltrue([for arn in var.secondary_kms_key_arns : can(regex("^arn:aws[a-z-]*:kms:[a-z0-9-]+:[0-9]{12}:key/.+$", arn))])
+    error_message = "secondary_kms_key_arns must contain KMS key ARNs"
+  }
+}
+
 variable "external_id" {
   type        = string
   description = "External ID for AssumeRole trust policy (for Railway or external access)"
@@ -76,6 +93,130 @@ variable "enable_rds_monitoring" {
   default     = false
 }
 
+variable "require_secure_transport" {
+  type        = bool
+  description = "Require aws:SecureTransport (TLS) on the app role's S3 and KMS data-access statements"
+  default     = true
+}
+
+variable "allowed_vpce_ids" {
+  type        = list(string)
+  description = "VPC endpoint IDs the app role's S3 requests must originate from (aws:SourceVpce). Empty disables the restriction."
+  default     = []
+
+  validation {
+    condition     = alltrue([for id in var.allowed_vpce_ids : can(regex("^vpce-[a-z0-9]+$", id))])
+    error_message = "allowed_vpce_ids must contain only VPC endpoint IDs (vpce-xxxxxxxx)."
+  }
+}
+
+variable "s3_allowed_prefixes" {
+  type        = list(string)
+  description = "Key prefixes in the documents bucket the app role may list and access"
+  default     = ["tenants/"]
+
+  validation {
+    condition     = length(var.s3_allowed_prefixes) > 0 && alltrue([for prefix in var.s3_allowed_prefixes : can(regex("^[A-Za-z0-9!_.()'-]+(/[A-Za-z0-9!_.()'-]+)*/$", prefix))])
+    error_message = "s3_allowed_prefixes must be non-empty and each prefix must end with '/' (e.g., tenants/)."
+  }
+}
+
+variable "enable_presigned_urls" {
+  type        = bool
+  description = "Attach a policy letting the app hand out pre-signed GETs for documents, TLS-only and capped at presigned_url_max_expiry_seconds"
+  default     = false
+}
+
+variable "presigned_url_max_expiry_seconds" {
+  type        = number
+  description = "Longest a pre-signed document URL stays usable; older signatures are denied by policy whatever expiry the app set"
+  default     = 300
+
+  validation {
+    condition     = var.presigned_url_max_expiry_seconds >= 1 && var.presigned_url_max_expiry_seconds <= 604800 && floor(var.presigned_url_max_expiry_seconds) == var.presigned_url_max_expiry_seconds
+    error_message = "presigned_url_max_expiry_seconds must be a whole number between 1 and 604800 (the SigV4 maximum of 7 days)."
+  }
+}
+
+variable "manage_account_password_policy" {
+  type        = bool
+  description = "Manage the account-wide IAM password policy (only one configuration per account should enable this)"
+  default     = false
+}
+
+variable "password_minimum_length" {
+  type        = number
+  description = "Minimum IAM user password length"
+  default     = 14
+
+  validation {
+    condition     = var.password_minimum_length >= 14 && var.password_minimum_length <= 128
+    error_message = "password_minimum_length must be between 14 and 128"
+  }
+}
+
+variable "password_max_age_days" {
+  type        = number
+  description = "Days before an IAM user password must be rotated"
+  default     = 90
+
+  validation {
+    condition     = var.password_max_age_days >= 1 && var.password_max_age_days <= 90
+    error_message = "password_max_age_days must be between 1 and 90"
+  }
+}
+
+variable "ssm_parameter_names" {
+  type        = list(string)
+  description = "SSM parameter names (e.g., /dev-hipaa-db/master-password) the application may read"
+  default     = []
+
+  validation {
+    condition     = alltrue([for name in var.ssm_parameter_names : startswith(name, "/")])
+    error_message = "SSM parameter names must be fully qualified paths starting with /"
+  }
+}
+
+variable "create_auditor_role" {
+  type        = bool
+  description = "Create a read-only security auditor role (SecurityAudit) assumable from this account"
+  default     = false
+}
+
+variable "create_admin_role" {
+  type        = bool
+  description = "Create a break-glass administrator role assumable from this account"
+  default     = false
+}
+
+variable "require_mfa" {
+  type        = bool
+  description = "Require aws:MultiFactorAuthPresent in the auditor and admin role trust policies"
+  default     = true
+}
+
+variable "partition" {
+  type        = string
+  description = "AWS partition used to build ARNs (aws, aws-us-gov or aws-cn)"
+  default     = "aws"
+
+  validation {
+    condition     = contains(["aws", "aws-us-gov", "aws-cn"], var.partition)
+    error_message = "partition must be aws, aws-us-gov or aws-cn"
+  }
+}
+
+variable "dns_suffix" {
+  type        = string
+  description = "DNS suffix of the partition used to build service principals (amazonaws.com.cn in China)"
+  default     = "amazonaws.com"
+
+  validation {
+    condition     = contains(["amazonaws.com", "amazonaws.com.cn"], var.dns_suffix)
+    error_message = "dns_suffix must be amazonaws.com or amazonaws.com.cn"
+  }
+}
+
 variable "tags" {
   type        = map(string)
   description = "Additional resource tags"
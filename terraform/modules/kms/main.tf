@@ -9,6 +9,208 @@ locals {
   # Construct environment label with optional suffix for test isolation
   env_label   = var.environment
   full_suffix = var.name_suffix == "" ? local.env_label : "${local.env_label}-${var.name_suffix}"
+
+  # Backup vault the AWS Backup service principal is scoped to
+  backup_vault_name = var.backup_vault_name != "" ? var.backup_vault_name : "hipaa-backup-vault-${local.full_suffix}"
+
+  # Human-readable rotation schedule for auditors; AWS rotates enabled keys yearly
+  rotation_schedule = var.enable_key_rotation ? "Every 365 days" : "Disabled"
+  rotation_tags = var.tag_rotation_metadata ? {
+    RotationEnabled  = tostring(var.enable_key_rotation)
+    RotationSchedule = local.rotation_schedule
+  } : {}
+
+  # Separation of duties: once administrators or users are named, the account
+  # root statement delegates only key management to IAM, so cryptographic use
+  # is limited to key_user_arns and the service principals below
+  separation_of_duties = length(var.key_administrator_arns) > 0 || length(var.key_user_arns) > 0
+  key_management_actions = [
+    "kms:Create*",
+    "kms:Describe*",
+    "kms:Enable*",
+    "kms:List*",
+    "kms:Put*",
+    "kms:Update*",
+    "kms:Revoke*",
+    "kms:Disable*",
+    "kms:Get*",
+    "kms:Delete*",
+    "kms:TagResource",
+    "kms:UntagResource",
+    "kms:ScheduleKeyDeletion",
+    "kms:CancelKeyDeletion",
+    "kms:RotateKeyOnDemand"
+  ]
+
+  # Key policy rendered per region: the primary key and its multi-region
+  # replica share every grant except the regional CloudWatch Logs principal,
+  # which must name the region the key lives in
+  key_policy_regions = merge(
+    { primary = data.aws_region.current.name },
+    var.create_replica_key ? { replica = data.aws_region.replica[0].name } : {}
+  )
+  key_policies = {
+    for key, region in local.key_policy_regions : key => jsonencode({
+      Version = "2012-10-17"
+      Id      = "hipaa-master-key-policy-${local.full_suffix}"
+      Statement = concat([
+        # Root account access (required by AWS); management only under
+        # separation of duties so IAM policies cannot grant key use
+        {
+          Sid    = "Enable IAM User Permissions"
+          Effect = "Allow"
+          Principal = {
+            AWS = "arn:${var.partition}:iam::${var.aws_account_id}:root"
+          }
+          Action   = local.separation_of_duties ? local.key_management_actions : ["kms:*"]
+          Resource = "*"
+        },
+        # CloudTrail logging for key usage
+        {
+          Sid    = "Allow CloudTrail to encrypt logs"
+          Effect = "Allow"
+          Principal = {
+            Service = "cloudtrail.${var.dns_suffix}"
+          }
+          Action = [
+            "kms:GenerateDataKey*",
+            "kms:DecryptDataKey"
+          ]
+          Resource = "*"
+          Condition = {
+            StringLike = {
+              "kms:EncryptionContext:aws:cloudtrail:arn" = "arn:${var.partition}:cloudtrail:*:${var.aws_account_id}:trail/*"
+            }
+          }
+        },
+        {
+          Sid    = "Allow CloudTrail to describe key"
+          Effect = "Allow"
+          Principal = {
+            Service = "cloudtrail.${var.dns_suffix}"
+          }
+          Action   = "kms:DescribeKey"
+          Resource = "*"
+        },
+        # RDS service access for database encryption
+        {
+          Sid    = "Allow RDS to use the key"
+          Effect = "Allow"
+          Principal = {
+            Service = "rds.${var.dns_suffix}"
+          }
+          Action = [
+            "kms:DescribeKey",
+            "kms:CreateGrant"
+          ]
+          Resource = "*"
+          Condition = {
+            StringEquals = {
+              "kms:ViaService" = "rds.${var.dns_suffix}"
+            }
+          }
+        },
+        # S3 service access for bucket encryption
+        {
+          Sid    = "Allow S3 to use the key"
+          Effect = "Allow"
+          Principal = {
+            Service = "s3.${var.dns_suffix}"
+          }
+          Action = [
+            "kms:Decrypt",
+            "kms:GenerateDataKey"
+          ]
+          Resource = "*"
+        },
+        # CloudWatch Logs access for encrypted log groups (e.g. CloudTrail delivery)
+        {
+          Sid    = "Allow CloudWatch Logs to use the key"
+          Effect = "Allow"
+          Principal = {
+            Service = "logs.${region}.${var.dns_suffix}"
+          }
+          Action = [
+            "kms:Encrypt*",
+            "kms:Decrypt*",
+            "kms:ReEncrypt*",
+            "kms:GenerateDataKey*",
+            "kms:Describe*"
+          ]
+          Resource = "*"
+          Condition = {
+            ArnLike = {
+              "kms:EncryptionContext:aws:logs:arn" = "arn:${var.partition}:logs:${region}:${var.aws_account_id}:log-group:*"
+            }
+          }
+        }
+        ],
+        # Separation of duties: administrators manage the key but cannot use it,
+        # users encrypt/decrypt but cannot change or delete it
+        length(var.key_administrator_arns) > 0 ? [
+          {
+            Sid    = "Allow key administrators"
+            Effect = "Allow"
+            Principal = {
+              AWS = var.key_administrator_arns
+            }
+            Action   = local.key_management_actions
+            Resource = "*"
+          }
+        ] : [],
+        length(var.key_user_arns) > 0 ? [
+          {
+            Sid    = "Allow key users"
+            Effect = "Allow"
+            Principal = {
+              AWS = var.key_user_arns
+            }
+            Action = [
+              "kms:Encrypt",
+              "kms:Decrypt",
+              "kms:ReEncrypt*",
+              "kms:GenerateDataKey*",
+              "kms:DescribeKey"
+            ]
+            Resource = "*"
+          }
+        ] : [],
+        # AWS Backup access for copying encrypted RDS snapshots (Conditional)
+        var.enable_backup_service_access ? [
+          {
+            Sid    = "Allow AWS Backup to use the key"
+            Effect = "Allow"
+            Principal = {
+              Service = "backup.${var.dns_suffix}"
+            }
+            Action = [
+              "kms:Decrypt",
+              "kms:Encrypt",
+              "kms:GenerateDataKey*",
+              "kms:ReEncrypt*",
+              "kms:DescribeKey",
+              "kms:CreateGrant"
+            ]
+            Resource = "*"
+            Condition = {
+              StringEquals = {
+                "aws:SourceAccount" = var.aws_account_id
+              }
+              ArnLike = {
+                "aws:SourceArn" = "arn:${var.partition}:backup:*:${var.aws_account_id}:backup-vault:${local.backup_vault_name}"
+              }
+            }
+          }
+      ] : [])
+    })
+  }
+}
+
+data "aws_region" "current" {}
+
+data "aws_region" "replica" {
+  count    = var.create_replica_key ? 1 : 0
+  provider = aws.replica
 }
 
 # ------------------------------------------------------------------------------
@@ -16,76 +218,12 @@ locals {
 # ------------------------------------------------------------------------------
 resource "aws_kms_key" "master" {
   description             = "HIPAA infrastructure master encryption key for ${local.full_suffix}"
-  deletion_window_in_days = 30
+  deletion_window_in_days = var.allow_destroy ? 7 : 30
   enable_key_rotation     = var.enable_key_rotation
-  multi_region            = false
+  multi_region            = var.create_replica_key
 
   # Key policy granting least-privilege access
-  policy = jsonencode({
-    Version = "2012-10-17"
-    Id      = "hipaa-master-key-policy-${local.full_suffix}"
-    Statement = [
-      # Root account full access (required by AWS)
-      {
-        Sid    = "Enable IAM User Permissions"
-        Effect = "Allow"
-        Principal = {
-          AWS = "arn:aws:iam::${var.aws_account_id}:root"
-        }
-        Action   = "kms:*"
-        Resource = "*"
-      },
-      # CloudTrail logging for key usage
-      {
-        Sid    = "Allow CloudTrail to encrypt logs"
-        Effect = "Allow"
-        Principal = {
-          Service = "cloudtrail.amazonaws.com"
-        }
-        Action = [
-          "kms:GenerateDataKey*",
-          "kms:DecryptDataKey"
-        ]
-        Resource = "*"
-        Condition = {
-          StringLike = {
-            "kms:EncryptionContext:aws:cloudtrail:arn" = "arn:aws:cloudtrail:*:${var.aws_account_id}:trail/*"
-          }
-        }
-      },
-      # RDS service access for database encryption
-      {
-        Sid    = "Allow RDS to use the key"
-        Effect = "Allow"
-        Principal = {
-          Service = "rds.amazonaws.com"
-        }
-        Action = [
-          "kms:DescribeKey",
-          "kms:CreateGrant"
-        ]
-        Resource = "*"
-        Condition = {
-          StringEquals = {
-            "kms:ViaService" = "rds.amazonaws.com"
-          }
-        }
-      },
-      # S3 service access for bucket encryption
-      {
-        Sid    = "Allow S3 to use the key"
-        Effect = "Allow"
-        Principal = {
-          Service = "s3.amazonaws.com"
-        }
-        Action = [
-          "kms:Decrypt",
-          "kms:GenerateDataKey"
-        ]
-        Resource = "*"
-      }
-    ]
-  })
+  policy = local.key_policies["primary"]
 
   tags = merge(
     var.tags,
@@ -94,14 +232,145 @@ resource "aws_kms_key" "master" {
       Environment = var.environment
       ManagedBy   = "Terraform"
       Purpose     = "Infrastructure encryption master key"
-    }
+    },
+    local.rotation_tags
   )
+
+  lifecycle {
+    precondition {
+      condition     = length(var.key_administrator_arns) == 0 || length(var.key_user_arns) > 0
+      error_message = "key_administrator_arns without key_user_arns leaves no IAM principal able to use the key; list the application and deployment roles in key_user_arns."
+    }
+  }
 }
 
 # ------------------------------------------------------------------------------
 # KMS Key Alias
 # ------------------------------------------------------------------------------
+# Aliases are unique per account and region, so they carry the full suffix;
+# without a name_suffix this is alias/hipaa-master-<environment>.
 resource "aws_kms_alias" "master" {
-  name          = "alias/hipaa-master-${var.environment}"
+  name          = "alias/hipaa-master-${local.full_suffix}"
   target_key_id = aws_kms_key.master.key_id
 }
+
+# ------------------------------------------------------------------------------
+# KMS Replica Key (Conditional - Disaster Recovery Region)
+# ------------------------------------------------------------------------------
+# Multi-region replica of the master key in the replica region so replicated
+# S3 objects and RDS backups remain decryptable there. Uses the aws.replica
+# provider configuration passed in by the root module.
+resource "aws_kms_replica_key" "master" {
+  count    = var.create_replica_key ? 1 : 0
+  provider = aws.replica
+
+  description             = "HIPAA infrastructure master encryption key replica for ${local.full_suffix}"
+  primary_key_arn         = aws_kms_key.master.arn
+  deletion_window_in_days = var.allow_destroy ? 7 : 30
+
+  policy = local.key_policies["replica"]
+
+  tags = merge(
+    var.tags,
+    {
+      Name        = "hipaa-master-key-replica-${var.environment}"
+      Environment = var.environment
+      ManagedBy   = "Terraform"
+      Purpose     = "Infrastructure encryption master key replica"
+    }
+  )
+}
+
+resource "aws_kms_alias" "replica" {
+  count    = var.create_replica_key ? 1 : 0
+  provider = aws.replica
+
+  name          = "alias/hipaa-master-${local.full_suffix}"
+  target_key_id = aws_kms_replica_key.master[0].key_id
+}
+
+# ------------------------------------------------------------------------------
+# KMS Backup Key (Conditional)
+# ------------------------------------------------------------------------------
+# Separate key for backup data so disabling or losing access to one key does
+# not take out both the documents and their backups. Same policy as the master
+# key: RDS, S3 and AWS Backup are the services that use it.
+resource "aws_kms_key" "backup" {
+  count = var.create_backup_key ? 1 : 0
+
+  description             = "HIPAA backup encryption key for ${local.full_suffix}"
+  deletion_window_in_days = var.allow_destroy ? 7 : 30
+  enable_key_rotation     = var.enable_key_rotation
+
+  policy = local.key_policies["primary"]
+
+  tags = merge(
+    var.tags,
+    {
+      Name        = "hipaa-backup-key-${var.environment}"
+      Environment = var.environment
+      ManagedBy   = "Terraform"
+      Purpose     = "Backup encryption key"
+    },
+    local.rotation_tags
+  )
+}
+
+resource "aws_kms_alias" "backup" {
+  count = var.create_backup_key ? 1 : 0
+
+  name          = "alias/hipaa-backup-${local.full_suffix}"
+  target_key_id = aws_kms_key.backup[0].key_id
+}
+
+# ------------------------------------------------------------------------------
+# KMS Log Key (Conditional)
+# ------------------------------------------------------------------------------
+# Separate key for audit log delivery so log encryption can be administered
+# and audited apart from the keys protecting PHI. The master key policy
+# already carries the CloudTrail and CloudWatch Logs grants it needs.
+resource "aws_kms_key" "log" {
+  count = var.create_log_key ? 1 : 0
+
+  description             = "HIPAA audit log encryption key for ${local.full_suffix}"
+  deletion_window_in_days = var.allow_destroy ? 7 : 30
+  enable_key_rotation     = var.enable_key_rotation
+
+  policy = local.key_policies["primary"]
+
+  tags = merge(
+    var.tags,
+    {
+      Name        = "hipaa-log-key-${var.environment}"
+      Environment = var.environment
+      ManagedBy   = "Terraform"
+      Purpose     = "Audit log encryption key"
+    },
+    local.rotation_tags
+  )
+}
+
+resource "aws_kms_alias" "log" {
+  count = var.create_log_key ? 1 : 0
+
+  name          = "alias/hipaa-logs-${local.full_suffix}"
+  target_key_id = aws_kms_key.log[0].key_id
+}
+
+# ------------------------------------------------------------------------------
+# EBS Encryption by Default (Conditional)
+# ------------------------------------------------------------------------------
+# Account and region-wide settings: every new EBS volume and snapshot copy is
+# encrypted, with the master key unless the caller names another key. Covers
+# bastions that port-forward to PHI without each instance having to opt in.
+# Destroying these resources turns default encryption back off and restores
+# the aws/ebs managed key.
+resource "aws_ebs_encryption_by_default" "main" {
+  count   = var.enable_ebs_encryption_by_default ? 1 : 0
+  enabled = true
+}
+
+resource "aws_ebs_default_kms_key" "main" {
+  count   = var.enable_ebs_encryption_by_default ? 1 : 0
+  key_arn = aws_kms_key.master.arn
+}
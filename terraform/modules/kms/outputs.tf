@@ -16,3 +16,51 @@ output "kms_key_alias" {
   value       = aws_kms_alias.master.name
   description = "KMS key alias name for easier reference in application code"
 }
+
+output "kms_replica_key_arn" {
+  value       = var.create_replica_key ? aws_kms_replica_key.master[0].arn : ""
+  description = "KMS replica key ARN in the replica region (empty if no replica key)"
+}
+
+output "backup_kms_key_id" {
+  value       = var.create_backup_key ? aws_kms_key.backup[0].key_id : aws_kms_key.master.key_id
+  description = "KMS key ID for backup data: the backup key when create_backup_key is true, otherwise the master key"
+}
+
+output "backup_kms_key_arn" {
+  value       = var.create_backup_key ? aws_kms_key.backup[0].arn : aws_kms_key.master.arn
+  description = "KMS key ARN for backup data: the backup key when create_backup_key is true, otherwise the master key"
+}
+
+output "log_kms_key_arn" {
+  value       = var.create_log_key ? aws_kms_key.log[0].arn : aws_kms_key.master.arn
+  description = "KMS key ARN for audit logs: the log key when create_log_key is true, otherwise the master key"
+}
+
+output "kms_key_policy" {
+  value       = aws_kms_key.master.policy
+  description = "JSON key policy attached to the master key (for compliance verification)"
+}
+
+output "key_administrator_arns" {
+  value       = var.key_administrator_arns
+  description = "Principals granted key management (no cryptographic use) in the key policy"
+}
+
+output "key_user_arns" {
+  value       = var.key_user_arns
+  description = "Principals granted cryptographic use (no key management) in the key policy"
+}
+
+output "kms_rotation_status" {
+  value = {
+    enabled  = aws_kms_key.master.enable_key_rotation
+    schedule = local.rotation_schedule
+  }
+  description = "Rotation status of the master key as refreshed from AWS, with its human-readable schedule"
+}
+
+output "ebs_default_kms_key_arn" {
+  value       = var.enable_ebs_encryption_by_default ? aws_ebs_default_kms_key.main[0].key_arn : ""
+  description = "Key new EBS volumes are encrypted with by default (empty if EBS encryption by default is not managed)"
+}
@@ -39,6 +39,98 @@ variable "enable_key_rotation" {
   default     = true
 }
 
+variable "tag_rotation_metadata" {
+  type        = bool
+  description = "Tag the master key with RotationEnabled and RotationSchedule for audit evidence"
+  default     = true
+}
+
+variable "create_replica_key" {
+  type        = bool
+  description = "Create the master key as a multi-region key with a replica in the aws.replica provider region (changing this on an existing key forces replacement)"
+  default     = false
+}
+
+variable "enable_backup_service_access" {
+  type        = bool
+  description = "Allow the AWS Backup service principal to use the key for copying encrypted RDS snapshots"
+  default     = false
+}
+
+variable "backup_vault_name" {
+  type        = string
+  description = "Backup vault the AWS Backup key access is scoped to (defaults to hipaa-backup-vault-<suffix>)"
+  default     = ""
+}
+
+variable "key_administrator_arns" {
+  type        = list(string)
+  description = "IAM principal ARNs allowed to manage the master key (policy, rotation, deletion) without using it for cryptographic operations"
+  default     = []
+
+  validation {
+    condition     = alltrue([for arn in var.key_administrator_arns : can(regex("^arn:aws[a-z-]*:iam::[0-9]{12}:", arn))])
+    error_message = "key_administrator_arns must contain IAM principal ARNs"
+  }
+}
+
+variable "key_user_arns" {
+  type        = list(string)
+  description = "IAM principal ARNs allowed to encrypt and decrypt with the master key without managing it"
+  default     = []
+
+  validation {
+    condition     = alltrue([for arn in var.key_user_arns : can(regex("^arn:aws[a-z-]*:iam::[0-9]{12}:", arn))])
+    error_message = "key_user_arns must contain IAM principal ARNs"
+  }
+}
+
+variable "enable_ebs_encryption_by_default" {
+  type        = bool
+  description = "Turn on account-level EBS encryption by default in this region with the master key as the default EBS key, so any EC2 volume (bastion, monitoring) is encrypted"
+  default     = false
+}
+
+variable "create_backup_key" {
+  type        = bool
+  description = "Create a dedicated key for backups (RDS storage and backups, the backups bucket) so they do not share the master key that encrypts documents"
+  default     = false
+}
+
+variable "create_log_key" {
+  type        = bool
+  description = "Create a dedicated key for audit logs (CloudTrail, Config delivery, CloudWatch log groups) so log encryption is separate from the data keys"
+  default     = false
+}
+
+variable "allow_destroy" {
+  type        = bool
+  description = "Test teardown escape hatch: shorten the key deletion window from 30 to 7 days (never enable in production)"
+  default     = false
+}
+
+variable "partition" {
+  type        = string
+  description = "AWS partition used to build ARNs (aws, aws-us-gov or aws-cn)"
+  default     = "aws"
+
+  validation {
+    condition     = contains(["aws", "aws-us-gov", "aws-cn"], var.partition)
+    error_message = "partition must be aws, aws-us-gov or aws-cn"
+  }
+}
+
+variable "dns_suffix" {
+  type        = string
+  description = "DNS suffix of the partition used to build service principals (amazonaws.com.cn in China)"
+  default     = "amazonaws.com"
+
+  validation {
+    condition     = contains(["amazonaws.com", "amazonaws.com.cn"], var.dns_suffix)
+    error_message = "dns_suffix must be amazonaws.com or amazonaws.com.cn"
+  }
+}
+
 variable "tags" {
   type        = map(string)
   description = "Additional resource tags to apply to KMS resources"
@@ -0,0 +1,580 @@
+# ==============================================================================
+# Monitoring Module - Critical Alarms
+# ==============================================================================
+# Purpose: CloudWatch alarms for conditions that threaten PHI availability,
+# notifying a regional SNS topic and, optionally, a central-region topic so
+# DR and primary regions share a single pane of glass.
+# ==============================================================================
+
+locals {
+  # Construct environment label with optional suffix for test isolation
+  env_label   = var.environment
+  full_suffix = var.name_suffix == "" ? local.env_label : "${local.env_label}-${var.name_suffix}"
+
+  central_alarms_enabled = var.central_alarm_region != ""
+
+  # Security findings forwarded to an external SIEM
+  siem_enabled   = var.enable_siem_forwarding
+  siem_s3_prefix = "siem-findings/"
+  siem_event_patterns = {
+    config-compliance = {
+      source      = ["aws.config"]
+      detail-type = ["Config Rules Compliance Change"]
+    }
+    guardduty-findings = {
+      source      = ["aws.guardduty"]
+      detail-type = ["GuardDuty Finding"]
+    }
+  }
+
+  xray_enabled = var.enable_xray_tracing
+
+  # ACM alarms keyed by certificate ID, which is unique and short enough for alarm names
+  acm_certificates = { for arn in var.acm_certificate_arns : element(split("/", arn), 1) => arn }
+
+  # Every critical alarm notifies the regional topic and, when set, the central one
+  critical_alarm_actions = concat(
+    [aws_sns_topic.alarms.arn],
+    local.central_alarms_enabled ? [aws_sns_topic.central[0].arn] : []
+  )
+
+  common_tags = merge(
+    var.tags,
+    {
+      Module      = "monitoring"
+      Environment = var.environment
+      Context     = var.name_suffix
+      ManagedBy   = "Terraform"
+    }
+  )
+}
+
+data "aws_caller_identity" "current" {}
+
+# ------------------------------------------------------------------------------
+# Regional Alarm Topic
+# ------------------------------------------------------------------------------
+resource "aws_sns_topic" "alarms" {
+  name              = "${local.full_suffix}-critical-alarms"
+  display_name      = "Critical Alarms - ${local.full_suffix}"
+  kms_master_key_id = "alias/aws/sns"
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${local.full_suffix}-critical-alarms"
+    }
+  )
+}
+
+resource "aws_sns_topic_policy" "alarms" {
+  arn = aws_sns_topic.alarms.arn
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Principal = {
+          Service = "cloudwatch.${var.dns_suffix}"
+        }
+        Action   = "SNS:Publish"
+        Resource = aws_sns_topic.alarms.arn
+        Condition = {
+          StringEquals = {
+            "aws:SourceAccount" = data.aws_caller_identity.current.account_id
+          }
+        }
+      }
+    ]
+  })
+}
+
+resource "aws_sns_topic_subscription" "alarms_email" {
+  count = var.alarm_email != "" ? 1 : 0
+
+  topic_arn = aws_sns_topic.alarms.arn
+  protocol  = "email"
+  endpoint  = var.alarm_email
+}
+
+# ------------------------------------------------------------------------------
+# Central Alarm Topic (Conditional)
+# ------------------------------------------------------------------------------
+# Created through the aws.central provider in central_alarm_region and added
+# to every critical alarm's actions alongside the regional topic.
+
+resource "aws_sns_topic" "central" {
+  count    = local.central_alarms_enabled ? 1 : 0
+  provider = aws.central
+
+  name              = "${local.full_suffix}-critical-alarms-central"
+  display_name      = "Critical Alarms (central) - ${local.full_suffix}"
+  kms_master_key_id = "alias/aws/sns"
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${local.full_suffix}-critical-alarms-central"
+    }
+  )
+}
+
+resource "aws_sns_topic_policy" "central" {
+  count    = local.central_alarms_enabled ? 1 : 0
+  provider = aws.central
+
+  arn = aws_sns_topic.central[0].arn
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Principal = {
+          Service = "cloudwatch.${var.dns_suffix}"
+        }
+        Action   = "SNS:Publish"
+        Resource = aws_sns_topic.central[0].arn
+        Condition = {
+          StringEquals = {
+            "aws:SourceAccount" = data.aws_caller_identity.current.account_id
+          }
+        }
+      }
+    ]
+  })
+}
+
+resource "aws_sns_topic_subscription" "central_email" {
+  count    = local.central_alarms_enabled && var.alarm_email != "" ? 1 : 0
+  provider = aws.central
+
+  topic_arn = aws_sns_topic.central[0].arn
+  protocol  = "email"
+  endpoint  = var.alarm_email
+}
+
+# ------------------------------------------------------------------------------
+# RDS Critical Alarms
+# ------------------------------------------------------------------------------
+resource "aws_cloudwatch_metric_alarm" "rds_cpu_high" {
+  alarm_name          = "${local.full_suffix}-rds-cpu-high"
+  alarm_description   = "RDS ${var.rds_instance_identifier} CPU above ${var.rds_cpu_threshold}% for 15 minutes"
+  namespace           = "AWS/RDS"
+  metric_name         = "CPUUtilization"
+  statistic           = "Average"
+  period              = 300
+  evaluation_periods  = 3
+  threshold           = var.rds_cpu_threshold
+  comparison_operator = "GreaterThanThreshold"
+  treat_missing_data  = "missing"
+
+  dimensions = {
+    DBInstanceIdentifier = var.rds_instance_identifier
+  }
+
+  alarm_actions = local.critical_alarm_actions
+  ok_actions    = local.critical_alarm_actions
+
+  tags = local.common_tags
+}
+
+resource "aws_cloudwatch_metric_alarm" "rds_free_storage_low" {
+  alarm_name          = "${local.full_suffix}-rds-free-storage-low"
+  alarm_description   = "RDS ${var.rds_instance_identifier} free storage below ${var.rds_free_storage_threshold_gb} GB"
+  namespace           = "AWS/RDS"
+  metric_name         = "FreeStorageSpace"
+  statistic           = "Minimum"
+  period              = 300
+  evaluation_periods  = 1
+  threshold           = var.rds_free_storage_threshold_gb * 1024 * 1024 * 1024
+  comparison_operator = "LessThanThreshold"
+  treat_missing_data  = "missing"
+
+  dimensions = {
+    DBInstanceIdentifier = var.rds_instance_identifier
+  }
+
+  alarm_actions = local.critical_alarm_actions
+  ok_actions    = local.critical_alarm_actions
+
+  tags = local.common_tags
+}
+
+# gp3 throttles silently at its provisioned throughput, so alarm on combined
+# read + write bytes approaching the limit before vector queries degrade
+resource "aws_cloudwatch_metric_alarm" "rds_storage_throughput_high" {
+  alarm_name          = "${local.full_suffix}-rds-storage-throughput-high"
+  alarm_description   = "RDS ${var.rds_instance_identifier} gp3 throughput above ${var.rds_throughput_threshold_percent}% of ${var.rds_storage_throughput_mibps} MiB/s for 15 minutes"
+  evaluation_periods  = 3
+  threshold           = var.rds_throughput_threshold_percent
+  comparison_operator = "GreaterThanThreshold"
+  treat_missing_data  = "missing"
+
+  metric_query {
+    id          = "utilization"
+    expression  = "100 * (read + write) / (${var.rds_storage_throughput_mibps} * 1048576)"
+    label       = "gp3 throughput utilization (%)"
+    return_data = true
+  }
+
+  metric_query {
+    id = "read"
+
+    metric {
+      namespace   = "AWS/RDS"
+      metric_name = "ReadThroughput"
+      stat        = "Average"
+      period      = 300
+
+      dimensions = {
+        DBInstanceIdentifier = var.rds_instance_identifier
+      }
+    }
+  }
+
+  metric_query {
+    id = "write"
+
+    metric {
+      namespace   = "AWS/RDS"
+      metric_name = "WriteThroughput"
+      stat        = "Average"
+      period      = 300
+
+      dimensions = {
+        DBInstanceIdentifier = var.rds_instance_identifier
+      }
+    }
+  }
+
+  alarm_actions = local.critical_alarm_actions
+  ok_actions    = local.critical_alarm_actions
+
+  tags = local.common_tags
+}
+
+# ------------------------------------------------------------------------------
+# Certificate Expiry Alarms
+# ------------------------------------------------------------------------------
+# ACM publishes DaysToExpiry daily per certificate. RDS has no equivalent
+# metric, so the CA alarm derives days remaining from the CA's valid_till and
+# the timestamps of the instance's own CPU datapoints, which keeps the count
+# current between applies. A CA rotation changes rds_ca_cert_identifier and
+# the next apply moves the deadline.
+
+resource "aws_cloudwatch_metric_alarm" "acm_certificate_expiry" {
+  for_each = local.acm_certificates
+
+  alarm_name          = "${local.full_suffix}-acm-expiry-${each.key}"
+  alarm_description   = "ACM certificate ${each.value} expires in under ${var.cert_expiry_warning_days} days"
+  namespace           = "AWS/CertificateManager"
+  metric_name         = "DaysToExpiry"
+  statistic           = "Minimum"
+  period              = 86400
+  evaluation_periods  = 1
+  threshold           = var.cert_expiry_warning_days
+  comparison_operator = "LessThanThreshold"
+  treat_missing_data  = "missing"
+
+  dimensions = {
+    CertificateArn = each.value
+  }
+
+  alarm_actions = local.critical_alarm_actions
+  ok_actions    = local.critical_alarm_actions
+
+  tags = local.common_tags
+}
+
+data "aws_rds_certificate" "current" {
+  id = var.rds_ca_cert_identifier
+}
+
+resource "time_static" "rds_ca_valid_till" {
+  rfc3339 = data.aws_rds_certificate.current.valid_till
+}
+
+resource "aws_cloudwatch_metric_alarm" "rds_ca_expiry" {
+  alarm_name          = "${local.full_suffix}-rds-ca-expiry"
+  alarm_description   = "RDS ${var.rds_instance_identifier} CA ${var.rds_ca_cert_identifier} expires in under ${var.cert_expiry_warning_days} days"
+  evaluation_periods  = 1
+  threshold           = var.cert_expiry_warning_days
+  comparison_operator = "LessThanThreshold"
+  treat_missing_data  = "missing"
+
+  metric_query {
+    id          = "days_to_expiry"
+    expression  = "(${time_static.rds_ca_valid_till.unix} - EPOCH(cpu)) / 86400"
+    label       = "Days until ${var.rds_ca_cert_identifier} expires"
+    return_data = true
+  }
+
+  metric_query {
+    id = "cpu"
+
+    metric {
+      namespace   = "AWS/RDS"
+      metric_name = "CPUUtilization"
+      stat        = "Maximum"
+      period      = 86400
+
+      dimensions = {
+        DBInstanceIdentifier = var.rds_instance_identifier
+      }
+    }
+  }
+
+  alarm_actions = local.critical_alarm_actions
+  ok_actions    = local.critical_alarm_actions
+
+  tags = local.common_tags
+}
+
+# ------------------------------------------------------------------------------
+# SIEM Findings Forwarding (Conditional)
+# ------------------------------------------------------------------------------
+# EventBridge forwards Config compliance changes and GuardDuty findings to a
+# KMS-encrypted Firehose stream as newline-delimited JSON events, delivered to
+# a SIEM HTTP endpoint (Splunk HEC, etc.) or to S3 for SIEM pull ingestion.
+# The S3 bucket also receives records the HTTP endpoint rejects.
+
+resource "aws_iam_role" "siem_firehose" {
+  count = local.siem_enabled ? 1 : 0
+  name  = "${local.full_suffix}-siem-firehose"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Principal = {
+          Service = "firehose.${var.dns_suffix}"
+        }
+        Action = "sts:AssumeRole"
+        Condition = {
+          StringEquals = {
+            "sts:ExternalId" = data.aws_caller_identity.current.account_id
+          }
+        }
+      }
+    ]
+  })
+
+  tags = local.common_tags
+}
+
+resource "aws_iam_role_policy" "siem_firehose" {
+  count = local.siem_enabled ? 1 : 0
+  name  = "${local.full_suffix}-siem-firehose"
+  role  = aws_iam_role.siem_firehose[0].id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid    = "DeliverToBucket"
+        Effect = "Allow"
+        Action = [
+          "s3:AbortMultipartUpload",
+          "s3:GetBucketLocation",
+          "s3:ListBucket",
+          "s3:ListBucketMultipartUploads",
+          "s3:PutObject"
+        ]
+        Resource = [
+          var.siem_s3_bucket_arn,
+          "${var.siem_s3_bucket_arn}/${local.siem_s3_prefix}*"
+        ]
+      },
+      {
+        Sid    = "EncryptDeliveredObjects"
+        Effect = "Allow"
+        Action = [
+          "kms:Decrypt",
+          "kms:GenerateDataKey"
+        ]
+        Resource = var.siem_kms_key_arn
+      }
+    ]
+  })
+}
+
+resource "aws_kinesis_firehose_delivery_stream" "siem" {
+  count       = local.siem_enabled ? 1 : 0
+  name        = "${local.full_suffix}-siem-findings"
+  destination = var.siem_destination == "http_endpoint" ? "http_endpoint" : "extended_s3"
+
+  server_side_encryption {
+    enabled  = true
+    key_type = "CUSTOMER_MANAGED_CMK"
+    key_arn  = var.siem_kms_key_arn
+  }
+
+  dynamic "extended_s3_configuration" {
+    for_each = var.siem_destination == "s3" ? [1] : []
+
+    content {
+      role_arn            = aws_iam_role.siem_firehose[0].arn
+      bucket_arn          = var.siem_s3_bucket_arn
+      prefix              = "${local.siem_s3_prefix}!{timestamp:yyyy/MM/dd}/"
+      error_output_prefix = "${local.siem_s3_prefix}errors/!{firehose:error-output-type}/!{timestamp:yyyy/MM/dd}/"
+      kms_key_arn         = var.siem_kms_key_arn
+      buffering_interval  = 60
+      compression_format  = "GZIP"
+
+      # One JSON event per line, the format SIEM S3 inputs expect
+      processing_configuration {
+        enabled = true
+
+        processors {
+          type = "AppendDelimiterToRecord"
+        }
+      }
+    }
+  }
+
+  dynamic "http_endpoint_configuration" {
+    for_each = var.siem_destination == "http_endpoint" ? [1] : []
+
+    content {
+      name               = var.siem_http_endpoint_name
+      url                = var.siem_http_endpoint_url
+      access_key         = var.siem_http_endpoint_access_key
+      role_arn           = aws_iam_role.siem_firehose[0].arn
+      s3_backup_mode     = "FailedDataOnly"
+      buffering_interval = 60
+      retry_duration     = 300
+
+      request_configuration {
+        content_encoding = "GZIP"
+
+        common_attributes {
+          name  = "environment"
+          value = local.full_suffix
+        }
+      }
+
+      s3_configuration {
+        role_arn           = aws_iam_role.siem_firehose[0].arn
+        bucket_arn         = var.siem_s3_bucket_arn
+        prefix             = "${local.siem_s3_prefix}failed/"
+        kms_key_arn        = var.siem_kms_key_arn
+        compression_format = "GZIP"
+      }
+    }
+  }
+
+  tags = local.common_tags
+
+  lifecycle {
+    precondition {
+      condition     = var.siem_s3_bucket_arn != "" && var.siem_kms_key_arn != ""
+      error_message = "enable_siem_forwarding requires siem_s3_bucket_arn and siem_kms_key_arn"
+    }
+
+    precondition {
+      condition     = var.siem_destination != "http_endpoint" || var.siem_http_endpoint_url != ""
+      error_message = "siem_destination = \"http_endpoint\" requires siem_http_endpoint_url"
+    }
+  }
+}
+
+resource "aws_iam_role" "siem_events" {
+  count = local.siem_enabled ? 1 : 0
+  name  = "${local.full_suffix}-siem-events"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Principal = {
+          Service = "events.${var.dns_suffix}"
+        }
+        Action = "sts:AssumeRole"
+      }
+    ]
+  })
+
+  tags = local.common_tags
+}
+
+resource "aws_iam_role_policy" "siem_events" {
+  count = local.siem_enabled ? 1 : 0
+  name  = "${local.full_suffix}-siem-events"
+  role  = aws_iam_role.siem_events[0].id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Action = [
+          "firehose:PutRecord",
+          "firehose:PutRecordBatch"
+        ]
+        Resource = aws_kinesis_firehose_delivery_stream.siem[0].arn
+      }
+    ]
+  })
+}
+
+resource "aws_cloudwatch_event_rule" "siem" {
+  for_each = local.siem_enabled ? local.siem_event_patterns : {}
+
+  name          = "${local.full_suffix}-siem-${each.key}"
+  description   = "Forward ${each.key} events to the SIEM delivery stream"
+  event_pattern = jsonencode(each.value)
+
+  tags = local.common_tags
+}
+
+resource "aws_cloudwatch_event_target" "siem" {
+  for_each = aws_cloudwatch_event_rule.siem
+
+  rule     = each.value.name
+  arn      = aws_kinesis_firehose_delivery_stream.siem[0].arn
+  role_arn = aws_iam_role.siem_events[0].arn
+}
+
+# ------------------------------------------------------------------------------
+# X-Ray Tracing (Conditional)
+# ------------------------------------------------------------------------------
+# Traces can carry request paths and annotations derived from PHI, so trace
+# storage is encrypted with the customer managed key instead of the X-Ray
+# default. The encryption config is a per-region account singleton: enable it
+# in one stack per account and region.
+
+resource "aws_xray_encryption_config" "main" {
+  count  = local.xray_enabled ? 1 : 0
+  type   = "KMS"
+  key_id = var.xray_kms_key_arn
+
+  lifecycle {
+    precondition {
+      condition     = var.xray_kms_key_arn != ""
+      error_message = "xray_kms_key_arn is required when enable_xray_tracing is true."
+    }
+  }
+}
+
+resource "aws_xray_sampling_rule" "main" {
+  count = local.xray_enabled ? 1 : 0
+
+  # Rule names are limited to 32 characters
+  rule_name      = substr("hipaa-${local.full_suffix}", 0, 32)
+  priority       = 1000
+  version        = 1
+  reservoir_size = var.xray_reservoir_size
+  fixed_rate     = var.xray_sampling_rate
+  service_name   = "*"
+  service_type   = "*"
+  host           = "*"
+  http_method    = "*"
+  url_path       = "*"
+  resource_arn   = "*"
+
+  tags = local.common_tags
+}
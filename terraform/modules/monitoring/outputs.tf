@@ -0,0 +1,61 @@
+# ==============================================================================
+# Monitoring Module - Output Values
+# ==============================================================================
+
+output "alarm_topic_arn" {
+  value       = aws_sns_topic.alarms.arn
+  description = "ARN of the regional SNS topic notified by critical alarms"
+}
+
+output "central_alarm_topic_arn" {
+  value       = local.central_alarms_enabled ? aws_sns_topic.central[0].arn : ""
+  description = "ARN of the central-region SNS topic notified by critical alarms (empty if central_alarm_region is unset)"
+}
+
+output "critical_alarm_names" {
+  value = {
+    rds_cpu_high                = aws_cloudwatch_metric_alarm.rds_cpu_high.alarm_name
+    rds_free_storage_low        = aws_cloudwatch_metric_alarm.rds_free_storage_low.alarm_name
+    rds_storage_throughput_high = aws_cloudwatch_metric_alarm.rds_storage_throughput_high.alarm_name
+    rds_ca_expiry               = aws_cloudwatch_metric_alarm.rds_ca_expiry.alarm_name
+  }
+  description = "Map of critical alarm names"
+}
+
+output "rds_throughput_alarm_arn" {
+  value       = aws_cloudwatch_metric_alarm.rds_storage_throughput_high.arn
+  description = "ARN of the RDS gp3 storage throughput alarm"
+}
+
+output "cert_expiry_alarm_arns" {
+  value = merge(
+    { rds_ca = aws_cloudwatch_metric_alarm.rds_ca_expiry.arn },
+    { for id, alarm in aws_cloudwatch_metric_alarm.acm_certificate_expiry : "acm-${id}" => alarm.arn }
+  )
+  description = "Certificate expiry alarm ARNs: rds_ca, plus acm-<certificate id> per watched ACM certificate"
+}
+
+output "rds_ca_valid_till" {
+  value       = data.aws_rds_certificate.current.valid_till
+  description = "Expiry (RFC 3339) of the CA the RDS CA expiry alarm counts down to"
+}
+
+output "siem_firehose_arn" {
+  value       = local.siem_enabled ? aws_kinesis_firehose_delivery_stream.siem[0].arn : ""
+  description = "ARN of the Firehose stream forwarding security findings to the SIEM (empty if disabled)"
+}
+
+output "siem_event_rule_names" {
+  value       = { for key, rule in aws_cloudwatch_event_rule.siem : key => rule.name }
+  description = "EventBridge rules routing Config and GuardDuty events to the SIEM stream, keyed by source (empty if disabled)"
+}
+
+output "xray_encryption_key_arn" {
+  value       = local.xray_enabled ? aws_xray_encryption_config.main[0].key_id : ""
+  description = "KMS key ARN encrypting X-Ray traces (empty if tracing is disabled)"
+}
+
+output "xray_sampling_rule_name" {
+  value       = local.xray_enabled ? aws_xray_sampling_rule.main[0].rule_name : ""
+  description = "Name of the X-Ray sampling rule for this environment (empty if tracing is disabled)"
+}
@@ -0,0 +1,237 @@
+# ==============================================================================
+# Monitoring Module - Input Variables
+# ==============================================================================
+
+variable "environment" {
+  type        = string
+  description = "Environment name (dev, staging, production)"
+
+  validation {
+    condition     = contains(["dev", "staging", "production"], var.environment)
+    error_message = "Environment must be dev, staging, or production"
+  }
+}
+
+variable "name_suffix" {
+  type        = string
+  description = "Optional suffix for resource names (tests/ephemeral runs)"
+  default     = ""
+
+  validation {
+    condition     = can(regex("^[a-z0-9-]*$", var.name_suffix))
+    error_message = "name_suffix may contain only lowercase letters, digits, and hyphens"
+  }
+}
+
+variable "rds_instance_identifier" {
+  type        = string
+  description = "Identifier of the primary RDS instance to alarm on"
+
+  validation {
+    condition     = can(regex("^[a-z][a-z0-9-]{0,62}$", var.rds_instance_identifier))
+    error_message = "rds_instance_identifier must be an RDS instance identifier, not empty or an ARN"
+  }
+}
+
+variable "alarm_email" {
+  type        = string
+  description = "Email subscribed to critical alarm notifications (empty disables the subscription)"
+  default     = ""
+}
+
+variable "rds_cpu_threshold" {
+  type        = number
+  description = "Average CPUUtilization (%) above which the RDS CPU alarm fires"
+  default     = 80
+
+  validation {
+    condition     = var.rds_cpu_threshold > 0 && var.rds_cpu_threshold <= 100
+    error_message = "rds_cpu_threshold must be between 1 and 100"
+  }
+}
+
+variable "rds_free_storage_threshold_gb" {
+  type        = number
+  description = "Free storage (GB) below which the RDS storage alarm fires"
+  default     = 5
+
+  validation {
+    condition     = var.rds_free_storage_threshold_gb > 0
+    error_message = "rds_free_storage_threshold_gb must be greater than 0"
+  }
+}
+
+variable "rds_storage_throughput_mibps" {
+  type        = number
+  description = "Provisioned gp3 storage throughput (MiB/s) of the RDS instance; 125 is the gp3 baseline"
+  default     = 125
+
+  validation {
+    condition     = var.rds_storage_throughput_mibps >= 125
+    error_message = "rds_storage_throughput_mibps must be at least the gp3 baseline of 125"
+  }
+}
+
+variable "rds_throughput_threshold_percent" {
+  type        = number
+  description = "Percentage of provisioned gp3 throughput above which the RDS throughput alarm fires"
+  default     = 80
+
+  validation {
+    condition     = var.rds_throughput_threshold_percent > 0 && var.rds_throughput_threshold_percent <= 100
+    error_message = "rds_throughput_threshold_percent must be between 1 and 100"
+  }
+}
+
+variable "rds_ca_cert_identifier" {
+  type        = string
+  description = "Certificate authority of the RDS instance's server certificate, watched by the CA expiry alarm"
+  default     = "rds-ca-rsa2048-g1"
+}
+
+variable "acm_certificate_arns" {
+  type        = list(string)
+  description = "ACM certificates (in this region) that each get a DaysToExpiry alarm"
+  default     = []
+
+  validation {
+    condition     = alltrue([for arn in var.acm_certificate_arns : can(regex("^arn:aws[a-z-]*:acm:[a-z0-9-]+:[0-9]{12}:certificate/[0-9a-f-]+$", arn))])
+    error_message = "acm_certificate_arns must contain ACM certificate ARNs"
+  }
+}
+
+variable "cert_expiry_warning_days" {
+  type        = number
+  description = "Days before an ACM certificate or the RDS CA expires at which the expiry alarms fire"
+  default     = 30
+
+  validation {
+    condition     = var.cert_expiry_warning_days >= 1 && var.cert_expiry_warning_days <= 365 && floor(var.cert_expiry_warning_days) == var.cert_expiry_warning_days
+    error_message = "cert_expiry_warning_days must be a whole number between 1 and 365"
+  }
+}
+
+variable "central_alarm_region" {
+  type        = string
+  description = "Region of a central alarm topic that also receives critical alarm notifications (empty disables); requires the aws.central provider in that region"
+  default     = ""
+}
+
+variable "enable_siem_forwarding" {
+  type        = bool
+  description = "Forward Config compliance changes and GuardDuty findings to an external SIEM through a KMS-encrypted Firehose stream"
+  default     = false
+}
+
+variable "siem_destination" {
+  type        = string
+  description = "Firehose destination for SIEM forwarding: s3 (SIEM pulls from the bucket) or http_endpoint (push to siem_http_endpoint_url)"
+  default     = "s3"
+
+  validation {
+    condition     = contains(["s3", "http_endpoint"], var.siem_destination)
+    error_message = "siem_destination must be s3 or http_endpoint"
+  }
+}
+
+variable "siem_s3_bucket_arn" {
+  type        = string
+  description = "Bucket receiving SIEM findings under siem-findings/ (s3 destination) or records the HTTP endpoint rejects (required when enable_siem_forwarding is true)"
+  default     = ""
+
+  validation {
+    condition     = var.siem_s3_bucket_arn == "" || can(regex("^arn:aws[a-z-]*:s3:::[a-z0-9.-]+$", var.siem_s3_bucket_arn))
+    error_message = "siem_s3_bucket_arn must be an S3 bucket ARN (arn:aws:s3:::bucket-name)"
+  }
+}
+
+variable "siem_kms_key_arn" {
+  type        = string
+  description = "KMS key encrypting the SIEM delivery stream and delivered objects (required when enable_siem_forwarding is true)"
+  default     = ""
+
+  validation {
+    condition     = var.siem_kms_key_arn == "" || can(regex("^arn:aws[a-z-]*:kms:[a-z0-9-]+:[0-9]{12}:key/.+$", var.siem_kms_key_arn))
+    error_message = "siem_kms_key_arn must be a valid KMS key ARN"
+  }
+}
+
+variable "siem_http_endpoint_url" {
+  type        = string
+  description = "HTTPS URL of the SIEM ingestion endpoint (required when siem_destination is http_endpoint)"
+  default     = ""
+
+  validation {
+    condition     = var.siem_http_endpoint_url == "" || startswith(var.siem_http_endpoint_url, "https://")
+    error_message = "siem_http_endpoint_url must use https://"
+  }
+}
+
+variable "siem_http_endpoint_name" {
+  type        = string
+  description = "Display name of the SIEM HTTP endpoint"
+  default     = "SIEM"
+}
+
+variable "siem_http_endpoint_access_key" {
+  type        = string
+  description = "Access key or token sent to the SIEM HTTP endpoint (e.g. Splunk HEC token)"
+  default     = ""
+  sensitive   = true
+}
+
+variable "enable_xray_tracing" {
+  type        = bool
+  description = "Encrypt X-Ray trace storage with a customer managed KMS key and install the stack's sampling rule"
+  default     = false
+}
+
+variable "xray_kms_key_arn" {
+  type        = string
+  description = "KMS key encrypting X-Ray traces at rest (required when enable_xray_tracing is true)"
+  default     = ""
+
+  validation {
+    condition     = var.xray_kms_key_arn == "" || can(regex("^arn:aws[a-z-]*:kms:[a-z0-9-]+:[0-9]{12}:key/.+$", var.xray_kms_key_arn))
+    error_message = "xray_kms_key_arn must be a valid KMS key ARN"
+  }
+}
+
+variable "xray_sampling_rate" {
+  type        = number
+  description = "Fraction of requests traced after the per-second reservoir is used up"
+  default     = 0.05
+
+  validation {
+    condition     = var.xray_sampling_rate >= 0 && var.xray_sampling_rate <= 1
+    error_message = "xray_sampling_rate must be between 0 and 1"
+  }
+}
+
+variable "xray_reservoir_size" {
+  type        = number
+  description = "Requests per second traced before xray_sampling_rate applies"
+  default     = 1
+
+  validation {
+    condition     = var.xray_reservoir_size >= 0 && floor(var.xray_reservoir_size) == var.xray_reservoir_size
+    error_message = "xray_reservoir_size must be a non-negative whole number"
+  }
+}
+
+variable "dns_suffix" {
+  type        = string
+  description = "DNS suffix of the partition used to build service principals (amazonaws.com.cn in China)"
+  default     = "amazonaws.com"
+
+  validation {
+    condition     = contains(["amazonaws.com", "amazonaws.com.cn"], var.dns_suffix)
+    error_message = "dns_suffix must be amazonaws.com or amazonaws.com.cn"
+  }
+}
+
+variable "tags" {
+  type        = map(string)
+  description = "Additional resource tags to apply to all monitoring resources"
+  default     = {}
+}
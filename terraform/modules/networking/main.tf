@@ -119,6 +119,19 @@ resource "aws_security_group_rule" "app_egress_to_vpc_endpoints" {
   description              = "Allow HTTPS to VPC endpoints (S3, Bedrock)"
 }
 
+# Egress rule: Broad HTTPS for deployments without interface endpoints
+# Bedrock prompts can carry PHI, so this path through NAT is opt-in only
+resource "aws_security_group_rule" "app_egress_https_internet" {
+  count             = var.restrict_bedrock_egress ? 0 : 1
+  type              = "egress"
+  from_port         = 443
+  to_port           = 443
+  protocol          = "tcp"
+  cidr_blocks       = ["0.0.0.0/0"]
+  security_group_id = aws_security_group.app.id
+  description       = "Allow HTTPS to AWS services over the internet (restrict_bedrock_egress = false)"
+}
+
 # ------------------------------------------------------------------------------
 # VPC Endpoint Security Group
 # ------------------------------------------------------------------------------
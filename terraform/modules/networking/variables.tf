@@ -54,6 +54,12 @@ variable "railway_ip_ranges" {
   }
 }
 
+variable "restrict_bedrock_egress" {
+  type        = bool
+  description = "Limit the app's HTTPS egress to the VPC endpoint security group so Bedrock (and other AWS APIs) are only reachable through interface endpoints; false adds HTTPS egress to 0.0.0.0/0"
+  default     = true
+}
+
 variable "tags" {
   type        = map(string)
   description = "Additional resource tags to apply to all security groups"
@@ -0,0 +1,110 @@
+# ==============================================================================
+# PrivateLink Module - Partner Endpoint Service
+# ==============================================================================
+# Purpose: Let healthcare partners reach the service from their own VPCs over
+# AWS PrivateLink instead of the internet. An internal Network Load Balancer
+# fronts the service, and a VPC endpoint service exposes it only to
+# allow-listed partner principals, with every connection manually accepted.
+# Dependencies: VPC (subnets); targets are registered by private IP
+# ==============================================================================
+
+locals {
+  # Construct environment label with optional suffix for test isolation
+  env_label   = var.environment
+  full_suffix = var.name_suffix == "" ? local.env_label : "${local.env_label}-${var.name_suffix}"
+
+  # Load balancer and target group names are limited to 32 characters
+  nlb_name = trimsuffix(substr("hipaa-pl-${local.full_suffix}", 0, 32), "-")
+
+  common_tags = merge(
+    var.tags,
+    {
+      Module      = "privatelink"
+      Environment = var.environment
+      Context     = var.name_suffix
+      ManagedBy   = "Terraform"
+    }
+  )
+}
+
+# ------------------------------------------------------------------------------
+# Network Load Balancer
+# ------------------------------------------------------------------------------
+# Internal only; partners reach it through their interface endpoints. TCP
+# passthrough keeps TLS end to end between the partner and the targets.
+
+resource "aws_lb" "main" {
+  name                             = local.nlb_name
+  internal                         = true
+  load_balancer_type               = "network"
+  subnets                          = var.subnet_ids
+  enable_cross_zone_load_balancing = true
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = local.nlb_name
+    }
+  )
+}
+
+resource "aws_lb_target_group" "main" {
+  name        = local.nlb_name
+  port        = var.service_port
+  protocol    = "TCP"
+  target_type = "ip"
+  vpc_id      = var.vpc_id
+
+  health_check {
+    protocol = "TCP"
+  }
+
+  tags = local.common_tags
+}
+
+resource "aws_lb_target_group_attachment" "main" {
+  count = length(var.target_ip_addresses)
+
+  target_group_arn = aws_lb_target_group.main.arn
+  target_id        = var.target_ip_addresses[count.index]
+  port             = var.service_port
+}
+
+resource "aws_lb_listener" "main" {
+  load_balancer_arn = aws_lb.main.arn
+  port              = var.service_port
+  protocol          = "TCP"
+
+  default_action {
+    type             = "forward"
+    target_group_arn = aws_lb_target_group.main.arn
+  }
+
+  tags = local.common_tags
+}
+
+# ------------------------------------------------------------------------------
+# VPC Endpoint Service
+# ------------------------------------------------------------------------------
+# Only allowed_principal_arns can discover and request a connection, and each
+# request still waits for acceptance, so a leaked service name or a mistyped
+# principal cannot connect on its own.
+
+resource "aws_vpc_endpoint_service" "main" {
+  acceptance_required        = true
+  network_load_balancer_arns = [aws_lb.main.arn]
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "hipaa-partner-endpoint-service-${local.full_suffix}"
+    }
+  )
+}
+
+resource "aws_vpc_endpoint_service_allowed_principal" "partners" {
+  for_each = toset(var.allowed_principal_arns)
+
+  vpc_endpoint_service_id = aws_vpc_endpoint_service.main.id
+  principal_arn           = each.value
+}
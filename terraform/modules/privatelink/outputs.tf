@@ -0,0 +1,28 @@
+# ==============================================================================
+# PrivateLink Module - Output Values
+# ==============================================================================
+
+output "endpoint_service_name" {
+  value       = aws_vpc_endpoint_service.main.service_name
+  description = "Endpoint service name partners use to create their interface endpoint"
+}
+
+output "endpoint_service_id" {
+  value       = aws_vpc_endpoint_service.main.id
+  description = "Endpoint service ID, used to accept or reject partner connection requests"
+}
+
+output "nlb_arn" {
+  value       = aws_lb.main.arn
+  description = "ARN of the internal Network Load Balancer fronting the service"
+}
+
+output "target_group_arn" {
+  value       = aws_lb_target_group.main.arn
+  description = "ARN of the NLB target group for registering additional targets"
+}
+
+output "allowed_principal_arns" {
+  value       = sort(var.allowed_principal_arns)
+  description = "Partner principals allowed to request connections to the endpoint service"
+}
@@ -0,0 +1,82 @@
+# ==============================================================================
+# PrivateLink Module - Input Variables
+# ==============================================================================
+
+variable "environment" {
+  type        = string
+  description = "Deployment tier (dev, staging, production)"
+
+  validation {
+    condition     = contains(["dev", "staging", "production"], var.environment)
+    error_message = "Environment must be one of dev, staging, production."
+  }
+}
+
+variable "name_suffix" {
+  type        = string
+  default     = ""
+  description = "Optional suffix for resource names (tests/ephemeral runs)"
+
+  validation {
+    condition     = can(regex("^[a-z0-9-]*$", var.name_suffix))
+    error_message = "name_suffix may contain only lowercase letters, digits, and hyphens."
+  }
+}
+
+variable "vpc_id" {
+  type        = string
+  description = "VPC the Network Load Balancer and its targets live in"
+}
+
+variable "subnet_ids" {
+  type        = list(string)
+  description = "Private subnet IDs for the internal Network Load Balancer (one per AZ partners connect from)"
+
+  validation {
+    condition     = length(var.subnet_ids) > 0
+    error_message = "subnet_ids must contain at least one subnet"
+  }
+}
+
+variable "service_port" {
+  type        = number
+  description = "TCP port partners connect to and targets listen on; targets terminate TLS"
+  default     = 443
+
+  validation {
+    condition     = var.service_port >= 1 && var.service_port <= 65535
+    error_message = "service_port must be between 1 and 65535"
+  }
+}
+
+variable "target_ip_addresses" {
+  type        = list(string)
+  description = "Private IPv4 addresses in the VPC registered as NLB targets for the partner-facing service"
+  default     = []
+
+  validation {
+    condition     = alltrue([for ip in var.target_ip_addresses : can(cidrhost("${ip}/32", 0))])
+    error_message = "target_ip_addresses must contain IPv4 addresses"
+  }
+}
+
+variable "allowed_principal_arns" {
+  type        = list(string)
+  description = "Partner AWS principals (account root, role or user ARNs) allowed to create endpoints to the service; connections still need manual acceptance"
+
+  validation {
+    condition     = length(var.allowed_principal_arns) > 0
+    error_message = "allowed_principal_arns must name at least one partner principal"
+  }
+
+  validation {
+    condition     = alltrue([for arn in var.allowed_principal_arns : can(regex("^arn:aws[a-z-]*:iam::[0-9]{12}:(root|role/.+|user/.+)$", arn))])
+    error_message = "allowed_principal_arns must contain IAM account root, role or user ARNs; wildcards are not allowed"
+  }
+}
+
+variable "tags" {
+  type        = map(string)
+  description = "Additional resource tags"
+  default     = {}
+}
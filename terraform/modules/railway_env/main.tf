@@ -0,0 +1,31 @@
+# ==============================================================================
+# Railway Env Module - Main Configuration
+# ==============================================================================
+# Purpose: Render stack outputs as a dotenv file for the Railway deploy step.
+#          Secrets are emitted as SSM parameter paths, never as plaintext.
+# ==============================================================================
+
+locals {
+  # Secrets are exposed only by reference so the file is safe to hand to Railway
+  secret_references = {
+    for key, path in var.secret_ssm_parameters : "${key}_SSM_PARAMETER" => path
+  }
+
+  rendered_vars = merge(var.env_vars, local.secret_references)
+
+  # keys() is sorted, so the file content is stable across plans
+  env_file_content = join("", [
+    for key in keys(local.rendered_vars) : "${key}=${local.rendered_vars[key]}\n"
+  ])
+}
+
+# ------------------------------------------------------------------------------
+# Dotenv File (Conditional)
+# ------------------------------------------------------------------------------
+resource "local_file" "env" {
+  count = var.write_env_file ? 1 : 0
+
+  filename        = var.env_file_path
+  content         = local.env_file_content
+  file_permission = "0600"
+}
@@ -0,0 +1,18 @@
+# ==============================================================================
+# Railway Env Module - Output Values
+# ==============================================================================
+
+output "env_file_content" {
+  value       = local.env_file_content
+  description = "Rendered dotenv content (KEY=value per line, secrets as SSM parameter paths)"
+}
+
+output "env_file_path" {
+  value       = var.write_env_file ? local_file.env[0].filename : ""
+  description = "Path of the written dotenv file (empty if write_env_file is false)"
+}
+
+output "env_var_names" {
+  value       = keys(local.rendered_vars)
+  description = "Sorted list of variable names rendered into the file"
+}
@@ -0,0 +1,42 @@
+# ==============================================================================
+# Railway Env Module - Input Variables
+# ==============================================================================
+
+variable "env_vars" {
+  type        = map(string)
+  description = "Non-secret environment variables to render as KEY=value lines"
+  default     = {}
+
+  validation {
+    condition     = alltrue([for key in keys(var.env_vars) : can(regex("^[A-Z][A-Z0-9_]*$", key))])
+    error_message = "Environment variable names must be upper-case letters, digits, and underscores."
+  }
+}
+
+variable "secret_ssm_parameters" {
+  type        = map(string)
+  description = "Secret environment variables mapped to the SSM parameter path holding the value; rendered as KEY_SSM_PARAMETER=<path>"
+  default     = {}
+
+  validation {
+    condition     = alltrue([for key in keys(var.secret_ssm_parameters) : can(regex("^[A-Z][A-Z0-9_]*$", key))])
+    error_message = "Environment variable names must be upper-case letters, digits, and underscores."
+  }
+
+  validation {
+    condition     = alltrue([for path in values(var.secret_ssm_parameters) : startswith(path, "/")])
+    error_message = "Secrets must be referenced by SSM parameter path (starting with /), never by plaintext value."
+  }
+}
+
+variable "write_env_file" {
+  type        = bool
+  description = "Write the rendered variables to env_file_path on the machine running Terraform"
+  default     = false
+}
+
+variable "env_file_path" {
+  type        = string
+  description = "Path of the dotenv file written when write_env_file is true"
+  default     = ".env.railway"
+}
@@ -0,0 +1,80 @@
+"""Stop or start this environment's RDS databases on a schedule.
+
+Invoked by two EventBridge schedules with {"action": "stop"} or
+{"action": "start"}. Only instances and Aurora clusters named with the
+environment's identifier prefix and tagged Schedule = SCHEDULE_TAG are
+touched. Instances with read replicas cannot be stopped and are reported as
+skipped, as are databases already in the requested state.
+"""
+
+import os
+
+import boto3
+from botocore.exceptions import ClientError
+
+rds = boto3.client("rds")
+
+IDENTIFIER_PREFIX = os.environ["IDENTIFIER_PREFIX"]
+SCHEDULE_TAG_KEY = os.environ.get("SCHEDULE_TAG_KEY", "Schedule")
+SCHEDULE_TAG = os.environ["SCHEDULE_TAG"]
+
+
+def _scheduled(tags):
+    return any(tag["Key"] == SCHEDULE_TAG_KEY and tag["Value"] == SCHEDULE_TAG for tag in tags)
+
+
+def _instances():
+    for page in rds.get_paginator("describe_db_instances").paginate():
+        for instance in page["DBInstances"]:
+            identifier = instance["DBInstanceIdentifier"]
+            # Aurora members are stopped and started with their cluster
+            if not identifier.startswith(IDENTIFIER_PREFIX) or instance.get("DBClusterIdentifier"):
+                continue
+            if _scheduled(instance.get("TagList", [])):
+                yield instance
+
+
+def _clusters():
+    for page in rds.get_paginator("describe_db_clusters").paginate():
+        for cluster in page["DBClusters"]:
+            if cluster["DBClusterIdentifier"].startswith(IDENTIFIER_PREFIX) and _scheduled(cluster.get("TagList", [])):
+                yield cluster
+
+
+def handler(event, _context):
+    action = event.get("action")
+    if action not in ("stop", "start"):
+        return {"action": "ignored", "reason": f"unknown action {action!r}"}
+
+    ready_status = "available" if action == "stop" else "stopped"
+    changed, skipped = [], []
+
+    for instance in _instances():
+        identifier = instance["DBInstanceIdentifier"]
+        if instance["DBInstanceStatus"] != ready_status:
+            skipped.append({"database": identifier, "reason": f"status {instance['DBInstanceStatus']}"})
+            continue
+        try:
+            if action == "stop":
+                rds.stop_db_instance(DBInstanceIdentifier=identifier)
+            else:
+                rds.start_db_instance(DBInstanceIdentifier=identifier)
+            changed.append(identifier)
+        except ClientError as error:
+            skipped.append({"database": identifier, "reason": error.response["Error"]["Code"]})
+
+    for cluster in _clusters():
+        identifier = cluster["DBClusterIdentifier"]
+        if cluster["Status"] != ready_status:
+            skipped.append({"database": identifier, "reason": f"status {cluster['Status']}"})
+            continue
+        try:
+            if action == "stop":
+                rds.stop_db_cluster(DBClusterIdentifier=identifier)
+            else:
+                rds.start_db_cluster(DBClusterIdentifier=identifier)
+            changed.append(identifier)
+        except ClientError as error:
+            skipped.append({"database": identifier, "reason": error.response["Error"]["Code"]})
+
+    return {"action": action, "changed": changed, "skipped": skipped}
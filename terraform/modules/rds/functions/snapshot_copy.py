@@ -0,0 +1,62 @@
+"""Copy RDS snapshots to an isolated backup account.
+
+Triggered by EventBridge "RDS DB Snapshot Event" notifications for the primary
+instance, dispatched on EventID:
+
+1. Snapshot created (CREATED_EVENT_IDS): copy the source snapshot with a KMS
+   key the backup account is granted on.
+2. Copy finished (COPIED_EVENT_IDS): share the copy with that account, which
+   then copies it again under its own key for an immutable off-account backup.
+"""
+
+import os
+
+import boto3
+
+rds = boto3.client("rds")
+
+SOURCE_INSTANCE = os.environ["SOURCE_DB_INSTANCE_IDENTIFIER"]
+TARGET_ACCOUNT_ID = os.environ["TARGET_ACCOUNT_ID"]
+SHARE_KMS_KEY_ARN = os.environ["SHARE_KMS_KEY_ARN"]
+COPY_PREFIX = os.environ.get("COPY_PREFIX", "xacct-")
+CREATED_EVENT_IDS = os.environ["CREATED_EVENT_IDS"].split(",")
+COPIED_EVENT_IDS = os.environ["COPIED_EVENT_IDS"].split(",")
+
+
+def handler(event, _context):
+    detail = event.get("detail", {})
+    event_id = detail.get("EventID", "")
+    snapshot_id = detail.get("SourceIdentifier", "")
+    if not snapshot_id:
+        return {"action": "ignored", "reason": "no snapshot identifier"}
+    if event_id not in CREATED_EVENT_IDS + COPIED_EVENT_IDS:
+        return {"action": "ignored", "reason": f"unhandled event {event_id}"}
+
+    snapshot = rds.describe_db_snapshots(DBSnapshotIdentifier=snapshot_id)["DBSnapshots"][0]
+    if snapshot["DBInstanceIdentifier"] != SOURCE_INSTANCE:
+        return {"action": "ignored", "reason": "snapshot of another instance"}
+    if snapshot["Status"] != "available":
+        return {"action": "ignored", "reason": f"snapshot status {snapshot['Status']}"}
+
+    # Second pass: our re-encrypted copy finished, share it with the backup account
+    if event_id in COPIED_EVENT_IDS:
+        if not snapshot_id.startswith(COPY_PREFIX):
+            return {"action": "ignored", "reason": "copy not made by this function"}
+        rds.modify_db_snapshot_attribute(
+            DBSnapshotIdentifier=snapshot_id,
+            AttributeName="restore",
+            ValuesToAdd=[TARGET_ACCOUNT_ID],
+        )
+        return {"action": "shared", "snapshot": snapshot_id, "account": TARGET_ACCOUNT_ID}
+
+    # First pass: re-encrypt the source snapshot with the shareable key
+    if snapshot_id.startswith(COPY_PREFIX):
+        return {"action": "ignored", "reason": "snapshot is already a copy"}
+    copy_id = COPY_PREFIX + snapshot_id.replace("rds:", "").replace(":", "-")
+    rds.copy_db_snapshot(
+        SourceDBSnapshotIdentifier=snapshot_id,
+        TargetDBSnapshotIdentifier=copy_id,
+        KmsKeyId=SHARE_KMS_KEY_ARN,
+        CopyTags=True,
+    )
+    return {"action": "copied", "snapshot": snapshot_id, "copy": copy_id}
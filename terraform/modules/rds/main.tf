@@ -1,8 +1,33 @@
 # RDS Module - Main Configuration
-# This module provisions RDS PostgreSQL with pgvector, encryption, backups, and Multi-AZ
+# This module provisions RDS PostgreSQL (or an Aurora PostgreSQL cluster) with
+# pgvector, encryption, backups, and Multi-AZ
 
 locals {
-  identifier_prefix = "${var.environment}-hipaa-db"
+  # Construct environment label with optional suffix for test isolation
+  env_label         = var.environment
+  full_suffix       = var.name_suffix == "" ? local.env_label : "${local.env_label}-${var.name_suffix}"
+  identifier_prefix = "${local.full_suffix}-hipaa-db"
+
+  # Burstable micro/small classes do not support Performance Insights for PostgreSQL
+  performance_insights_unsupported_classes = ["db.t3.micro", "db.t3.small", "db.t4g.micro", "db.t4g.small"]
+
+  # engine_type selects a single RDS instance or an Aurora cluster; resources
+  # specific to one engine are counted on these flags
+  aurora_enabled   = var.engine_type == "aurora-postgresql"
+  instance_enabled = !local.aurora_enabled
+
+  # null leaves the server certificate on the RDS default CA
+  ca_cert_identifier = var.ca_cert_identifier == "" ? null : var.ca_cert_identifier
+
+  # Aurora PostgreSQL has no micro or small instance classes
+  aurora_unsupported_classes = ["db.t3.micro", "db.t3.small", "db.t4g.micro", "db.t4g.small"]
+
+  # Overnight stop/start never applies to production
+  scheduling_enabled = var.enable_scheduling && var.environment != "production"
+  schedule_tags      = local.scheduling_enabled ? { Schedule = var.schedule_tag_value } : {}
+
+  # The proxy gets a reader endpoint only when there is a replica to read from
+  proxy_reader_enabled = var.enable_rds_proxy && (local.aurora_enabled ? var.aurora_reader_count > 0 : var.enable_read_replica)
 
   common_tags = merge(
     var.tags,
@@ -36,6 +61,8 @@ resource "aws_db_subnet_group" "main" {
 # ==============================================================================
 # Parameter group enabling pgvector extension and optimal PostgreSQL settings
 resource "aws_db_parameter_group" "main" {
+  count = local.instance_enabled ? 1 : 0
+
   name        = "${local.identifier_prefix}-postgres15-pgvector"
   family      = var.parameter_group_family
   description = "Custom parameter group for ${var.environment} with pgvector extension enabled"
@@ -125,7 +152,7 @@ resource "aws_iam_role" "rds_monitoring" {
       {
         Effect = "Allow"
         Principal = {
-          Service = "monitoring.rds.amazonaws.com"
+          Service = "monitoring.rds.${var.dns_suffix}"
         }
         Action = "sts:AssumeRole"
       }
@@ -139,7 +166,7 @@ resource "aws_iam_role_policy_attachment" "rds_monitoring" {
   count = var.enable_enhanced_monitoring && var.monitoring_interval > 0 ? 1 : 0
 
   role       = aws_iam_role.rds_monitoring[0].name
-  policy_arn = "arn:aws:iam::aws:policy/service-role/AmazonRDSEnhancedMonitoringRole"
+  policy_arn = "arn:${var.partition}:iam::aws:policy/service-role/AmazonRDSEnhancedMonitoringRole"
 }
 
 # ==============================================================================
@@ -148,22 +175,67 @@ resource "aws_iam_role_policy_attachment" "rds_monitoring" {
 # Generate a secure random password for the master user
 # In production, this should be stored in AWS Secrets Manager
 resource "random_password" "master_password" {
-  length  = 32
-  special = true
+  length      = var.master_password_length
+  special     = true
+  min_upper   = 1
+  min_lower   = 1
+  min_numeric = 1
+  min_special = 1
   # Exclude characters that might cause issues in connection strings
   override_special = "!#$%&*()-_=+[]{}<>:?"
+
+  lifecycle {
+    # Changing these regenerates the password, but the instance ignores
+    # password changes, so SSM would drift from the real credential. Passwords
+    # created before the class minimums keep them at 0, which
+    # strong_password_enforced reports.
+    ignore_changes = [
+      min_upper,
+      min_lower,
+      min_numeric,
+      min_special
+    ]
+  }
+}
+
+# ==============================================================================
+# SSM Parameters for Database Credentials
+# ==============================================================================
+# KMS-encrypted SecureString parameters so consumers (e.g., the Railway env
+# file) reference credentials by SSM path instead of embedding plaintext
+resource "aws_ssm_parameter" "master_username" {
+  name        = "/${local.identifier_prefix}/master-username"
+  description = "Master username for ${local.identifier_prefix}"
+  type        = "SecureString"
+  key_id      = var.kms_key_id
+  value       = var.db_username
+
+  tags = local.common_tags
+}
+
+resource "aws_ssm_parameter" "master_password" {
+  name        = "/${local.identifier_prefix}/master-password"
+  description = "Master password for ${local.identifier_prefix}"
+  type        = "SecureString"
+  key_id      = var.kms_key_id
+  value       = random_password.master_password.result
+
+  tags = local.common_tags
 }
 
 # ==============================================================================
 # RDS PostgreSQL Primary Instance
 # ==============================================================================
 resource "aws_db_instance" "main" {
+  count = local.instance_enabled ? 1 : 0
+
   # Instance identification
   identifier = "${local.identifier_prefix}-primary"
 
   # Engine configuration
   engine                      = "postgres"
   engine_version              = var.engine_version
+  license_model               = var.license_model
   auto_minor_version_upgrade  = var.auto_minor_version_upgrade
   allow_major_version_upgrade = false
 
@@ -186,9 +258,11 @@ resource "aws_db_instance" "main" {
   vpc_security_group_ids = [var.security_group_id]
   publicly_accessible    = false
   multi_az               = var.multi_az
+  availability_zone      = var.availability_zone != "" ? var.availability_zone : null
+  ca_cert_identifier     = local.ca_cert_identifier
 
   # Parameter and option groups
-  parameter_group_name = aws_db_parameter_group.main.name
+  parameter_group_name = aws_db_parameter_group.main[0].name
 
   # Backup configuration
   backup_retention_period   = var.backup_retention_days
@@ -201,7 +275,7 @@ resource "aws_db_instance" "main" {
   # Maintenance configuration
   maintenance_window  = var.maintenance_window
   apply_immediately   = var.apply_immediately
-  deletion_protection = var.deletion_protection
+  deletion_protection = var.deletion_protection && !var.allow_destroy
 
   # Monitoring and logging
   enabled_cloudwatch_logs_exports = var.enable_cloudwatch_logs ? var.cloudwatch_log_types : []
@@ -222,7 +296,8 @@ resource "aws_db_instance" "main" {
       Name     = "${local.identifier_prefix}-primary"
       Role     = "primary"
       Snapshot = "automated"
-    }
+    },
+    local.schedule_tags
   )
 
   lifecycle {
@@ -232,6 +307,40 @@ resource "aws_db_instance" "main" {
       # Ignore snapshot identifier timestamp changes
       final_snapshot_identifier
     ]
+
+    # Fail at plan time instead of mid-apply with an opaque RDS API error
+    precondition {
+      condition     = !var.enable_performance_insights || !contains(local.performance_insights_unsupported_classes, var.instance_class)
+      error_message = "Performance Insights is not supported on ${var.instance_class}. Use db.t3.medium or larger, or set enable_performance_insights = false."
+    }
+
+    # Production PHI databases must survive an AZ outage and support point-in-time recovery
+    precondition {
+      condition     = var.environment != "production" || var.multi_az
+      error_message = "Production requires multi_az = true so the PHI database survives an Availability Zone failure."
+    }
+
+    precondition {
+      condition     = !(var.multi_az && var.availability_zone != "")
+      error_message = "availability_zone pins a single-AZ instance; unset it or set multi_az = false."
+    }
+
+    # Tenancy and licensing options the postgres engine cannot honour must fail
+    # loudly rather than deploy onto shared hardware unnoticed
+    precondition {
+      condition     = var.tenancy == "default"
+      error_message = "RDS for PostgreSQL does not support dedicated tenancy. For hardware isolation, deploy into a dedicated AWS account or use EC2 Dedicated Hosts outside RDS."
+    }
+
+    precondition {
+      condition     = var.license_model == "postgresql-license"
+      error_message = "RDS for PostgreSQL only supports license_model = \"postgresql-license\" (got ${var.license_model})."
+    }
+
+    precondition {
+      condition     = var.environment != "production" || var.backup_retention_days >= 7
+      error_message = "Production requires backup_retention_days >= 7 (got ${var.backup_retention_days}) for HIPAA contingency planning."
+    }
   }
 
   depends_on = [
@@ -240,17 +349,275 @@ resource "aws_db_instance" "main" {
   ]
 }
 
+# The instance and its parameter group became conditional on engine_type
+moved {
+  from = aws_db_instance.main
+  to   = aws_db_instance.main[0]
+}
+
+moved {
+  from = aws_db_parameter_group.main
+  to   = aws_db_parameter_group.main[0]
+}
+
+# ==============================================================================
+# Aurora PostgreSQL Cluster (engine_type = "aurora-postgresql")
+# ==============================================================================
+# A writer plus aurora_reader_count readers on shared, KMS-encrypted cluster
+# storage. pgvector ships with Aurora PostgreSQL 15 and needs no preload, so the
+# cluster parameter group only carries the logging and TLS settings.
+resource "aws_rds_cluster_parameter_group" "aurora" {
+  count = local.aurora_enabled ? 1 : 0
+
+  name        = "${local.identifier_prefix}-aurora-postgresql15"
+  family      = "aurora-postgresql15"
+  description = "Cluster parameter group for ${var.environment} Aurora PostgreSQL with TLS enforced"
+
+  parameter {
+    name         = "log_min_duration_statement"
+    value        = "1000" # Log queries taking more than 1 second
+    apply_method = "immediate"
+  }
+
+  parameter {
+    name         = "log_connections"
+    value        = "1"
+    apply_method = "immediate"
+  }
+
+  parameter {
+    name         = "log_disconnections"
+    value        = "1"
+    apply_method = "immediate"
+  }
+
+  parameter {
+    name         = "rds.force_ssl"
+    value        = "1"
+    apply_method = "immediate"
+  }
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${local.identifier_prefix}-aurora-postgresql15"
+    }
+  )
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+resource "aws_rds_cluster" "aurora" {
+  count = local.aurora_enabled ? 1 : 0
+
+  cluster_identifier = "${local.identifier_prefix}-aurora"
+
+  # Engine configuration
+  engine                      = "aurora-postgresql"
+  engine_version              = var.engine_version
+  allow_major_version_upgrade = false
+
+  # Storage is always encrypted with the customer managed key
+  storage_encrypted = true
+  kms_key_id        = data.aws_kms_key.rds[0].arn
+
+  # Database configuration
+  database_name   = var.db_name
+  port            = var.db_port
+  master_username = var.db_username
+  master_password = random_password.master_password.result
+
+  # Network configuration
+  db_subnet_group_name            = aws_db_subnet_group.main.name
+  vpc_security_group_ids          = [var.security_group_id]
+  db_cluster_parameter_group_name = aws_rds_cluster_parameter_group.aurora[0].name
+
+  # Backup configuration (Aurora keeps at least one day of backups)
+  backup_retention_period   = max(var.backup_retention_days, 1)
+  preferred_backup_window   = var.backup_window
+  copy_tags_to_snapshot     = var.copy_tags_to_snapshot
+  skip_final_snapshot       = var.skip_final_snapshot
+  final_snapshot_identifier = var.skip_final_snapshot ? null : "${var.final_snapshot_identifier_prefix}-${local.identifier_prefix}-${formatdate("YYYY-MM-DD-hhmm", timestamp())}"
+
+  # Maintenance configuration
+  preferred_maintenance_window = var.maintenance_window
+  apply_immediately            = var.apply_immediately
+  deletion_protection          = var.deletion_protection && !var.allow_destroy
+
+  # Aurora PostgreSQL only exports the postgresql log
+  enabled_cloudwatch_logs_exports = var.enable_cloudwatch_logs ? [for log_type in var.cloudwatch_log_types : log_type if log_type == "postgresql"] : []
+
+  # IAM authentication
+  iam_database_authentication_enabled = var.enable_iam_database_authentication
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name     = "${local.identifier_prefix}-aurora"
+      Role     = "cluster"
+      Snapshot = "automated"
+    },
+    local.schedule_tags
+  )
+
+  lifecycle {
+    ignore_changes = [
+      master_password,
+      final_snapshot_identifier
+    ]
+
+    # pgvector is available from Aurora PostgreSQL 15.3
+    precondition {
+      condition     = try(tonumber(split(".", var.engine_version)[1]) >= 3, false)
+      error_message = "Aurora PostgreSQL ${var.engine_version} does not ship pgvector. Use engine_version 15.3 or later."
+    }
+
+    # A reader in another AZ is Aurora's equivalent of Multi-AZ
+    precondition {
+      condition     = var.environment != "production" || var.aurora_reader_count >= 1
+      error_message = "Production requires aurora_reader_count >= 1 so the PHI cluster can fail over to another Availability Zone."
+    }
+
+    precondition {
+      condition     = var.environment != "production" || var.backup_retention_days >= 7
+      error_message = "Production requires backup_retention_days >= 7 (got ${var.backup_retention_days}) for HIPAA contingency planning."
+    }
+
+    precondition {
+      condition     = var.tenancy == "default" && var.license_model == "postgresql-license"
+      error_message = "Aurora PostgreSQL only runs on default tenancy with license_model = \"postgresql-license\"."
+    }
+
+    # Instance-only features have cluster equivalents or are not implemented yet
+    precondition {
+      condition     = !var.enable_read_replica && !var.enable_reporting_replica
+      error_message = "enable_read_replica and enable_reporting_replica apply to the postgres engine. With Aurora, set aurora_reader_count instead."
+    }
+
+    precondition {
+      condition     = !var.enable_cross_region_backups && var.snapshot_copy_account_id == ""
+      error_message = "enable_cross_region_backups and snapshot_copy_account_id copy DB instance backups and are not supported with engine_type = \"aurora-postgresql\"."
+    }
+  }
+}
+
+resource "aws_rds_cluster_instance" "aurora" {
+  count = local.aurora_enabled ? 1 + var.aurora_reader_count : 0
+
+  # The first instance is created first and becomes the writer; readers
+  # share a lower failover priority
+  identifier         = count.index == 0 ? "${local.identifier_prefix}-writer" : "${local.identifier_prefix}-reader-${count.index}"
+  cluster_identifier = aws_rds_cluster.aurora[0].id
+  promotion_tier     = count.index == 0 ? 0 : 1
+
+  engine                     = aws_rds_cluster.aurora[0].engine
+  engine_version             = aws_rds_cluster.aurora[0].engine_version
+  instance_class             = var.instance_class
+  auto_minor_version_upgrade = var.auto_minor_version_upgrade
+
+  # Network configuration
+  db_subnet_group_name = aws_db_subnet_group.main.name
+  availability_zone    = var.availability_zone != "" ? var.availability_zone : null
+  publicly_accessible  = false
+  ca_cert_identifier   = local.ca_cert_identifier
+
+  # Maintenance configuration
+  preferred_maintenance_window = var.maintenance_window
+  apply_immediately            = var.apply_immediately
+
+  # Monitoring
+  monitoring_interval = var.enable_enhanced_monitoring ? var.monitoring_interval : 0
+  monitoring_role_arn = var.enable_enhanced_monitoring && var.monitoring_interval > 0 ? aws_iam_role.rds_monitoring[0].arn : null
+
+  # Performance Insights
+  performance_insights_enabled          = var.enable_performance_insights
+  performance_insights_retention_period = var.enable_performance_insights ? var.performance_insights_retention_days : null
+  performance_insights_kms_key_id       = var.enable_performance_insights ? var.kms_key_id : null
+
+  copy_tags_to_snapshot = var.copy_tags_to_snapshot
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = count.index == 0 ? "${local.identifier_prefix}-writer" : "${local.identifier_prefix}-reader-${count.index}"
+      Role = count.index == 0 ? "writer" : "reader"
+    }
+  )
+
+  lifecycle {
+    precondition {
+      condition     = !contains(local.aurora_unsupported_classes, var.instance_class)
+      error_message = "Aurora PostgreSQL does not support ${var.instance_class}. Use db.t3.medium, db.t4g.medium or larger."
+    }
+  }
+}
+
+# ==============================================================================
+# Primary Database Attributes
+# ==============================================================================
+# Engine-independent view of the primary used by outputs, the proxy and the
+# snapshot tooling. For Aurora the identifier is the writer instance (CloudWatch
+# alarms use DBInstanceIdentifier) while endpoints, ARN and resource ID are the
+# cluster's, so connections follow failovers and IAM auth uses the cluster ID.
+locals {
+  primary = local.aurora_enabled ? {
+    identifier            = aws_rds_cluster_instance.aurora[0].identifier
+    id                    = aws_rds_cluster.aurora[0].id
+    arn                   = aws_rds_cluster.aurora[0].arn
+    resource_id           = aws_rds_cluster.aurora[0].cluster_resource_id
+    endpoint              = "${aws_rds_cluster.aurora[0].endpoint}:${aws_rds_cluster.aurora[0].port}"
+    address               = aws_rds_cluster.aurora[0].endpoint
+    port                  = aws_rds_cluster.aurora[0].port
+    db_name               = aws_rds_cluster.aurora[0].database_name
+    username              = aws_rds_cluster.aurora[0].master_username
+    engine_version_actual = aws_rds_cluster.aurora[0].engine_version_actual
+    storage_encrypted     = aws_rds_cluster.aurora[0].storage_encrypted
+    publicly_accessible   = aws_rds_cluster_instance.aurora[0].publicly_accessible
+    multi_az              = var.aurora_reader_count > 0
+    license_model         = "postgresql-license"
+    ca_cert_identifier    = aws_rds_cluster_instance.aurora[0].ca_cert_identifier
+    } : {
+    identifier            = aws_db_instance.main[0].identifier
+    id                    = aws_db_instance.main[0].id
+    arn                   = aws_db_instance.main[0].arn
+    resource_id           = aws_db_instance.main[0].resource_id
+    endpoint              = aws_db_instance.main[0].endpoint
+    address               = aws_db_instance.main[0].address
+    port                  = aws_db_instance.main[0].port
+    db_name               = aws_db_instance.main[0].db_name
+    username              = aws_db_instance.main[0].username
+    engine_version_actual = aws_db_instance.main[0].engine_version_actual
+    storage_encrypted     = aws_db_instance.main[0].storage_encrypted
+    publicly_accessible   = aws_db_instance.main[0].publicly_accessible
+    multi_az              = aws_db_instance.main[0].multi_az
+    license_model         = aws_db_instance.main[0].license_model
+    ca_cert_identifier    = aws_db_instance.main[0].ca_cert_identifier
+  }
+}
+
+# ------------------------------------------------------------------------------
+# CA Rotation Readiness
+# ------------------------------------------------------------------------------
+# The CA with the furthest expiry is the one AWS steers rotations toward. A
+# primary still on an older CA needs clients to trust the new root bundle
+# before ca_cert_identifier is moved.
+data "aws_rds_certificate" "latest" {
+  latest_valid_till = true
+}
+
 # ==============================================================================
 # RDS Read Replica (Conditional - Production Only)
 # ==============================================================================
 resource "aws_db_instance" "read_replica" {
-  count = var.enable_read_replica ? 1 : 0
+  count = local.instance_enabled && var.enable_read_replica ? 1 : 0
 
   # Instance identification
   identifier = "${local.identifier_prefix}-replica"
 
   # Replica configuration
-  replicate_source_db = aws_db_instance.main.identifier
+  replicate_source_db = aws_db_instance.main[0].identifier
 
   # Instance sizing (can be different from primary)
   instance_class             = var.instance_class
@@ -263,9 +630,10 @@ resource "aws_db_instance" "read_replica" {
   # Network configuration
   publicly_accessible    = false
   vpc_security_group_ids = [var.security_group_id]
+  ca_cert_identifier     = local.ca_cert_identifier
 
   # Parameter group (use same as primary)
-  parameter_group_name = aws_db_parameter_group.main.name
+  parameter_group_name = aws_db_parameter_group.main[0].name
 
   # Maintenance configuration
   maintenance_window = var.maintenance_window
@@ -296,29 +664,723 @@ resource "aws_db_instance" "read_replica" {
     }
   )
 
+  lifecycle {
+    # PostgreSQL replicas stream from a source with automated backups enabled
+    precondition {
+      condition     = var.backup_retention_days > 0
+      error_message = "enable_read_replica requires automated backups on the primary. Set backup_retention_days to 1 or more."
+    }
+  }
+
+  depends_on = [
+    aws_db_instance.main
+  ]
+}
+
+# ==============================================================================
+# RDS Reporting Replica (Conditional)
+# ==============================================================================
+# Read-only replica for analytics/reporting in a different AZ from the primary,
+# behind its own security group so reporting access is separate from the
+# transactional application path
+
+data "aws_subnet" "private" {
+  count = var.enable_reporting_replica ? length(var.private_subnet_ids) : 0
+  id    = var.private_subnet_ids[count.index]
+}
+
+locals {
+  # First private subnet AZ that differs from the primary's AZ
+  reporting_availability_zone = var.reporting_replica_availability_zone != "" ? var.reporting_replica_availability_zone : try(
+    [for subnet in data.aws_subnet.private : subnet.availability_zone if subnet.availability_zone != aws_db_instance.main[0].availability_zone][0],
+    null
+  )
+}
+
+resource "aws_security_group" "reporting" {
+  count = var.enable_reporting_replica ? 1 : 0
+
+  name        = "${local.identifier_prefix}-reporting-sg"
+  description = "Reporting replica access - reporting clients only"
+  vpc_id      = data.aws_subnet.private[0].vpc_id
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${local.identifier_prefix}-reporting-sg"
+    }
+  )
+}
+
+resource "aws_security_group_rule" "reporting_ingress" {
+  count                    = var.enable_reporting_replica ? length(var.reporting_allowed_security_group_ids) : 0
+  type                     = "ingress"
+  from_port                = var.db_port
+  to_port                  = var.db_port
+  protocol                 = "tcp"
+  source_security_group_id = var.reporting_allowed_security_group_ids[count.index]
+  security_group_id        = aws_security_group.reporting[0].id
+  description              = "Allow PostgreSQL access from reporting security group ${count.index + 1}"
+}
+
+resource "aws_db_instance" "reporting_replica" {
+  count = local.instance_enabled && var.enable_reporting_replica ? 1 : 0
+
+  # Instance identification
+  identifier = "${local.identifier_prefix}-reporting"
+
+  # Replica configuration
+  replicate_source_db = aws_db_instance.main[0].identifier
+  availability_zone   = local.reporting_availability_zone
+
+  # Instance sizing (reporting workloads can use a different class)
+  instance_class             = coalesce(var.reporting_replica_instance_class, var.instance_class)
+  auto_minor_version_upgrade = var.auto_minor_version_upgrade
+
+  # Storage configuration (encryption inherited from primary)
+  storage_type          = "gp3"
+  max_allocated_storage = var.max_allocated_storage
+
+  # Network configuration - dedicated security group only
+  publicly_accessible    = false
+  vpc_security_group_ids = [aws_security_group.reporting[0].id]
+  ca_cert_identifier     = local.ca_cert_identifier
+
+  # Parameter group (use same as primary)
+  parameter_group_name = aws_db_parameter_group.main[0].name
+
+  # Maintenance configuration
+  maintenance_window = var.maintenance_window
+  apply_immediately  = var.apply_immediately
+
+  # Monitoring and logging
+  enabled_cloudwatch_logs_exports = var.enable_cloudwatch_logs ? var.cloudwatch_log_types : []
+  monitoring_interval             = var.enable_enhanced_monitoring ? var.monitoring_interval : 0
+  monitoring_role_arn             = var.enable_enhanced_monitoring && var.monitoring_interval > 0 ? aws_iam_role.rds_monitoring[0].arn : null
+
+  # IAM authentication
+  iam_database_authentication_enabled = var.enable_iam_database_authentication
+
+  # Backup configuration (replicas don't have automated backups)
+  backup_retention_period = 0
+  skip_final_snapshot     = true
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${local.identifier_prefix}-reporting"
+      Role = "reporting-replica"
+    }
+  )
+
+  lifecycle {
+    precondition {
+      condition     = var.backup_retention_days > 0
+      error_message = "enable_reporting_replica requires automated backups on the primary. Set backup_retention_days to 1 or more."
+    }
+  }
+
   depends_on = [
     aws_db_instance.main
   ]
 }
 
+# ==============================================================================
+# RDS Proxy (Conditional)
+# ==============================================================================
+# Connection pooling in front of the primary. The proxy runs in the RDS
+# security group, so app -> proxy reuses the existing app ingress rule and
+# proxy -> database is allowed by the self-referencing rules below.
+
+# Resolves kms_key_id (ID, ARN or alias) to the key ARN for resources that
+# only accept ARNs
+data "aws_kms_key" "rds" {
+  count  = var.enable_rds_proxy || local.aurora_enabled ? 1 : 0
+  key_id = var.kms_key_id
+}
+
+data "aws_region" "current" {}
+
+resource "aws_secretsmanager_secret" "proxy" {
+  count = var.enable_rds_proxy ? 1 : 0
+
+  name_prefix = "${local.identifier_prefix}-proxy-credentials-"
+  description = "Master credentials used by RDS Proxy for ${local.identifier_prefix}"
+  kms_key_id  = var.kms_key_id
+
+  tags = local.common_tags
+}
+
+resource "aws_secretsmanager_secret_version" "proxy" {
+  count = var.enable_rds_proxy ? 1 : 0
+
+  secret_id = aws_secretsmanager_secret.proxy[0].id
+  secret_string = jsonencode({
+    username = local.primary.username
+    password = random_password.master_password.result
+  })
+}
+
+resource "aws_iam_role" "proxy" {
+  count = var.enable_rds_proxy ? 1 : 0
+
+  name        = "${local.identifier_prefix}-proxy-role"
+  description = "IAM role for RDS Proxy to read database credentials in ${var.environment}"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Principal = {
+          Service = "rds.${var.dns_suffix}"
+        }
+        Action = "sts:AssumeRole"
+      }
+    ]
+  })
+
+  tags = local.common_tags
+}
+
+resource "aws_iam_role_policy" "proxy" {
+  count = var.enable_rds_proxy ? 1 : 0
+
+  name = "${local.identifier_prefix}-proxy-secrets"
+  role = aws_iam_role.proxy[0].id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid      = "ReadProxyCredentials"
+        Effect   = "Allow"
+        Action   = ["secretsmanager:GetSecretValue"]
+        Resource = [aws_secretsmanager_secret.proxy[0].arn]
+      },
+      {
+        Sid      = "DecryptProxyCredentials"
+        Effect   = "Allow"
+        Action   = ["kms:Decrypt"]
+        Resource = [data.aws_kms_key.rds[0].arn]
+        Condition = {
+          StringEquals = {
+            "kms:ViaService" = "secretsmanager.${data.aws_region.current.name}.${var.dns_suffix}"
+          }
+        }
+      }
+    ]
+  })
+}
+
+resource "aws_security_group_rule" "proxy_to_db_ingress" {
+  count = var.enable_rds_proxy ? 1 : 0
+
+  type              = "ingress"
+  from_port         = var.db_port
+  to_port           = var.db_port
+  protocol          = "tcp"
+  self              = true
+  security_group_id = var.security_group_id
+  description       = "Allow RDS Proxy to reach the database within the RDS security group"
+}
+
+resource "aws_security_group_rule" "proxy_to_db_egress" {
+  count = var.enable_rds_proxy ? 1 : 0
+
+  type              = "egress"
+  from_port         = var.db_port
+  to_port           = var.db_port
+  protocol          = "tcp"
+  self              = true
+  security_group_id = var.security_group_id
+  description       = "Allow RDS Proxy connections to the database within the RDS security group"
+}
+
+resource "aws_db_proxy" "main" {
+  count = var.enable_rds_proxy ? 1 : 0
+
+  name                   = "${local.identifier_prefix}-proxy"
+  engine_family          = "POSTGRESQL"
+  role_arn               = aws_iam_role.proxy[0].arn
+  vpc_subnet_ids         = var.private_subnet_ids
+  vpc_security_group_ids = [var.security_group_id]
+
+  # Encrypt every client -> proxy connection
+  require_tls         = var.proxy_require_tls
+  idle_client_timeout = var.proxy_idle_client_timeout
+  debug_logging       = false
+
+  auth {
+    auth_scheme = "SECRETS"
+    description = "Master credentials"
+    iam_auth    = var.enable_iam_database_authentication ? "REQUIRED" : "DISABLED"
+    secret_arn  = aws_secretsmanager_secret.proxy[0].arn
+  }
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${local.identifier_prefix}-proxy"
+    }
+  )
+
+  depends_on = [aws_iam_role_policy.proxy]
+}
+
+resource "aws_db_proxy_default_target_group" "main" {
+  count = var.enable_rds_proxy ? 1 : 0
+
+  db_proxy_name = aws_db_proxy.main[0].name
+
+  connection_pool_config {
+    # Bound how long a client waits to borrow a pooled connection
+    connection_borrow_timeout = var.proxy_connection_borrow_timeout
+    max_connections_percent   = 100
+  }
+}
+
+# An Aurora target registers the whole cluster, so its readers are already
+# available to the READ_ONLY endpoint below
+resource "aws_db_proxy_target" "main" {
+  count = var.enable_rds_proxy ? 1 : 0
+
+  db_proxy_name          = aws_db_proxy.main[0].name
+  target_group_name      = aws_db_proxy_default_target_group.main[0].name
+  db_instance_identifier = local.instance_enabled ? aws_db_instance.main[0].identifier : null
+  db_cluster_identifier  = local.aurora_enabled ? aws_rds_cluster.aurora[0].cluster_identifier : null
+}
+
+# Read/write split: the read replica joins the proxy's target group and a
+# READ_ONLY proxy endpoint routes reporting reads to it, leaving the default
+# endpoint for writes
+resource "aws_db_proxy_target" "read_replica" {
+  count = local.proxy_reader_enabled && local.instance_enabled ? 1 : 0
+
+  db_proxy_name          = aws_db_proxy.main[0].name
+  target_group_name      = aws_db_proxy_default_target_group.main[0].name
+  db_instance_identifier = aws_db_instance.read_replica[0].identifier
+}
+
+resource "aws_db_proxy_endpoint" "reader" {
+  count = local.proxy_reader_enabled ? 1 : 0
+
+  db_proxy_name          = aws_db_proxy.main[0].name
+  db_proxy_endpoint_name = "${local.identifier_prefix}-proxy-reader"
+  vpc_subnet_ids         = var.private_subnet_ids
+  vpc_security_group_ids = [var.security_group_id]
+  target_role            = "READ_ONLY"
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${local.identifier_prefix}-proxy-reader"
+    }
+  )
+
+  depends_on = [aws_db_proxy_target.main, aws_db_proxy_target.read_replica]
+}
+
+# ==============================================================================
+# Cross-Account Snapshot Copy (Conditional)
+# ==============================================================================
+# Ransomware resilience: every snapshot of the primary is re-encrypted with a
+# key the isolated backup account is granted on, then shared with that
+# account. EventBridge invokes the Lambda twice per snapshot - once for the
+# source snapshot (copy) and once when the copy finishes (share).
+
+locals {
+  snapshot_copy_enabled = var.snapshot_copy_account_id != ""
+  snapshot_copy_prefix  = "xacct-"
+
+  # RDS-EVENT-0042 and RDS-EVENT-0091 mark manual and automated snapshots
+  # being created (creation category); RDS-EVENT-0197 marks a same-region
+  # copy finishing, which RDS reports in the notification category
+  snapshot_created_event_ids = ["RDS-EVENT-0042", "RDS-EVENT-0091"]
+  snapshot_copied_event_ids  = ["RDS-EVENT-0197"]
+}
+
+data "aws_caller_identity" "current" {}
+
+resource "aws_kms_key" "snapshot_share" {
+  count = local.snapshot_copy_enabled ? 1 : 0
+
+  description             = "Shareable key for ${local.identifier_prefix} snapshots copied to account ${var.snapshot_copy_account_id}"
+  deletion_window_in_days = var.allow_destroy ? 7 : 30
+  enable_key_rotation     = true
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid    = "Enable IAM User Permissions"
+        Effect = "Allow"
+        Principal = {
+          AWS = "arn:${var.partition}:iam::${data.aws_caller_identity.current.account_id}:root"
+        }
+        Action   = "kms:*"
+        Resource = "*"
+      },
+      {
+        Sid    = "Allow backup account to copy shared snapshots"
+        Effect = "Allow"
+        Principal = {
+          AWS = "arn:${var.partition}:iam::${var.snapshot_copy_account_id}:root"
+        }
+        Action = [
+          "kms:Decrypt",
+          "kms:DescribeKey",
+          "kms:ReEncrypt*",
+          "kms:CreateGrant"
+        ]
+        Resource = "*"
+      }
+    ]
+  })
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name    = "${local.identifier_prefix}-snapshot-share"
+      Purpose = "Cross-account snapshot copy"
+    }
+  )
+}
+
+resource "aws_kms_alias" "snapshot_share" {
+  count = local.snapshot_copy_enabled ? 1 : 0
+
+  name          = "alias/${local.identifier_prefix}-snapshot-share"
+  target_key_id = aws_kms_key.snapshot_share[0].key_id
+}
+
+data "archive_file" "snapshot_copy" {
+  count = local.snapshot_copy_enabled ? 1 : 0
+
+  type        = "zip"
+  source_file = "${path.module}/functions/snapshot_copy.py"
+  output_path = "${path.module}/.build/snapshot_copy.zip"
+}
+
+resource "aws_iam_role" "snapshot_copy" {
+  count = local.snapshot_copy_enabled ? 1 : 0
+
+  name        = "${local.identifier_prefix}-snapshot-copy-role"
+  description = "IAM role for the cross-account snapshot copy Lambda in ${var.environment}"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Principal = {
+          Service = "lambda.${var.dns_suffix}"
+        }
+        Action = "sts:AssumeRole"
+      }
+    ]
+  })
+
+  tags = local.common_tags
+}
+
+resource "aws_iam_role_policy" "snapshot_copy" {
+  count = local.snapshot_copy_enabled ? 1 : 0
+
+  name = "${local.identifier_prefix}-snapshot-copy"
+  role = aws_iam_role.snapshot_copy[0].id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid    = "CopyAndShareSnapshots"
+        Effect = "Allow"
+        Action = [
+          "rds:DescribeDBSnapshots",
+          "rds:CopyDBSnapshot",
+          "rds:ModifyDBSnapshotAttribute",
+          "rds:AddTagsToResource"
+        ]
+        Resource = "arn:${var.partition}:rds:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:snapshot:*"
+      },
+      {
+        Sid    = "ReEncryptWithShareKey"
+        Effect = "Allow"
+        Action = [
+          "kms:Decrypt",
+          "kms:DescribeKey",
+          "kms:ReEncrypt*",
+          "kms:GenerateDataKey*",
+          "kms:CreateGrant"
+        ]
+        Resource = [var.kms_key_id, aws_kms_key.snapshot_share[0].arn]
+      },
+      {
+        Sid    = "WriteLogs"
+        Effect = "Allow"
+        Action = [
+          "logs:CreateLogGroup",
+          "logs:CreateLogStream",
+          "logs:PutLogEvents"
+        ]
+        Resource = "arn:${var.partition}:logs:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:log-group:/aws/lambda/${local.identifier_prefix}-snapshot-copy:*"
+      }
+    ]
+  })
+}
+
+resource "aws_lambda_function" "snapshot_copy" {
+  count = local.snapshot_copy_enabled ? 1 : 0
+
+  function_name    = "${local.identifier_prefix}-snapshot-copy"
+  description      = "Copies ${local.identifier_prefix} snapshots to account ${var.snapshot_copy_account_id}"
+  role             = aws_iam_role.snapshot_copy[0].arn
+  runtime          = "python3.12"
+  handler          = "snapshot_copy.handler"
+  filename         = data.archive_file.snapshot_copy[0].output_path
+  source_code_hash = data.archive_file.snapshot_copy[0].output_base64sha256
+  timeout          = 60
+
+  environment {
+    variables = {
+      SOURCE_DB_INSTANCE_IDENTIFIER = local.primary.identifier
+      TARGET_ACCOUNT_ID             = var.snapshot_copy_account_id
+      SHARE_KMS_KEY_ARN             = aws_kms_key.snapshot_share[0].arn
+      COPY_PREFIX                   = local.snapshot_copy_prefix
+      CREATED_EVENT_IDS             = join(",", local.snapshot_created_event_ids)
+      COPIED_EVENT_IDS              = join(",", local.snapshot_copied_event_ids)
+    }
+  }
+
+  tags = local.common_tags
+}
+
+resource "aws_cloudwatch_event_rule" "snapshot_created" {
+  count = local.snapshot_copy_enabled ? 1 : 0
+
+  name        = "${local.identifier_prefix}-snapshot-created"
+  description = "Snapshot created and copy finished events for ${local.identifier_prefix}"
+
+  event_pattern = jsonencode({
+    source      = ["aws.rds"]
+    detail-type = ["RDS DB Snapshot Event"]
+    detail = {
+      SourceType = ["SNAPSHOT"]
+      EventID    = concat(local.snapshot_created_event_ids, local.snapshot_copied_event_ids)
+    }
+  })
+
+  tags = local.common_tags
+}
+
+resource "aws_cloudwatch_event_target" "snapshot_copy" {
+  count = local.snapshot_copy_enabled ? 1 : 0
+
+  rule = aws_cloudwatch_event_rule.snapshot_created[0].name
+  arn  = aws_lambda_function.snapshot_copy[0].arn
+}
+
+resource "aws_lambda_permission" "snapshot_copy" {
+  count = local.snapshot_copy_enabled ? 1 : 0
+
+  statement_id  = "AllowEventBridgeInvoke"
+  action        = "lambda:InvokeFunction"
+  function_name = aws_lambda_function.snapshot_copy[0].function_name
+  principal     = "events.${var.dns_suffix}"
+  source_arn    = aws_cloudwatch_event_rule.snapshot_created[0].arn
+}
+
+# ==============================================================================
+# Non-Production Scheduling (Conditional)
+# ==============================================================================
+# EventBridge invokes a Lambda that stops databases tagged Schedule =
+# schedule_tag_value overnight and starts them before business hours. Only
+# instances and clusters of this environment carrying the tag are touched,
+# and nothing is created for production.
+
+data "archive_file" "scheduler" {
+  count = local.scheduling_enabled ? 1 : 0
+
+  type        = "zip"
+  source_file = "${path.module}/functions/rds_scheduler.py"
+  output_path = "${path.module}/.build/rds_scheduler.zip"
+}
+
+resource "aws_iam_role" "scheduler" {
+  count = local.scheduling_enabled ? 1 : 0
+
+  name        = "${local.identifier_prefix}-scheduler-role"
+  description = "IAM role for the RDS stop/start scheduler Lambda in ${var.environment}"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Principal = {
+          Service = "lambda.${var.dns_suffix}"
+        }
+        Action = "sts:AssumeRole"
+      }
+    ]
+  })
+
+  tags = local.common_tags
+}
+
+resource "aws_iam_role_policy" "scheduler" {
+  count = local.scheduling_enabled ? 1 : 0
+
+  name = "${local.identifier_prefix}-scheduler"
+  role = aws_iam_role.scheduler[0].id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid    = "FindScheduledDatabases"
+        Effect = "Allow"
+        Action = [
+          "rds:DescribeDBInstances",
+          "rds:DescribeDBClusters",
+          "rds:ListTagsForResource"
+        ]
+        Resource = "*"
+      },
+      {
+        Sid    = "StopAndStartTaggedDatabases"
+        Effect = "Allow"
+        Action = [
+          "rds:StopDBInstance",
+          "rds:StartDBInstance",
+          "rds:StopDBCluster",
+          "rds:StartDBCluster"
+        ]
+        Resource = [
+          "arn:${var.partition}:rds:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:db:${local.identifier_prefix}-*",
+          "arn:${var.partition}:rds:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:cluster:${local.identifier_prefix}-*"
+        ]
+        Condition = {
+          StringEquals = {
+            "aws:ResourceTag/Schedule" = var.schedule_tag_value
+          }
+        }
+      },
+      {
+        Sid    = "WriteLogs"
+        Effect = "Allow"
+        Action = [
+          "logs:CreateLogGroup",
+          "logs:CreateLogStream",
+          "logs:PutLogEvents"
+        ]
+        Resource = "arn:${var.partition}:logs:${data.aws_region.current.name}:${data.aws_caller_identity.current.account_id}:log-group:/aws/lambda/${local.identifier_prefix}-scheduler:*"
+      }
+    ]
+  })
+}
+
+resource "aws_lambda_function" "scheduler" {
+  count = local.scheduling_enabled ? 1 : 0
+
+  function_name    = "${local.identifier_prefix}-scheduler"
+  description      = "Stops and starts ${local.identifier_prefix} databases tagged Schedule = ${var.schedule_tag_value}"
+  role             = aws_iam_role.scheduler[0].arn
+  runtime          = "python3.12"
+  handler          = "rds_scheduler.handler"
+  filename         = data.archive_file.scheduler[0].output_path
+  source_code_hash = data.archive_file.scheduler[0].output_base64sha256
+  timeout          = 60
+
+  environment {
+    variables = {
+      IDENTIFIER_PREFIX = local.identifier_prefix
+      SCHEDULE_TAG_KEY  = "Schedule"
+      SCHEDULE_TAG      = var.schedule_tag_value
+    }
+  }
+
+  tags = local.common_tags
+}
+
+resource "aws_cloudwatch_event_rule" "scheduler" {
+  for_each = local.scheduling_enabled ? {
+    stop  = var.schedule_stop_expression
+    start = var.schedule_start_expression
+  } : {}
+
+  name                = "${local.identifier_prefix}-scheduled-${each.key}"
+  description         = "Scheduled ${each.key} of ${local.identifier_prefix} databases tagged Schedule = ${var.schedule_tag_value}"
+  schedule_expression = each.value
+
+  tags = local.common_tags
+}
+
+resource "aws_cloudwatch_event_target" "scheduler" {
+  for_each = aws_cloudwatch_event_rule.scheduler
+
+  rule  = each.value.name
+  arn   = aws_lambda_function.scheduler[0].arn
+  input = jsonencode({ action = each.key })
+}
+
+resource "aws_lambda_permission" "scheduler" {
+  for_each = aws_cloudwatch_event_rule.scheduler
+
+  statement_id  = "AllowEventBridge${title(each.key)}"
+  action        = "lambda:InvokeFunction"
+  function_name = aws_lambda_function.scheduler[0].function_name
+  principal     = "events.${var.dns_suffix}"
+  source_arn    = each.value.arn
+}
+
+# ==============================================================================
+# Cross-Region Automated Backup Replication (Conditional)
+# ==============================================================================
+# Copies automated backups to the aws.replica provider region for DR restores
+resource "aws_db_instance_automated_backups_replication" "main" {
+  count    = local.instance_enabled && var.enable_cross_region_backups ? 1 : 0
+  provider = aws.replica
+
+  source_db_instance_arn = aws_db_instance.main[0].arn
+  kms_key_id             = var.replica_kms_key_arn
+  retention_period       = var.backup_retention_days
+}
+
 # ==============================================================================
 # Manual Snapshot Before Destructive Changes (Production Only)
 # ==============================================================================
-# Create manual snapshot before destructive operations
+# Create manual snapshot before destructive operations; Aurora snapshots are
+# taken of the cluster rather than an instance
+locals {
+  manual_snapshot_cli = local.aurora_enabled ? {
+    command       = "create-db-cluster-snapshot"
+    source_flag   = "--db-cluster-identifier"
+    snapshot_flag = "--db-cluster-snapshot-identifier"
+    source        = aws_rds_cluster.aurora[0].cluster_identifier
+    } : {
+    command       = "create-db-snapshot"
+    source_flag   = "--db-instance-identifier"
+    snapshot_flag = "--db-snapshot-identifier"
+    source        = aws_db_instance.main[0].identifier
+  }
+}
+
 resource "null_resource" "manual_snapshot" {
   count = var.environment == "production" ? 1 : 0
 
   triggers = {
-    db_instance_id = aws_db_instance.main.id
+    db_instance_id = local.primary.id
     timestamp      = timestamp()
   }
 
   provisioner "local-exec" {
     command = <<-EOT
       # Create manual snapshot before destructive changes
-      aws rds create-db-snapshot \
-        --db-instance-identifier ${aws_db_instance.main.identifier} \
-        --db-snapshot-identifier manual-${aws_db_instance.main.identifier}-${formatdate("YYYY-MM-DD-hhmm", timestamp())} \
+      aws rds ${local.manual_snapshot_cli.command} \
+        ${local.manual_snapshot_cli.source_flag} ${local.manual_snapshot_cli.source} \
+        ${local.manual_snapshot_cli.snapshot_flag} manual-${local.manual_snapshot_cli.source}-${formatdate("YYYY-MM-DD-hhmm", timestamp())} \
         --tags Key=Environment,Value=${var.environment} Key=Type,Value=manual-snapshot Key=ManagedBy,Value=Terraform
     EOT
 
@@ -326,6 +1388,58 @@ resource "null_resource" "manual_snapshot" {
   }
 
   depends_on = [
-    aws_db_instance.main
+    aws_db_instance.main,
+    aws_rds_cluster_instance.aurora
+  ]
+}
+
+# ==============================================================================
+# Sizing Recommendation
+# ==============================================================================
+# Recommends an instance class and storage for the expected workload and warns
+# (without failing the plan) when the chosen configuration looks undersized.
+
+locals {
+  # Approximate PostgreSQL max_connections per class
+  # (LEAST(DBInstanceClassMemory/9531392, 5000)), smallest first
+  sizing_classes = [
+    { instance_class = "db.t3.micro", max_connections = 112, burstable = true },
+    { instance_class = "db.t3.small", max_connections = 225, burstable = true },
+    { instance_class = "db.t3.medium", max_connections = 450, burstable = true },
+    { instance_class = "db.t3.large", max_connections = 901, burstable = true },
+    { instance_class = "db.r6g.large", max_connections = 1802, burstable = false },
+    { instance_class = "db.r6g.xlarge", max_connections = 3604, burstable = false },
+    { instance_class = "db.r6g.2xlarge", max_connections = 5000, burstable = false },
   ]
+
+  # Keep 20% of connections free for admin sessions, replication and failover
+  sizing_required_connections = ceil(var.expected_connections * 1.25)
+
+  # Burstable classes can exhaust CPU credits under sustained production load
+  sizing_candidates = [
+    for class in local.sizing_classes : class
+    if class.max_connections >= local.sizing_required_connections && !(var.environment == "production" && class.burstable)
+  ]
+  sizing_recommended_class = length(local.sizing_candidates) > 0 ? local.sizing_candidates[0].instance_class : local.sizing_classes[length(local.sizing_classes) - 1].instance_class
+
+  # 50% growth headroom over the expected data set, autoscaling up to twice that
+  sizing_recommended_storage = max(20, ceil(var.expected_storage_gb * 1.5))
+
+  # Classes missing from the table are not judged
+  sizing_chosen_class     = one([for class in local.sizing_classes : class if class.instance_class == var.instance_class])
+  sizing_chosen_capacity  = try(local.sizing_chosen_class.max_connections, 5000)
+  sizing_chosen_burstable = try(local.sizing_chosen_class.burstable, false)
+
+  sizing_warnings = compact([
+    local.sizing_chosen_capacity < local.sizing_required_connections ? "${var.instance_class} supports about ${local.sizing_chosen_capacity} connections but ${local.sizing_required_connections} are needed for ${var.expected_connections} expected connections" : "",
+    var.environment == "production" && local.sizing_chosen_burstable ? "${var.instance_class} is a burstable class and may throttle under sustained production load" : "",
+    var.allocated_storage < var.expected_storage_gb ? "allocated_storage (${var.allocated_storage} GB) is below expected_storage_gb (${var.expected_storage_gb} GB)" : "",
+  ])
+}
+
+check "rds_sizing" {
+  assert {
+    condition     = length(local.sizing_warnings) == 0
+    error_message = "RDS instance looks undersized: ${join("; ", local.sizing_warnings)}. Consider ${local.sizing_recommended_class} with ${local.sizing_recommended_storage} GB."
+  }
 }
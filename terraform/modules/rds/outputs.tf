@@ -5,27 +5,27 @@
 # ==============================================================================
 
 output "rds_endpoint" {
-  value       = aws_db_instance.main.endpoint
+  value       = local.primary.endpoint
   description = "RDS primary endpoint (host:port)"
 }
 
 output "rds_address" {
-  value       = aws_db_instance.main.address
+  value       = local.primary.address
   description = "RDS primary instance hostname"
 }
 
 output "rds_port" {
-  value       = aws_db_instance.main.port
+  value       = local.primary.port
   description = "RDS primary instance port"
 }
 
 output "rds_db_name" {
-  value       = aws_db_instance.main.db_name
+  value       = local.primary.db_name
   description = "Database name"
 }
 
 output "rds_username" {
-  value       = aws_db_instance.main.username
+  value       = local.primary.username
   description = "Database master username"
   sensitive   = true
 }
@@ -36,19 +36,84 @@ output "rds_password" {
   sensitive   = true
 }
 
+output "strong_password_enforced" {
+  value = (
+    random_password.master_password.length >= 16 &&
+    random_password.master_password.min_upper > 0 &&
+    random_password.master_password.min_lower > 0 &&
+    random_password.master_password.min_numeric > 0 &&
+    random_password.master_password.min_special > 0
+  )
+  description = "Whether the master password in state was generated with at least 16 characters and every character class (false for passwords predating the requirements)"
+}
+
+output "rds_username_ssm_parameter" {
+  value       = aws_ssm_parameter.master_username.name
+  description = "SSM SecureString parameter holding the master username"
+}
+
+output "rds_password_ssm_parameter" {
+  value       = aws_ssm_parameter.master_password.name
+  description = "SSM SecureString parameter holding the master password"
+}
+
 output "rds_arn" {
-  value       = aws_db_instance.main.arn
-  description = "RDS instance ARN"
+  value       = local.primary.arn
+  description = "RDS instance ARN (cluster ARN for Aurora)"
 }
 
 output "rds_id" {
-  value       = aws_db_instance.main.id
-  description = "RDS instance identifier"
+  value       = local.primary.id
+  description = "RDS instance ID (cluster ID for Aurora)"
+}
+
+output "rds_identifier" {
+  value       = local.primary.identifier
+  description = "RDS primary instance identifier, the writer instance for Aurora (for AWS API lookups)"
+}
+
+output "rds_ca_cert_identifier" {
+  value       = local.primary.ca_cert_identifier
+  description = "Certificate authority the primary instance's server certificate is signed by"
+}
+
+output "rds_ca_rotation_needed" {
+  value       = local.primary.ca_cert_identifier != data.aws_rds_certificate.latest.id
+  description = "Whether the primary's CA differs from the available CA with the latest expiry"
+}
+
+output "rds_ca_latest_identifier" {
+  value       = data.aws_rds_certificate.latest.id
+  description = "Available RDS CA with the latest expiry, the target for a CA rotation"
 }
 
 output "rds_resource_id" {
-  value       = aws_db_instance.main.resource_id
-  description = "RDS instance resource ID"
+  value       = local.primary.resource_id
+  description = "RDS instance resource ID (cluster resource ID for Aurora, as used by IAM database auth)"
+}
+
+# ==============================================================================
+# Aurora Cluster Outputs
+# ==============================================================================
+
+output "engine_type" {
+  value       = var.engine_type
+  description = "Database engine in use (postgres or aurora-postgresql)"
+}
+
+output "aurora_cluster_endpoint" {
+  value       = local.aurora_enabled ? aws_rds_cluster.aurora[0].endpoint : ""
+  description = "Aurora cluster writer endpoint hostname (empty for postgres)"
+}
+
+output "aurora_reader_endpoint" {
+  value       = local.aurora_enabled ? aws_rds_cluster.aurora[0].reader_endpoint : ""
+  description = "Aurora cluster reader endpoint hostname, load balanced across readers (empty for postgres)"
+}
+
+output "aurora_cluster_identifier" {
+  value       = local.aurora_enabled ? aws_rds_cluster.aurora[0].cluster_identifier : ""
+  description = "Aurora cluster identifier (empty for postgres)"
 }
 
 # ==============================================================================
@@ -56,20 +121,59 @@ output "rds_resource_id" {
 # ==============================================================================
 
 output "rds_reader_endpoint" {
-  value       = var.enable_read_replica ? aws_db_instance.read_replica[0].endpoint : ""
+  value       = local.instance_enabled && var.enable_read_replica ? aws_db_instance.read_replica[0].endpoint : ""
   description = "RDS reader endpoint (read replica)"
 }
 
 output "rds_reader_address" {
-  value       = var.enable_read_replica ? aws_db_instance.read_replica[0].address : ""
+  value       = local.instance_enabled && var.enable_read_replica ? aws_db_instance.read_replica[0].address : ""
   description = "RDS read replica hostname"
 }
 
 output "rds_reader_arn" {
-  value       = var.enable_read_replica ? aws_db_instance.read_replica[0].arn : ""
+  value       = local.instance_enabled && var.enable_read_replica ? aws_db_instance.read_replica[0].arn : ""
   description = "RDS read replica ARN"
 }
 
+output "rds_reporting_endpoint" {
+  value       = local.instance_enabled && var.enable_reporting_replica ? aws_db_instance.reporting_replica[0].endpoint : ""
+  description = "Reporting replica endpoint (empty if disabled)"
+}
+
+output "rds_reporting_identifier" {
+  value       = local.instance_enabled && var.enable_reporting_replica ? aws_db_instance.reporting_replica[0].identifier : ""
+  description = "Reporting replica instance identifier"
+}
+
+output "rds_reporting_security_group_id" {
+  value       = var.enable_reporting_replica ? aws_security_group.reporting[0].id : ""
+  description = "Security group ID dedicated to the reporting replica"
+}
+
+# ==============================================================================
+# RDS Proxy Outputs
+# ==============================================================================
+
+output "rds_proxy_endpoint" {
+  value       = var.enable_rds_proxy ? aws_db_proxy.main[0].endpoint : ""
+  description = "RDS Proxy endpoint (empty if disabled)"
+}
+
+output "rds_proxy_reader_endpoint" {
+  value       = local.proxy_reader_enabled ? aws_db_proxy_endpoint.reader[0].endpoint : ""
+  description = "Read-only RDS Proxy endpoint routed to the read replica or Aurora readers (empty without the proxy or a reader)"
+}
+
+output "rds_proxy_name" {
+  value       = var.enable_rds_proxy ? aws_db_proxy.main[0].name : ""
+  description = "RDS Proxy name"
+}
+
+output "rds_proxy_require_tls" {
+  value       = var.enable_rds_proxy ? aws_db_proxy.main[0].require_tls : false
+  description = "Whether RDS Proxy requires TLS for client connections"
+}
+
 # ==============================================================================
 # Subnet and Parameter Group Outputs
 # ==============================================================================
@@ -85,13 +189,13 @@ output "db_subnet_group_arn" {
 }
 
 output "db_parameter_group_name" {
-  value       = aws_db_parameter_group.main.name
-  description = "DB parameter group name"
+  value       = local.aurora_enabled ? aws_rds_cluster_parameter_group.aurora[0].name : aws_db_parameter_group.main[0].name
+  description = "DB parameter group name (cluster parameter group for Aurora)"
 }
 
 output "db_parameter_group_arn" {
-  value       = aws_db_parameter_group.main.arn
-  description = "DB parameter group ARN"
+  value       = local.aurora_enabled ? aws_rds_cluster_parameter_group.aurora[0].arn : aws_db_parameter_group.main[0].arn
+  description = "DB parameter group ARN (cluster parameter group for Aurora)"
 }
 
 # ==============================================================================
@@ -99,13 +203,13 @@ output "db_parameter_group_arn" {
 # ==============================================================================
 
 output "connection_string" {
-  value       = "postgresql://${aws_db_instance.main.username}:${random_password.master_password.result}@${aws_db_instance.main.endpoint}/${aws_db_instance.main.db_name}"
+  value       = "postgresql://${local.primary.username}:${random_password.master_password.result}@${local.primary.endpoint}/${local.primary.db_name}"
   description = "Full PostgreSQL connection string for the primary instance"
   sensitive   = true
 }
 
 output "connection_string_asyncpg" {
-  value       = "postgresql+asyncpg://${aws_db_instance.main.username}:${random_password.master_password.result}@${aws_db_instance.main.endpoint}/${aws_db_instance.main.db_name}"
+  value       = "postgresql+asyncpg://${local.primary.username}:${random_password.master_password.result}@${local.primary.endpoint}/${local.primary.db_name}"
   description = "PostgreSQL connection string with asyncpg driver for Python"
   sensitive   = true
 }
@@ -134,16 +238,62 @@ output "environment" {
 }
 
 output "engine_version" {
-  value       = aws_db_instance.main.engine_version_actual
+  value       = local.primary.engine_version_actual
   description = "Actual PostgreSQL engine version"
 }
 
 output "storage_encrypted" {
-  value       = aws_db_instance.main.storage_encrypted
+  value       = local.primary.storage_encrypted
   description = "Whether storage encryption is enabled"
 }
 
+output "publicly_accessible" {
+  value       = local.primary.publicly_accessible
+  description = "Whether the primary instance has a public endpoint"
+}
+
 output "multi_az" {
-  value       = aws_db_instance.main.multi_az
+  value       = local.primary.multi_az
   description = "Whether Multi-AZ is enabled"
 }
+
+output "tenancy" {
+  value       = var.tenancy
+  description = "Instance tenancy of the primary (always default for PostgreSQL)"
+}
+
+output "license_model" {
+  value       = local.primary.license_model
+  description = "License model of the primary instance"
+}
+
+output "backup_replication_arn" {
+  value       = local.instance_enabled && var.enable_cross_region_backups ? aws_db_instance_automated_backups_replication.main[0].id : ""
+  description = "ARN of the replicated automated backups in the replica region (empty if disabled)"
+}
+
+output "snapshot_copy_configuration" {
+  value = local.snapshot_copy_enabled ? {
+    target_account_id = var.snapshot_copy_account_id
+    kms_key_arn       = aws_kms_key.snapshot_share[0].arn
+    lambda_function   = aws_lambda_function.snapshot_copy[0].function_name
+    event_rule        = aws_cloudwatch_event_rule.snapshot_created[0].name
+  } : {}
+  description = "Cross-account snapshot copy settings: target account, shareable KMS key, Lambda, and EventBridge rule (empty if disabled)"
+}
+
+output "schedule_expressions" {
+  value       = { for action, rule in aws_cloudwatch_event_rule.scheduler : action => rule.schedule_expression }
+  description = "EventBridge stop and start schedules keyed by action (empty if scheduling is disabled or the environment is production)"
+}
+
+output "rds_sizing_recommendation" {
+  value = {
+    instance_class        = local.sizing_recommended_class
+    allocated_storage     = local.sizing_recommended_storage
+    max_allocated_storage = local.sizing_recommended_storage * 2
+    undersized            = length(local.sizing_warnings) > 0
+    warnings              = local.sizing_warnings
+  }
+  description = "Recommended instance class and storage for expected_connections/expected_storage_gb, with any undersizing warnings"
+}
@@ -9,6 +9,17 @@ variable "environment" {
   }
 }
 
+variable "name_suffix" {
+  type        = string
+  default     = ""
+  description = "Optional suffix for resource names (tests/ephemeral runs)"
+
+  validation {
+    condition     = can(regex("^[a-z0-9-]*$", var.name_suffix))
+    error_message = "name_suffix may contain only lowercase letters, digits, and hyphens."
+  }
+}
+
 variable "private_subnet_ids" {
   type        = list(string)
   description = "Private subnet IDs for RDS deployment"
@@ -16,16 +27,52 @@ variable "private_subnet_ids" {
     condition     = length(var.private_subnet_ids) >= 2
     error_message = "At least 2 private subnets are required for RDS subnet group"
   }
+  validation {
+    condition     = alltrue([for id in var.private_subnet_ids : can(regex("^subnet-[a-z0-9]+$", id))])
+    error_message = "private_subnet_ids must contain subnet IDs (subnet-xxxxx)"
+  }
 }
 
 variable "security_group_id" {
   type        = string
   description = "Security group ID for RDS access control"
+
+  validation {
+    condition     = can(regex("^sg-[a-z0-9]+$", var.security_group_id))
+    error_message = "security_group_id must be a security group ID (sg-xxxxx)"
+  }
 }
 
 variable "kms_key_id" {
   type        = string
   description = "KMS key ID for RDS encryption"
+
+  validation {
+    condition     = can(regex("^((mrk-)?[0-9a-f-]{32,36}|arn:aws[a-z-]*:kms:[a-z0-9-]+:[0-9]{12}:key/.+|alias/.+)$", var.kms_key_id))
+    error_message = "kms_key_id must be a KMS key ID, key ARN or alias; an empty value would leave encryption unconfigured"
+  }
+}
+
+variable "engine_type" {
+  type        = string
+  description = "Database engine: postgres (single RDS instance) or aurora-postgresql (Aurora cluster with writer and reader instances)"
+  default     = "postgres"
+
+  validation {
+    condition     = contains(["postgres", "aurora-postgresql"], var.engine_type)
+    error_message = "engine_type must be postgres or aurora-postgresql"
+  }
+}
+
+variable "aurora_reader_count" {
+  type        = number
+  description = "Number of Aurora reader instances alongside the writer (aurora-postgresql only)"
+  default     = 1
+
+  validation {
+    condition     = var.aurora_reader_count >= 0 && var.aurora_reader_count <= 15 && floor(var.aurora_reader_count) == var.aurora_reader_count
+    error_message = "aurora_reader_count must be a whole number between 0 and 15"
+  }
 }
 
 variable "instance_class" {
@@ -38,6 +85,28 @@ variable "instance_class" {
   }
 }
 
+variable "tenancy" {
+  type        = string
+  description = "Instance tenancy: default (shared hardware) or dedicated; RDS for PostgreSQL only runs on default tenancy"
+  default     = "default"
+
+  validation {
+    condition     = contains(["default", "dedicated"], var.tenancy)
+    error_message = "tenancy must be default or dedicated"
+  }
+}
+
+variable "license_model" {
+  type        = string
+  description = "License model of the instance; PostgreSQL only supports postgresql-license"
+  default     = "postgresql-license"
+
+  validation {
+    condition     = contains(["postgresql-license", "license-included", "bring-your-own-license"], var.license_model)
+    error_message = "license_model must be postgresql-license, license-included or bring-your-own-license"
+  }
+}
+
 variable "allocated_storage" {
   type        = number
   description = "Allocated storage in GB"
@@ -64,6 +133,12 @@ variable "multi_az" {
   default     = false
 }
 
+variable "availability_zone" {
+  type        = string
+  description = "Pin the primary instance (or Aurora instances) to this AZ, e.g. to keep app-to-database traffic in one zone; incompatible with multi_az (empty lets RDS choose)"
+  default     = ""
+}
+
 variable "enable_read_replica" {
   type        = bool
   description = "Enable read replica (production only)"
@@ -72,11 +147,11 @@ variable "enable_read_replica" {
 
 variable "backup_retention_days" {
   type        = number
-  description = "Automated backup retention period in days"
+  description = "Automated backup retention period in days (0 disables automated backups, which also rules out read replicas)"
   default     = 30
   validation {
-    condition     = var.backup_retention_days >= 1 && var.backup_retention_days <= 35
-    error_message = "Backup retention days must be between 1 and 35"
+    condition     = var.backup_retention_days >= 0 && var.backup_retention_days <= 35
+    error_message = "Backup retention days must be between 0 and 35"
   }
 }
 
@@ -86,6 +161,12 @@ variable "deletion_protection" {
   default     = false
 }
 
+variable "allow_destroy" {
+  type        = bool
+  description = "Test teardown escape hatch: override deletion_protection and shorten KMS deletion windows (never enable in production)"
+  default     = false
+}
+
 variable "db_name" {
   type        = string
   description = "Name of the initial database to create"
@@ -106,6 +187,28 @@ variable "db_username" {
   }
 }
 
+variable "master_password_length" {
+  type        = number
+  description = "Length of the generated master password; every password also contains at least one upper, lower, numeric and special character"
+  default     = 32
+
+  validation {
+    condition     = var.master_password_length >= 16 && var.master_password_length <= 128
+    error_message = "master_password_length must be between 16 and 128 characters."
+  }
+}
+
+variable "ca_cert_identifier" {
+  type        = string
+  description = "Certificate authority for the instances' server certificates (empty keeps the RDS default); set it to rotate ahead of a CA expiry"
+  default     = ""
+
+  validation {
+    condition     = var.ca_cert_identifier == "" || can(regex("^rds-ca-[a-z0-9-]+$", var.ca_cert_identifier))
+    error_message = "ca_cert_identifier must be an RDS CA identifier such as rds-ca-rsa2048-g1"
+  }
+}
+
 variable "db_port" {
   type        = number
   description = "Port for PostgreSQL database"
@@ -216,7 +319,7 @@ variable "final_snapshot_identifier_prefix" {
 
 variable "copy_tags_to_snapshot" {
   type        = bool
-  description = "Copy tags to snapshots"
+  description = "Copy instance tags (e.g., DataClassification, Environment) to snapshots so restored databases keep them"
   default     = true
 }
 
@@ -232,6 +335,170 @@ variable "apply_immediately" {
   default     = false
 }
 
+variable "enable_reporting_replica" {
+  type        = bool
+  description = "Create a read-only reporting replica in a different AZ with its own security group"
+  default     = false
+}
+
+variable "reporting_replica_instance_class" {
+  type        = string
+  description = "Instance class for the reporting replica (defaults to instance_class)"
+  default     = ""
+}
+
+variable "reporting_replica_availability_zone" {
+  type        = string
+  description = "AZ for the reporting replica (defaults to a private subnet AZ other than the primary's)"
+  default     = ""
+}
+
+variable "reporting_allowed_security_group_ids" {
+  type        = list(string)
+  description = "Security group IDs of reporting clients allowed to connect to the reporting replica"
+  default     = []
+}
+
+variable "enable_rds_proxy" {
+  type        = bool
+  description = "Deploy RDS Proxy in front of the primary instance for connection pooling"
+  default     = false
+}
+
+variable "proxy_require_tls" {
+  type        = bool
+  description = "Require TLS for all client connections to RDS Proxy"
+  default     = true
+}
+
+variable "proxy_connection_borrow_timeout" {
+  type        = number
+  description = "Seconds a client waits to borrow a pooled connection before the proxy returns an error"
+  default     = 120
+
+  validation {
+    condition     = var.proxy_connection_borrow_timeout >= 1 && var.proxy_connection_borrow_timeout <= 3600
+    error_message = "proxy_connection_borrow_timeout must be between 1 and 3600 seconds"
+  }
+}
+
+variable "proxy_idle_client_timeout" {
+  type        = number
+  description = "Seconds an idle client connection is kept open by RDS Proxy"
+  default     = 1800
+
+  validation {
+    condition     = var.proxy_idle_client_timeout >= 1 && var.proxy_idle_client_timeout <= 28800
+    error_message = "proxy_idle_client_timeout must be between 1 and 28800 seconds"
+  }
+}
+
+variable "enable_cross_region_backups" {
+  type        = bool
+  description = "Replicate automated backups to the aws.replica provider region for disaster recovery"
+  default     = false
+}
+
+variable "replica_kms_key_arn" {
+  type        = string
+  description = "KMS key ARN in the replica region used to encrypt replicated backups (required when enable_cross_region_backups is true)"
+  default     = ""
+
+  validation {
+    condition     = var.replica_kms_key_arn == "" || can(regex("^arn:aws[a-z-]*:kms:[a-z0-9-]+:[0-9]{12}:key/.+$", var.replica_kms_key_arn))
+    error_message = "replica_kms_key_arn must be a valid KMS key ARN"
+  }
+}
+
+variable "snapshot_copy_account_id" {
+  type        = string
+  description = "Isolated backup account that receives copies of every snapshot, re-encrypted with a shareable key (empty disables)"
+  default     = ""
+
+  validation {
+    condition     = var.snapshot_copy_account_id == "" || can(regex("^[0-9]{12}$", var.snapshot_copy_account_id))
+    error_message = "snapshot_copy_account_id must be a 12-digit AWS account ID"
+  }
+}
+
+variable "enable_scheduling" {
+  type        = bool
+  description = "Stop the database overnight and start it before business hours (ignored in production)"
+  default     = false
+}
+
+variable "schedule_stop_expression" {
+  type        = string
+  description = "EventBridge schedule (UTC) that stops databases tagged Schedule = schedule_tag_value"
+  default     = "cron(0 1 ? * * *)"
+
+  validation {
+    condition     = can(regex("^(cron|rate)\\(.+\\)$", var.schedule_stop_expression))
+    error_message = "schedule_stop_expression must be an EventBridge cron() or rate() expression"
+  }
+}
+
+variable "schedule_start_expression" {
+  type        = string
+  description = "EventBridge schedule (UTC) that starts databases tagged Schedule = schedule_tag_value"
+  default     = "cron(0 12 ? * MON-FRI *)"
+
+  validation {
+    condition     = can(regex("^(cron|rate)\\(.+\\)$", var.schedule_start_expression))
+    error_message = "schedule_start_expression must be an EventBridge cron() or rate() expression"
+  }
+}
+
+variable "schedule_tag_value" {
+  type        = string
+  description = "Schedule tag value put on the database and matched by the scheduler"
+  default     = "office-hours"
+}
+
+variable "expected_connections" {
+  type        = number
+  description = "Expected peak concurrent database connections, used for rds_sizing_recommendation"
+  default     = 50
+
+  validation {
+    condition     = var.expected_connections >= 1
+    error_message = "expected_connections must be at least 1"
+  }
+}
+
+variable "expected_storage_gb" {
+  type        = number
+  description = "Expected database size in GB, used for rds_sizing_recommendation"
+  default     = 10
+
+  validation {
+    condition     = var.expected_storage_gb > 0
+    error_message = "expected_storage_gb must be greater than 0"
+  }
+}
+
+variable "partition" {
+  type        = string
+  description = "AWS partition used to build ARNs (aws, aws-us-gov or aws-cn)"
+  default     = "aws"
+
+  validation {
+    condition     = contains(["aws", "aws-us-gov", "aws-cn"], var.partition)
+    error_message = "partition must be aws, aws-us-gov or aws-cn"
+  }
+}
+
+variable "dns_suffix" {
+  type        = string
+  description = "DNS suffix of the partition used to build service principals (amazonaws.com.cn in China)"
+  default     = "amazonaws.com"
+
+  validation {
+    condition     = contains(["amazonaws.com", "amazonaws.com.cn"], var.dns_suffix)
+    error_message = "dns_suffix must be amazonaws.com or amazonaws.com.cn"
+  }
+}
+
 variable "tags" {
   type        = map(string)
   description = "Additional resource tags"
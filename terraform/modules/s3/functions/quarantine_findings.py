@@ -0,0 +1,95 @@
+"""Move objects flagged by Macie or GuardDuty into the quarantine bucket.
+
+Triggered by EventBridge for high-severity "Macie Finding" and "GuardDuty
+Finding" events. Flagged objects in the documents or backups bucket are copied
+into the Object Lock protected quarantine bucket and the flagged version is
+deleted from the source. Every finding is published to the alert topic, so
+findings on buckets this function may not touch still reach the security team.
+"""
+
+import json
+import os
+
+import boto3
+
+s3 = boto3.client("s3")
+sns = boto3.client("sns")
+
+QUARANTINE_BUCKET = os.environ["QUARANTINE_BUCKET"]
+QUARANTINE_KMS_KEY_ARN = os.environ["QUARANTINE_KMS_KEY_ARN"]
+SOURCE_BUCKETS = set(filter(None, os.environ.get("SOURCE_BUCKETS", "").split(",")))
+ALERT_TOPIC_ARN = os.environ["ALERT_TOPIC_ARN"]
+
+
+def flagged_objects(event):
+    """Return (bucket, key, version_id) tuples referenced by a finding."""
+    detail = event.get("detail", {})
+
+    if event.get("source") == "aws.macie":
+        resources = detail.get("resourcesAffected", {})
+        bucket = resources.get("s3Bucket", {}).get("name")
+        obj = resources.get("s3Object", {})
+        if bucket and obj.get("key"):
+            return [(bucket, obj["key"], obj.get("versionId"))]
+        return []
+
+    objects = []
+    for bucket in detail.get("resource", {}).get("s3BucketDetails", []):
+        for obj in bucket.get("s3ObjectDetails", []):
+            if bucket.get("name") and obj.get("key"):
+                objects.append((bucket["name"], obj["key"], obj.get("versionId")))
+    return objects
+
+
+def quarantine(bucket, key, version_id):
+    source = {"Bucket": bucket, "Key": key}
+    if version_id:
+        source["VersionId"] = version_id
+
+    quarantine_key = f"{bucket}/{key}"
+    s3.copy_object(
+        Bucket=QUARANTINE_BUCKET,
+        Key=quarantine_key,
+        CopySource=source,
+        # Without an explicit key S3 falls back to the AWS managed aws/s3 key, not the stack CMK
+        ServerSideEncryption="aws:kms",
+        SSEKMSKeyId=QUARANTINE_KMS_KEY_ARN,
+        TaggingDirective="COPY",
+    )
+
+    # Deleting the exact version removes the PHI rather than hiding it behind a delete marker
+    if version_id:
+        s3.delete_object(Bucket=bucket, Key=key, VersionId=version_id)
+    else:
+        s3.delete_object(Bucket=bucket, Key=key)
+    return quarantine_key
+
+
+def handler(event, _context):
+    detail = event.get("detail", {})
+    results = []
+    for bucket, key, version_id in flagged_objects(event):
+        if bucket not in SOURCE_BUCKETS:
+            results.append({"bucket": bucket, "key": key, "action": "alerted"})
+            continue
+        try:
+            quarantine_key = quarantine(bucket, key, version_id)
+            results.append({"bucket": bucket, "key": key, "action": "quarantined", "quarantine_key": quarantine_key})
+        except Exception as err:  # keep alerting on the rest of the finding
+            results.append({"bucket": bucket, "key": key, "action": "failed", "error": str(err)})
+
+    sns.publish(
+        TopicArn=ALERT_TOPIC_ARN,
+        Subject=f"PHI quarantine: {event.get('detail-type', 'finding')}"[:100],
+        Message=json.dumps(
+            {
+                "source": event.get("source"),
+                "finding_id": detail.get("id"),
+                "finding_type": detail.get("type"),
+                "severity": detail.get("severity"),
+                "objects": results,
+            },
+            default=str,
+        ),
+    )
+    return {"action": "processed", "objects": results}
@@ -15,6 +15,102 @@ locals {
   backups_bucket_name    = "hipaa-compliant-backups-${local.full_suffix}-${var.aws_account_id}"
   audit_logs_bucket_name = "hipaa-compliant-audit-${local.full_suffix}-${var.aws_account_id}"
 
+  documents_replica_bucket_name = "hipaa-compliant-docs-replica-${local.full_suffix}-${var.aws_account_id}"
+  canary_bucket_name            = "hipaa-compliant-canary-${local.full_suffix}-${var.aws_account_id}"
+  quarantine_bucket_name        = "hipaa-compliant-quarantine-${local.full_suffix}-${var.aws_account_id}"
+
+  # Only this role may read or write quarantined objects
+  quarantine_role_arn = var.quarantine_role_arn != "" ? var.quarantine_role_arn : one(aws_iam_role.quarantine[*].arn)
+
+  # Findings Lambda moves flagged objects out of these buckets
+  quarantine_automation_enabled = var.create_quarantine_bucket && var.enable_quarantine_automation
+  quarantine_source_buckets     = [local.documents_bucket_name, local.backups_bucket_name]
+  kms_key_arn                   = startswith(var.kms_key_id, "arn:") ? var.kms_key_id : "arn:${var.partition}:kms:${data.aws_region.current.name}:${var.aws_account_id}:key/${var.kms_key_id}"
+
+  # Documents key transition: new writes use the active key while objects
+  # under either key stay decryptable until they are re-encrypted
+  documents_key_transition = var.secondary_kms_key_arn != ""
+  documents_kms_key_arn    = var.documents_active_kms_key == "secondary" ? var.secondary_kms_key_arn : local.kms_key_arn
+  documents_kms_key_arns   = compact([local.kms_key_arn, var.secondary_kms_key_arn])
+
+  backups_kms_key_arn = var.backups_kms_key_arn != "" ? var.backups_kms_key_arn : local.kms_key_arn
+
+  # Upload encryption enforcement: encryption headers, when sent, must select
+  # SSE-KMS with an authorized key. Header-less writes (including multipart
+  # UploadPart, which never carries them) fall through to the bucket default
+  # key. AWS service deliveries (CloudTrail, access logs) into the audit
+  # bucket are exempt there.
+  upload_encryption_buckets = {
+    documents = {
+      arn             = aws_s3_bucket.documents.arn
+      key_arns        = local.documents_kms_key_arns
+      exempt_services = false
+    }
+    backups = {
+      arn             = aws_s3_bucket.backups.arn
+      key_arns        = [local.backups_kms_key_arn]
+      exempt_services = false
+    }
+    audit_logs = {
+      arn             = aws_s3_bucket.audit_logs.arn
+      key_arns        = [local.kms_key_arn]
+      exempt_services = true
+    }
+  }
+  upload_encryption_statements = {
+    for name, bucket in local.upload_encryption_buckets : name => var.deny_unencrypted_uploads ? [
+      {
+        Sid       = "DenyNonKMSUploads"
+        Effect    = "Deny"
+        Principal = "*"
+        Action    = "s3:PutObject"
+        Resource  = "${bucket.arn}/*"
+        Condition = merge(
+          {
+            StringNotEqualsIfExists = {
+              "s3:x-amz-server-side-encryption" = "aws:kms"
+            }
+          },
+          bucket.exempt_services ? { Bool = { "aws:PrincipalIsAWSService" = "false" } } : {}
+        )
+      },
+      {
+        Sid       = "DenyUploadsWithOtherKMSKeys"
+        Effect    = "Deny"
+        Principal = "*"
+        Action    = "s3:PutObject"
+        Resource  = "${bucket.arn}/*"
+        Condition = merge(
+          {
+            StringNotEqualsIfExists = {
+              "s3:x-amz-server-side-encryption-aws-kms-key-id" = bucket.key_arns
+            }
+          },
+          bucket.exempt_services ? { Bool = { "aws:PrincipalIsAWSService" = "false" } } : {}
+        )
+      },
+      {
+        # aws:kms without a key ID selects the AWS managed aws/s3 key
+        Sid       = "DenyKMSUploadsWithoutKeyId"
+        Effect    = "Deny"
+        Principal = "*"
+        Action    = "s3:PutObject"
+        Resource  = "${bucket.arn}/*"
+        Condition = merge(
+          {
+            StringEquals = {
+              "s3:x-amz-server-side-encryption" = "aws:kms"
+            }
+            Null = {
+              "s3:x-amz-server-side-encryption-aws-kms-key-id" = "true"
+            }
+          },
+          bucket.exempt_services ? { Bool = { "aws:PrincipalIsAWSService" = "false" } } : {}
+        )
+      }
+    ] : []
+  }
+
   common_tags = merge(
     var.tags,
     {
@@ -32,7 +128,7 @@ locals {
 
 resource "aws_s3_bucket" "documents" {
   bucket        = local.documents_bucket_name
-  force_destroy = false
+  force_destroy = var.allow_destroy
 
   tags = merge(
     local.common_tags,
@@ -49,7 +145,7 @@ resource "aws_s3_bucket" "documents" {
 
 resource "aws_s3_bucket" "backups" {
   bucket        = local.backups_bucket_name
-  force_destroy = false
+  force_destroy = var.allow_destroy
 
   tags = merge(
     local.common_tags,
@@ -66,7 +162,7 @@ resource "aws_s3_bucket" "backups" {
 
 resource "aws_s3_bucket" "audit_logs" {
   bucket        = local.audit_logs_bucket_name
-  force_destroy = false
+  force_destroy = var.allow_destroy
 
   tags = merge(
     local.common_tags,
@@ -87,10 +183,17 @@ resource "aws_s3_bucket_server_side_encryption_configuration" "documents" {
   rule {
     apply_server_side_encryption_by_default {
       sse_algorithm     = "aws:kms"
-      kms_master_key_id = var.kms_key_id
+      kms_master_key_id = local.documents_kms_key_arn
     }
     bucket_key_enabled = true
   }
+
+  lifecycle {
+    precondition {
+      condition     = var.documents_active_kms_key == "primary" || local.documents_key_transition
+      error_message = "documents_active_kms_key = \"secondary\" requires secondary_kms_key_arn"
+    }
+  }
 }
 
 resource "aws_s3_bucket_server_side_encryption_configuration" "backups" {
@@ -99,7 +202,7 @@ resource "aws_s3_bucket_server_side_encryption_configuration" "backups" {
   rule {
     apply_server_side_encryption_by_default {
       sse_algorithm     = "aws:kms"
-      kms_master_key_id = var.kms_key_id
+      kms_master_key_id = local.backups_kms_key_arn
     }
     bucket_key_enabled = true
   }
@@ -176,6 +279,22 @@ resource "aws_s3_bucket_public_access_block" "audit_logs" {
   restrict_public_buckets = true
 }
 
+# ==============================================================================
+# Transfer Acceleration - PHI Buckets (Explicitly Suspended)
+# ==============================================================================
+# Managed rather than left unset so acceleration enabled out of band shows up
+# as drift and is reverted on the next apply
+
+resource "aws_s3_bucket_accelerate_configuration" "documents" {
+  bucket = aws_s3_bucket.documents.id
+  status = var.enable_transfer_acceleration ? "Enabled" : "Suspended"
+}
+
+resource "aws_s3_bucket_accelerate_configuration" "backups" {
+  bucket = aws_s3_bucket.backups.id
+  status = var.enable_transfer_acceleration ? "Enabled" : "Suspended"
+}
+
 # ==============================================================================
 # Lifecycle Policies - Documents Bucket (Cost Optimization)
 # ==============================================================================
@@ -188,14 +307,14 @@ resource "aws_s3_bucket_lifecycle_configuration" "documents" {
     id     = "transition-to-infrequent-access"
     status = "Enabled"
 
-    transition {
-      days          = 90
-      storage_class = "STANDARD_IA"
-    }
-
-    transition {
-      days          = 365
-      storage_class = "GLACIER"
+    # Intelligent-Tiering replaces the fixed IA/Glacier schedule: objects move
+    # into it immediately and S3 tiers them by observed access
+    dynamic "transition" {
+      for_each = var.enable_intelligent_tiering ? { 0 = "INTELLIGENT_TIERING" } : { 90 = "STANDARD_IA", 365 = "GLACIER" }
+      content {
+        days          = tonumber(transition.key)
+        storage_class = transition.value
+      }
     }
 
     expiration {
@@ -213,6 +332,43 @@ resource "aws_s3_bucket_lifecycle_configuration" "documents" {
   }
 }
 
+# ==============================================================================
+# Intelligent-Tiering - Documents Bucket (Conditional)
+# ==============================================================================
+# Opt-in archive tiers for documents untouched for months. Archived objects
+# must be restored before they can be read, so the app must handle
+# InvalidObjectState on GetObject for old documents.
+
+resource "aws_s3_bucket_intelligent_tiering_configuration" "documents" {
+  count  = var.enable_intelligent_tiering ? 1 : 0
+  bucket = aws_s3_bucket.documents.id
+  name   = "documents-archive-tiers"
+  status = "Enabled"
+
+  tiering {
+    access_tier = "ARCHIVE_ACCESS"
+    days        = var.intelligent_tiering_archive_days
+  }
+
+  tiering {
+    access_tier = "DEEP_ARCHIVE_ACCESS"
+    days        = var.intelligent_tiering_deep_archive_days
+  }
+
+  lifecycle {
+    # Objects only enter Intelligent-Tiering through the lifecycle transition
+    precondition {
+      condition     = var.enable_lifecycle_policies
+      error_message = "enable_intelligent_tiering requires enable_lifecycle_policies to transition documents into Intelligent-Tiering"
+    }
+
+    precondition {
+      condition     = var.intelligent_tiering_deep_archive_days > var.intelligent_tiering_archive_days
+      error_message = "intelligent_tiering_deep_archive_days must be greater than intelligent_tiering_archive_days"
+    }
+  }
+}
+
 # ==============================================================================
 # Lifecycle Policies - Backups Bucket
 # ==============================================================================
@@ -249,11 +405,20 @@ resource "aws_s3_bucket_lifecycle_configuration" "backups" {
 # Access Logging Configuration
 # ==============================================================================
 
+# A bucket logging to itself writes a log object per log delivery, looping
+# until storage costs are noticed; documents_bucket_name overrides can cause it
 resource "aws_s3_bucket_logging" "documents" {
   bucket = aws_s3_bucket.documents.id
 
   target_bucket = aws_s3_bucket.audit_logs.id
   target_prefix = "documents-access/"
+
+  lifecycle {
+    precondition {
+      condition     = local.documents_bucket_name != local.audit_logs_bucket_name
+      error_message = "Audit logs bucket must differ from the documents bucket (${local.documents_bucket_name}) to avoid an access logging loop"
+    }
+  }
 }
 
 resource "aws_s3_bucket_logging" "backups" {
@@ -261,4 +426,913 @@ resource "aws_s3_bucket_logging" "backups" {
 
   target_bucket = aws_s3_bucket.audit_logs.id
   target_prefix = "backups-access/"
+
+  lifecycle {
+    precondition {
+      condition     = local.backups_bucket_name != local.audit_logs_bucket_name
+      error_message = "Audit logs bucket must differ from the backups bucket (${local.backups_bucket_name}) to avoid an access logging loop"
+    }
+  }
+}
+
+# ==============================================================================
+# Documents and Backups Bucket Policies - Upload Encryption
+# ==============================================================================
+# While a secondary key is configured, writes naming any other key are denied
+# so a compromised key can be retired by switching documents_active_kms_key
+# and re-encrypting, without locking out objects still under the old key.
+# deny_unencrypted_uploads additionally rejects uploads that rely on default
+# encryption instead of sending an SSE-KMS header.
+
+resource "aws_s3_bucket_policy" "documents" {
+  count  = local.documents_key_transition || var.deny_unencrypted_uploads ? 1 : 0
+  bucket = aws_s3_bucket.documents.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = concat(
+      local.documents_key_transition ? [
+        {
+          Sid       = "DenyUnauthorizedKMSKeys"
+          Effect    = "Deny"
+          Principal = "*"
+          Action    = "s3:PutObject"
+          Resource  = "${aws_s3_bucket.documents.arn}/*"
+          Condition = {
+            StringNotEqualsIfExists = {
+              "s3:x-amz-server-side-encryption-aws-kms-key-id" = local.documents_kms_key_arns
+            }
+          }
+        }
+      ] : [],
+      local.upload_encryption_statements["documents"]
+    )
+  })
+
+  depends_on = [aws_s3_bucket_public_access_block.documents]
+}
+
+resource "aws_s3_bucket_policy" "backups" {
+  count  = var.deny_unencrypted_uploads ? 1 : 0
+  bucket = aws_s3_bucket.backups.id
+
+  policy = jsonencode({
+    Version   = "2012-10-17"
+    Statement = local.upload_encryption_statements["backups"]
+  })
+
+  depends_on = [aws_s3_bucket_public_access_block.backups]
+}
+
+# ==============================================================================
+# Audit Logs Bucket Policy - CloudTrail Delivery
+# ==============================================================================
+# Allows CloudTrail in this account to deliver log files under cloudtrail/
+
+resource "aws_s3_bucket_policy" "audit_logs" {
+  bucket = aws_s3_bucket.audit_logs.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = concat([
+      {
+        Sid    = "AWSCloudTrailAclCheck"
+        Effect = "Allow"
+        Principal = {
+          Service = "cloudtrail.${var.dns_suffix}"
+        }
+        Action   = "s3:GetBucketAcl"
+        Resource = aws_s3_bucket.audit_logs.arn
+        Condition = {
+          StringEquals = {
+            "aws:SourceAccount" = var.aws_account_id
+          }
+        }
+      },
+      {
+        Sid    = "AWSCloudTrailWrite"
+        Effect = "Allow"
+        Principal = {
+          Service = "cloudtrail.${var.dns_suffix}"
+        }
+        Action   = "s3:PutObject"
+        Resource = "${aws_s3_bucket.audit_logs.arn}/cloudtrail/AWSLogs/${var.aws_account_id}/*"
+        Condition = {
+          StringEquals = {
+            "s3:x-amz-acl"      = "bucket-owner-full-control"
+            "aws:SourceAccount" = var.aws_account_id
+          }
+        }
+      }
+      ],
+      local.upload_encryption_statements["audit_logs"]
+    )
+  })
+
+  # Policy attachment fails while public access settings are still being applied
+  depends_on = [aws_s3_bucket_public_access_block.audit_logs]
+}
+
+# ==============================================================================
+# Request Metrics and Error Alarms - Documents Bucket (Conditional)
+# ==============================================================================
+# Per-request CloudWatch metrics for the PHI bucket. A spike in 4xx responses
+# (mostly AccessDenied) can mean credential probing; 5xx points at S3 or KMS
+# throttling that the application will surface as failed document access.
+
+resource "aws_s3_bucket_metric" "documents" {
+  count = var.enable_request_metrics ? 1 : 0
+
+  bucket = aws_s3_bucket.documents.id
+  name   = "EntireBucket"
+}
+
+resource "aws_cloudwatch_metric_alarm" "documents_4xx" {
+  count = var.enable_request_metrics ? 1 : 0
+
+  alarm_name          = "${local.full_suffix}-documents-4xx-errors"
+  alarm_description   = "More than ${var.request_4xx_alarm_threshold} 4xx responses in 5 minutes on ${local.documents_bucket_name} - possible AccessDenied probing"
+  namespace           = "AWS/S3"
+  metric_name         = "4xxErrors"
+  statistic           = "Sum"
+  period              = 300
+  evaluation_periods  = 1
+  threshold           = var.request_4xx_alarm_threshold
+  comparison_operator = "GreaterThanThreshold"
+  treat_missing_data  = "notBreaching"
+
+  dimensions = {
+    BucketName = aws_s3_bucket.documents.id
+    FilterId   = aws_s3_bucket_metric.documents[0].name
+  }
+
+  alarm_actions = var.request_alarm_actions
+
+  tags = local.common_tags
+}
+
+resource "aws_cloudwatch_metric_alarm" "documents_5xx" {
+  count = var.enable_request_metrics ? 1 : 0
+
+  alarm_name          = "${local.full_suffix}-documents-5xx-errors"
+  alarm_description   = "More than ${var.request_5xx_alarm_threshold} 5xx responses in 5 minutes on ${local.documents_bucket_name}"
+  namespace           = "AWS/S3"
+  metric_name         = "5xxErrors"
+  statistic           = "Sum"
+  period              = 300
+  evaluation_periods  = 1
+  threshold           = var.request_5xx_alarm_threshold
+  comparison_operator = "GreaterThanThreshold"
+  treat_missing_data  = "notBreaching"
+
+  dimensions = {
+    BucketName = aws_s3_bucket.documents.id
+    FilterId   = aws_s3_bucket_metric.documents[0].name
+  }
+
+  alarm_actions = var.request_alarm_actions
+
+  tags = local.common_tags
+}
+
+# ==============================================================================
+# EventBridge Notifications - Documents and Backups Buckets (Conditional)
+# ==============================================================================
+# S3 publishes every object event to the account's default event bus once
+# EventBridge is enabled on a bucket; there is no per-event filter at this
+# level, so the audit pipeline selects what it needs with its own rules.
+
+resource "aws_s3_bucket_notification" "documents" {
+  count = var.enable_eventbridge_notifications ? 1 : 0
+
+  bucket      = aws_s3_bucket.documents.id
+  eventbridge = true
+}
+
+resource "aws_s3_bucket_notification" "backups" {
+  count = var.enable_eventbridge_notifications ? 1 : 0
+
+  bucket      = aws_s3_bucket.backups.id
+  eventbridge = true
+}
+
+# ==============================================================================
+# Canary Bucket - Access Tripwire (Conditional)
+# ==============================================================================
+# A bucket no workload should ever read. Any GetObject trips an alarm; pair it
+# with CloudTrail data events (root module) to identify the caller.
+
+resource "aws_s3_bucket" "canary" {
+  count = var.create_canary_bucket ? 1 : 0
+
+  bucket        = local.canary_bucket_name
+  force_destroy = var.allow_destroy
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name    = local.canary_bucket_name
+      Purpose = "Canary - Any Access Is Suspicious"
+    }
+  )
+}
+
+resource "aws_s3_bucket_server_side_encryption_configuration" "canary" {
+  count = var.create_canary_bucket ? 1 : 0
+
+  bucket = aws_s3_bucket.canary[0].id
+
+  rule {
+    apply_server_side_encryption_by_default {
+      sse_algorithm     = "aws:kms"
+      kms_master_key_id = var.kms_key_id
+    }
+    bucket_key_enabled = true
+  }
+}
+
+resource "aws_s3_bucket_public_access_block" "canary" {
+  count = var.create_canary_bucket ? 1 : 0
+
+  bucket = aws_s3_bucket.canary[0].id
+
+  block_public_acls       = true
+  block_public_policy     = true
+  ignore_public_acls      = true
+  restrict_public_buckets = true
+}
+
+# Bait object with a tempting name; contains no real data
+resource "aws_s3_object" "canary_bait" {
+  count = var.create_canary_bucket ? 1 : 0
+
+  bucket  = aws_s3_bucket.canary[0].id
+  key     = "exports/patient-records-export.csv"
+  content = "canary,do-not-use\n"
+
+  depends_on = [aws_s3_bucket_server_side_encryption_configuration.canary]
+}
+
+# Request metrics are required for per-bucket GetRequests in CloudWatch
+resource "aws_s3_bucket_metric" "canary" {
+  count = var.create_canary_bucket ? 1 : 0
+
+  bucket = aws_s3_bucket.canary[0].id
+  name   = "EntireBucket"
+}
+
+resource "aws_sns_topic" "canary_alerts" {
+  count = var.create_canary_bucket ? 1 : 0
+
+  name         = "${local.full_suffix}-canary-alerts"
+  display_name = "Canary Bucket Access Alerts - ${local.full_suffix}"
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${local.full_suffix}-canary-alerts"
+    }
+  )
+}
+
+resource "aws_sns_topic_policy" "canary_alerts" {
+  count = var.create_canary_bucket ? 1 : 0
+
+  arn = aws_sns_topic.canary_alerts[0].arn
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Principal = {
+          Service = "cloudwatch.${var.dns_suffix}"
+        }
+        Action   = "SNS:Publish"
+        Resource = aws_sns_topic.canary_alerts[0].arn
+      }
+    ]
+  })
+}
+
+resource "aws_sns_topic_subscription" "canary_email" {
+  count = var.create_canary_bucket && var.canary_alert_email != "" ? 1 : 0
+
+  topic_arn = aws_sns_topic.canary_alerts[0].arn
+  protocol  = "email"
+  endpoint  = var.canary_alert_email
+}
+
+resource "aws_cloudwatch_metric_alarm" "canary_access" {
+  count = var.create_canary_bucket ? 1 : 0
+
+  alarm_name          = "${local.full_suffix}-canary-bucket-access"
+  alarm_description   = "Object read from canary bucket ${local.canary_bucket_name} - investigate immediately"
+  namespace           = "AWS/S3"
+  metric_name         = "GetRequests"
+  statistic           = "Sum"
+  period              = 60
+  evaluation_periods  = 1
+  threshold           = 0
+  comparison_operator = "GreaterThanThreshold"
+  treat_missing_data  = "notBreaching"
+
+  dimensions = {
+    BucketName = aws_s3_bucket.canary[0].id
+    FilterId   = aws_s3_bucket_metric.canary[0].name
+  }
+
+  alarm_actions = [aws_sns_topic.canary_alerts[0].arn]
+
+  tags = local.common_tags
+}
+
+# ==============================================================================
+# Quarantine Bucket - Incident Response (Conditional)
+# ==============================================================================
+# Destination for objects flagged by Macie/GuardDuty. Object access is denied
+# to every principal except the quarantine security role (and the findings
+# Lambda, when automation is enabled); bucket management stays with account
+# administrators so Terraform can still manage it. Object Lock keeps
+# quarantined evidence from being altered while an incident is investigated.
+
+resource "aws_iam_role" "quarantine" {
+  count = var.create_quarantine_bucket && var.quarantine_role_arn == "" ? 1 : 0
+
+  name        = "${local.full_suffix}-quarantine-role"
+  description = "Security role with exclusive object access to the quarantine bucket"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Principal = {
+          AWS = "arn:${var.partition}:iam::${var.aws_account_id}:root"
+        }
+        Action = "sts:AssumeRole"
+        Condition = {
+          Bool = {
+            "aws:MultiFactorAuthPresent" = "true"
+          }
+        }
+      }
+    ]
+  })
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${local.full_suffix}-quarantine-role"
+    }
+  )
+}
+
+resource "aws_s3_bucket" "quarantine" {
+  count = var.create_quarantine_bucket ? 1 : 0
+
+  bucket              = local.quarantine_bucket_name
+  force_destroy       = var.allow_destroy
+  object_lock_enabled = true
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name    = local.quarantine_bucket_name
+      Purpose = "Quarantined Objects - Incident Response"
+    }
+  )
+}
+
+resource "aws_s3_bucket_server_side_encryption_configuration" "quarantine" {
+  count = var.create_quarantine_bucket ? 1 : 0
+
+  bucket = aws_s3_bucket.quarantine[0].id
+
+  rule {
+    apply_server_side_encryption_by_default {
+      sse_algorithm     = "aws:kms"
+      kms_master_key_id = var.kms_key_id
+    }
+    bucket_key_enabled = true
+  }
+}
+
+resource "aws_s3_bucket_versioning" "quarantine" {
+  count = var.create_quarantine_bucket ? 1 : 0
+
+  bucket = aws_s3_bucket.quarantine[0].id
+
+  versioning_configuration {
+    status = "Enabled"
+  }
+}
+
+resource "aws_s3_bucket_object_lock_configuration" "quarantine" {
+  count = var.create_quarantine_bucket ? 1 : 0
+
+  bucket = aws_s3_bucket.quarantine[0].id
+
+  # Governance mode lets the quarantine role release objects with explicit bypass
+  rule {
+    default_retention {
+      mode = "GOVERNANCE"
+      days = var.quarantine_retention_days
+    }
+  }
+
+  depends_on = [aws_s3_bucket_versioning.quarantine]
+}
+
+resource "aws_s3_bucket_public_access_block" "quarantine" {
+  count = var.create_quarantine_bucket ? 1 : 0
+
+  bucket = aws_s3_bucket.quarantine[0].id
+
+  block_public_acls       = true
+  block_public_policy     = true
+  ignore_public_acls      = true
+  restrict_public_buckets = true
+}
+
+resource "aws_s3_bucket_policy" "quarantine" {
+  count = var.create_quarantine_bucket ? 1 : 0
+
+  bucket = aws_s3_bucket.quarantine[0].id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid       = "DenyObjectAccessExceptQuarantineRole"
+        Effect    = "Deny"
+        Principal = "*"
+        Action = [
+          "s3:GetObject*",
+          "s3:PutObject*",
+          "s3:DeleteObject*",
+          "s3:RestoreObject",
+          "s3:ListBucket*"
+        ]
+        Resource = [
+          aws_s3_bucket.quarantine[0].arn,
+          "${aws_s3_bucket.quarantine[0].arn}/*"
+        ]
+        # Conditions are ANDed: the deny applies unless the caller is either exempt role
+        Condition = merge(
+          {
+            StringNotEquals = {
+              "aws:PrincipalArn" = local.quarantine_role_arn
+            }
+          },
+          local.quarantine_automation_enabled ? {
+            ArnNotEquals = {
+              "aws:PrincipalArn" = aws_iam_role.quarantine_findings[0].arn
+            }
+          } : {}
+        )
+      },
+      {
+        Sid    = "AllowQuarantineRole"
+        Effect = "Allow"
+        Principal = {
+          AWS = local.quarantine_role_arn
+        }
+        Action = [
+          "s3:GetObject*",
+          "s3:PutObject*",
+          "s3:ListBucket*"
+        ]
+        Resource = [
+          aws_s3_bucket.quarantine[0].arn,
+          "${aws_s3_bucket.quarantine[0].arn}/*"
+        ]
+      },
+      {
+        Sid       = "DenyInsecureTransport"
+        Effect    = "Deny"
+        Principal = "*"
+        Action    = "s3:*"
+        Resource = [
+          aws_s3_bucket.quarantine[0].arn,
+          "${aws_s3_bucket.quarantine[0].arn}/*"
+        ]
+        Condition = {
+          Bool = {
+            "aws:SecureTransport" = "false"
+          }
+        }
+      }
+    ]
+  })
+
+  depends_on = [aws_s3_bucket_public_access_block.quarantine]
+}
+
+# ==============================================================================
+# Quarantine Automation - Macie/GuardDuty Findings (Conditional)
+# ==============================================================================
+# High-severity findings trigger a Lambda that moves the flagged object from
+# the documents or backups bucket into quarantine and alerts the security
+# team over SNS. Findings on other buckets are alerted on but not moved.
+
+resource "aws_sns_topic" "quarantine_alerts" {
+  count = local.quarantine_automation_enabled ? 1 : 0
+
+  name              = "${local.full_suffix}-quarantine-alerts"
+  display_name      = "PHI Quarantine Alerts - ${local.full_suffix}"
+  kms_master_key_id = "alias/aws/sns"
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${local.full_suffix}-quarantine-alerts"
+    }
+  )
+}
+
+resource "aws_sns_topic_subscription" "quarantine_email" {
+  count = local.quarantine_automation_enabled && var.quarantine_alert_email != "" ? 1 : 0
+
+  topic_arn = aws_sns_topic.quarantine_alerts[0].arn
+  protocol  = "email"
+  endpoint  = var.quarantine_alert_email
+}
+
+data "archive_file" "quarantine_findings" {
+  count = local.quarantine_automation_enabled ? 1 : 0
+
+  type        = "zip"
+  source_file = "${path.module}/functions/quarantine_findings.py"
+  output_path = "${path.module}/.build/quarantine_findings.zip"
+}
+
+resource "aws_iam_role" "quarantine_findings" {
+  count = local.quarantine_automation_enabled ? 1 : 0
+
+  name        = "${local.full_suffix}-quarantine-findings-role"
+  description = "IAM role for the Macie/GuardDuty quarantine Lambda in ${var.environment}"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Principal = {
+          Service = "lambda.${var.dns_suffix}"
+        }
+        Action = "sts:AssumeRole"
+      }
+    ]
+  })
+
+  tags = local.common_tags
+}
+
+resource "aws_iam_role_policy" "quarantine_findings" {
+  count = local.quarantine_automation_enabled ? 1 : 0
+
+  name = "${local.full_suffix}-quarantine-findings"
+  role = aws_iam_role.quarantine_findings[0].id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid    = "MoveFlaggedObjects"
+        Effect = "Allow"
+        Action = [
+          "s3:GetObject",
+          "s3:GetObjectVersion",
+          "s3:GetObjectTagging",
+          "s3:GetObjectVersionTagging",
+          "s3:DeleteObject",
+          "s3:DeleteObjectVersion"
+        ]
+        Resource = [for bucket in local.quarantine_source_buckets : "arn:${var.partition}:s3:::${bucket}/*"]
+      },
+      {
+        Sid    = "WriteQuarantine"
+        Effect = "Allow"
+        Action = [
+          "s3:PutObject",
+          "s3:PutObjectTagging"
+        ]
+        Resource = "${aws_s3_bucket.quarantine[0].arn}/*"
+      },
+      {
+        Sid    = "UseBucketKey"
+        Effect = "Allow"
+        Action = [
+          "kms:Decrypt",
+          "kms:GenerateDataKey"
+        ]
+        Resource = distinct(concat(local.documents_kms_key_arns, [local.backups_kms_key_arn]))
+      },
+      {
+        Sid      = "PublishAlerts"
+        Effect   = "Allow"
+        Action   = "sns:Publish"
+        Resource = aws_sns_topic.quarantine_alerts[0].arn
+      },
+      {
+        Sid    = "WriteLogs"
+        Effect = "Allow"
+        Action = [
+          "logs:CreateLogGroup",
+          "logs:CreateLogStream",
+          "logs:PutLogEvents"
+        ]
+        Resource = "arn:${var.partition}:logs:${data.aws_region.current.name}:${var.aws_account_id}:log-group:/aws/lambda/${local.full_suffix}-quarantine-findings:*"
+      }
+    ]
+  })
+}
+
+resource "aws_lambda_function" "quarantine_findings" {
+  count = local.quarantine_automation_enabled ? 1 : 0
+
+  function_name    = "${local.full_suffix}-quarantine-findings"
+  description      = "Moves objects flagged by Macie/GuardDuty into ${local.quarantine_bucket_name}"
+  role             = aws_iam_role.quarantine_findings[0].arn
+  runtime          = "python3.12"
+  handler          = "quarantine_findings.handler"
+  filename         = data.archive_file.quarantine_findings[0].output_path
+  source_code_hash = data.archive_file.quarantine_findings[0].output_base64sha256
+  timeout          = 300
+
+  environment {
+    variables = {
+      QUARANTINE_BUCKET      = aws_s3_bucket.quarantine[0].id
+      QUARANTINE_KMS_KEY_ARN = local.kms_key_arn
+      SOURCE_BUCKETS         = join(",", local.quarantine_source_buckets)
+      ALERT_TOPIC_ARN        = aws_sns_topic.quarantine_alerts[0].arn
+    }
+  }
+
+  tags = local.common_tags
+}
+
+resource "aws_cloudwatch_event_rule" "guardduty_findings" {
+  count = local.quarantine_automation_enabled ? 1 : 0
+
+  name        = "${local.full_suffix}-guardduty-high-findings"
+  description = "High-severity GuardDuty findings routed to the quarantine Lambda"
+
+  event_pattern = jsonencode({
+    source      = ["aws.guardduty"]
+    detail-type = ["GuardDuty Finding"]
+    detail = {
+      severity = [{ numeric = [">=", 7] }]
+    }
+  })
+
+  tags = local.common_tags
+}
+
+resource "aws_cloudwatch_event_rule" "macie_findings" {
+  count = local.quarantine_automation_enabled ? 1 : 0
+
+  name        = "${local.full_suffix}-macie-high-findings"
+  description = "High-severity Macie findings routed to the quarantine Lambda"
+
+  event_pattern = jsonencode({
+    source      = ["aws.macie"]
+    detail-type = ["Macie Finding"]
+    detail = {
+      severity = {
+        description = ["High"]
+      }
+    }
+  })
+
+  tags = local.common_tags
+}
+
+resource "aws_cloudwatch_event_target" "guardduty_findings" {
+  count = local.quarantine_automation_enabled ? 1 : 0
+
+  rule = aws_cloudwatch_event_rule.guardduty_findings[0].name
+  arn  = aws_lambda_function.quarantine_findings[0].arn
+}
+
+resource "aws_cloudwatch_event_target" "macie_findings" {
+  count = local.quarantine_automation_enabled ? 1 : 0
+
+  rule = aws_cloudwatch_event_rule.macie_findings[0].name
+  arn  = aws_lambda_function.quarantine_findings[0].arn
+}
+
+resource "aws_lambda_permission" "guardduty_findings" {
+  count = local.quarantine_automation_enabled ? 1 : 0
+
+  statement_id  = "AllowGuardDutyFindingsInvoke"
+  action        = "lambda:InvokeFunction"
+  function_name = aws_lambda_function.quarantine_findings[0].function_name
+  principal     = "events.${var.dns_suffix}"
+  source_arn    = aws_cloudwatch_event_rule.guardduty_findings[0].arn
+}
+
+resource "aws_lambda_permission" "macie_findings" {
+  count = local.quarantine_automation_enabled ? 1 : 0
+
+  statement_id  = "AllowMacieFindingsInvoke"
+  action        = "lambda:InvokeFunction"
+  function_name = aws_lambda_function.quarantine_findings[0].function_name
+  principal     = "events.${var.dns_suffix}"
+  source_arn    = aws_cloudwatch_event_rule.macie_findings[0].arn
+}
+
+# ==============================================================================
+# Cross-Region Replication - Documents Bucket (Conditional)
+# ==============================================================================
+# Replicates PHI documents to a bucket in the aws.replica provider region,
+# re-encrypted with the replica KMS key so the copy is usable in DR.
+
+data "aws_region" "current" {}
+
+data "aws_region" "replica" {
+  count    = var.enable_replication ? 1 : 0
+  provider = aws.replica
+}
+
+resource "aws_s3_bucket" "documents_replica" {
+  count    = var.enable_replication ? 1 : 0
+  provider = aws.replica
+
+  bucket        = local.documents_replica_bucket_name
+  force_destroy = var.allow_destroy
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name    = local.documents_replica_bucket_name
+      Purpose = "PHI Document Storage Replica"
+    }
+  )
+}
+
+resource "aws_s3_bucket_server_side_encryption_configuration" "documents_replica" {
+  count    = var.enable_replication ? 1 : 0
+  provider = aws.replica
+
+  bucket = aws_s3_bucket.documents_replica[0].id
+
+  rule {
+    apply_server_side_encryption_by_default {
+      sse_algorithm     = "aws:kms"
+      kms_master_key_id = var.replica_kms_key_arn
+    }
+    bucket_key_enabled = true
+  }
+}
+
+resource "aws_s3_bucket_versioning" "documents_replica" {
+  count    = var.enable_replication ? 1 : 0
+  provider = aws.replica
+
+  bucket = aws_s3_bucket.documents_replica[0].id
+
+  versioning_configuration {
+    status = "Enabled"
+  }
+}
+
+resource "aws_s3_bucket_public_access_block" "documents_replica" {
+  count    = var.enable_replication ? 1 : 0
+  provider = aws.replica
+
+  bucket = aws_s3_bucket.documents_replica[0].id
+
+  block_public_acls       = true
+  block_public_policy     = true
+  ignore_public_acls      = true
+  restrict_public_buckets = true
+}
+
+# IAM role assumed by S3 to replicate objects
+resource "aws_iam_role" "replication" {
+  count = var.enable_replication ? 1 : 0
+
+  name = "hipaa-s3-replication-${local.full_suffix}"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Principal = {
+          Service = "s3.${var.dns_suffix}"
+        }
+        Action = "sts:AssumeRole"
+      }
+    ]
+  })
+
+  tags = local.common_tags
+}
+
+resource "aws_iam_role_policy" "replication" {
+  count = var.enable_replication ? 1 : 0
+
+  name = "hipaa-s3-replication-${local.full_suffix}"
+  role = aws_iam_role.replication[0].id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid    = "ReadSourceConfiguration"
+        Effect = "Allow"
+        Action = [
+          "s3:GetReplicationConfiguration",
+          "s3:ListBucket"
+        ]
+        Resource = [aws_s3_bucket.documents.arn]
+      },
+      {
+        Sid    = "ReadSourceObjects"
+        Effect = "Allow"
+        Action = [
+          "s3:GetObjectVersionForReplication",
+          "s3:GetObjectVersionAcl",
+          "s3:GetObjectVersionTagging"
+        ]
+        Resource = ["${aws_s3_bucket.documents.arn}/*"]
+      },
+      {
+        Sid    = "WriteReplicaObjects"
+        Effect = "Allow"
+        Action = [
+          "s3:ReplicateObject",
+          "s3:ReplicateDelete",
+          "s3:ReplicateTags"
+        ]
+        Resource = ["${aws_s3_bucket.documents_replica[0].arn}/*"]
+      },
+      {
+        Sid      = "DecryptSourceObjects"
+        Effect   = "Allow"
+        Action   = ["kms:Decrypt"]
+        Resource = "*"
+        Condition = {
+          StringLike = {
+            "kms:ViaService"                   = "s3.${data.aws_region.current.name}.${var.dns_suffix}"
+            "kms:EncryptionContext:aws:s3:arn" = "${aws_s3_bucket.documents.arn}/*"
+          }
+        }
+      },
+      {
+        Sid      = "EncryptReplicaObjects"
+        Effect   = "Allow"
+        Action   = ["kms:Encrypt", "kms:GenerateDataKey"]
+        Resource = [var.replica_kms_key_arn]
+        Condition = {
+          StringLike = {
+            "kms:ViaService"                   = "s3.${data.aws_region.replica[0].name}.${var.dns_suffix}"
+            "kms:EncryptionContext:aws:s3:arn" = "${aws_s3_bucket.documents_replica[0].arn}/*"
+          }
+        }
+      }
+    ]
+  })
+}
+
+resource "aws_s3_bucket_replication_configuration" "documents" {
+  count = var.enable_replication ? 1 : 0
+
+  bucket = aws_s3_bucket.documents.id
+  role   = aws_iam_role.replication[0].arn
+
+  rule {
+    id     = "replicate-documents-to-replica-region"
+    status = "Enabled"
+
+    filter {}
+
+    delete_marker_replication {
+      status = "Enabled"
+    }
+
+    source_selection_criteria {
+      sse_kms_encrypted_objects {
+        status = "Enabled"
+      }
+    }
+
+    destination {
+      bucket        = aws_s3_bucket.documents_replica[0].arn
+      storage_class = "STANDARD"
+
+      encryption_configuration {
+        replica_kms_key_id = var.replica_kms_key_arn
+      }
+    }
+  }
+
+  # Replication requires versioning on both source and destination
+  depends_on = [
+    aws_s3_bucket_versioning.documents,
+    aws_s3_bucket_versioning.documents_replica
+  ]
 }
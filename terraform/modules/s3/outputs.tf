@@ -27,6 +27,11 @@ output "s3_bucket_backups_arn" {
   description = "Backups bucket ARN for IAM policy configuration"
 }
 
+output "backups_kms_key_arn" {
+  value       = local.backups_kms_key_arn
+  description = "KMS key ARN encrypting the backups bucket"
+}
+
 output "s3_bucket_audit_logs_arn" {
   value       = aws_s3_bucket.audit_logs.arn
   description = "Audit logs bucket ARN for IAM policy configuration"
@@ -36,3 +41,92 @@ output "s3_bucket_documents_region" {
   value       = aws_s3_bucket.documents.region
   description = "Documents bucket region"
 }
+
+output "s3_bucket_documents_replica" {
+  value       = var.enable_replication ? aws_s3_bucket.documents_replica[0].id : ""
+  description = "Documents replica bucket name in the replica region (empty if replication disabled)"
+}
+
+output "s3_bucket_documents_replica_region" {
+  value       = var.enable_replication ? aws_s3_bucket.documents_replica[0].region : ""
+  description = "Documents replica bucket region"
+}
+
+output "intelligent_tiering_configuration_id" {
+  value       = var.enable_intelligent_tiering ? aws_s3_bucket_intelligent_tiering_configuration.documents[0].id : ""
+  description = "Intelligent-Tiering configuration ID (bucket:name) on the documents bucket (empty if disabled)"
+}
+
+output "request_metrics_id" {
+  value       = var.enable_request_metrics ? aws_s3_bucket_metric.documents[0].id : ""
+  description = "Request metrics configuration ID (bucket:filter) on the documents bucket (empty if disabled)"
+}
+
+output "eventbridge_notifications" {
+  value = {
+    documents = var.enable_eventbridge_notifications ? aws_s3_bucket_notification.documents[0].eventbridge : false
+    backups   = var.enable_eventbridge_notifications ? aws_s3_bucket_notification.backups[0].eventbridge : false
+  }
+  description = "Whether EventBridge notifications are enabled on the documents and backups buckets"
+}
+
+output "request_error_alarm_names" {
+  value = var.enable_request_metrics ? {
+    documents_4xx = aws_cloudwatch_metric_alarm.documents_4xx[0].alarm_name
+    documents_5xx = aws_cloudwatch_metric_alarm.documents_5xx[0].alarm_name
+  } : {}
+  description = "Map of documents bucket 4xx/5xx alarm names (empty if disabled)"
+}
+
+output "canary_bucket_name" {
+  value       = var.create_canary_bucket ? aws_s3_bucket.canary[0].id : ""
+  description = "Canary bucket name (empty if disabled)"
+}
+
+output "canary_bucket_arn" {
+  value       = var.create_canary_bucket ? aws_s3_bucket.canary[0].arn : ""
+  description = "Canary bucket ARN for CloudTrail data event selectors"
+}
+
+output "canary_alarm_name" {
+  value       = var.create_canary_bucket ? aws_cloudwatch_metric_alarm.canary_access[0].alarm_name : ""
+  description = "CloudWatch alarm that fires on canary bucket reads"
+}
+
+output "canary_alert_topic_arn" {
+  value       = var.create_canary_bucket ? aws_sns_topic.canary_alerts[0].arn : ""
+  description = "SNS topic receiving canary access alerts"
+}
+
+output "quarantine_bucket_name" {
+  value       = var.create_quarantine_bucket ? aws_s3_bucket.quarantine[0].id : ""
+  description = "Quarantine bucket name (empty if disabled)"
+}
+
+output "quarantine_bucket_arn" {
+  value       = var.create_quarantine_bucket ? aws_s3_bucket.quarantine[0].arn : ""
+  description = "Quarantine bucket ARN for incident-response tooling (empty if disabled)"
+}
+
+output "quarantine_role_arn" {
+  value       = var.create_quarantine_bucket ? local.quarantine_role_arn : ""
+  description = "IAM role with exclusive object access to the quarantine bucket (empty if disabled)"
+}
+
+output "quarantine_automation" {
+  value = local.quarantine_automation_enabled ? {
+    lambda_function = aws_lambda_function.quarantine_findings[0].function_name
+    alert_topic_arn = aws_sns_topic.quarantine_alerts[0].arn
+    guardduty_rule  = aws_cloudwatch_event_rule.guardduty_findings[0].name
+    macie_rule      = aws_cloudwatch_event_rule.macie_findings[0].name
+  } : {}
+  description = "Quarantine automation settings: findings Lambda, SNS alert topic, and EventBridge rules (empty if disabled)"
+}
+
+output "documents_kms_keys" {
+  value = {
+    active_key_arn      = local.documents_kms_key_arn
+    authorized_key_arns = local.documents_kms_key_arns
+  }
+  description = "Documents bucket KMS key used for new writes and all keys authorized on the bucket"
+}
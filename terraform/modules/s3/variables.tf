@@ -36,6 +36,50 @@ variable "aws_account_id" {
 variable "kms_key_id" {
   type        = string
   description = "KMS key ID for S3 bucket encryption (SSE-KMS)"
+
+  validation {
+    condition     = can(regex("^((mrk-)?[0-9a-f-]{32,36}|arn:aws[a-z-]*:kms:[a-z0-9-]+:[0-9]{12}:key/.+|alias/.+)$", var.kms_key_id))
+    error_message = "kms_key_id must be a KMS key ID, key ARN or alias; an empty value would leave encryption unconfigured"
+  }
+}
+
+variable "secondary_kms_key_arn" {
+  type        = string
+  description = "Second KMS key ARN authorized on the documents bucket for staged key migration or crypto-shredding (optional)"
+  default     = ""
+
+  validation {
+    condition     = var.secondary_kms_key_arn == "" || can(regex("^arn:aws[a-z-]*:kms:[a-z0-9-]+:[0-9]{12}:key/.+$", var.secondary_kms_key_arn))
+    error_message = "secondary_kms_key_arn must be a valid KMS key ARN"
+  }
+}
+
+variable "backups_kms_key_arn" {
+  type        = string
+  description = "KMS key ARN for the backups bucket when it should not share kms_key_id with documents (empty uses kms_key_id)"
+  default     = ""
+
+  validation {
+    condition     = var.backups_kms_key_arn == "" || can(regex("^arn:aws[a-z-]*:kms:[a-z0-9-]+:[0-9]{12}:key/.+$", var.backups_kms_key_arn))
+    error_message = "backups_kms_key_arn must be a valid KMS key ARN"
+  }
+}
+
+variable "documents_active_kms_key" {
+  type        = string
+  description = "Key used for new documents writes: primary (kms_key_id) or secondary (secondary_kms_key_arn)"
+  default     = "primary"
+
+  validation {
+    condition     = contains(["primary", "secondary"], var.documents_active_kms_key)
+    error_message = "documents_active_kms_key must be primary or secondary"
+  }
+}
+
+variable "deny_unencrypted_uploads" {
+  type        = bool
+  description = "Deny PutObject on the documents, backups and audit logs buckets when the request's encryption headers select anything other than SSE-KMS with an authorized key ARN"
+  default     = false
 }
 
 variable "enable_lifecycle_policies" {
@@ -44,12 +88,188 @@ variable "enable_lifecycle_policies" {
   default     = true
 }
 
+variable "enable_intelligent_tiering" {
+  type        = bool
+  description = "Move documents into S3 Intelligent-Tiering with archive access tiers instead of the fixed IA/Glacier lifecycle transitions"
+  default     = false
+}
+
+variable "intelligent_tiering_archive_days" {
+  type        = number
+  description = "Days without access before documents move to the Archive Access tier"
+  default     = 90
+
+  validation {
+    condition     = var.intelligent_tiering_archive_days >= 90 && var.intelligent_tiering_archive_days <= 730
+    error_message = "intelligent_tiering_archive_days must be between 90 and 730"
+  }
+}
+
+variable "intelligent_tiering_deep_archive_days" {
+  type        = number
+  description = "Days without access before documents move to the Deep Archive Access tier"
+  default     = 180
+
+  validation {
+    condition     = var.intelligent_tiering_deep_archive_days >= 180 && var.intelligent_tiering_deep_archive_days <= 730
+    error_message = "intelligent_tiering_deep_archive_days must be between 180 and 730"
+  }
+}
+
+variable "enable_transfer_acceleration" {
+  type        = bool
+  description = "Transfer Acceleration on the PHI buckets; must stay false because accelerated transfers enter through public edge locations"
+  default     = false
+
+  validation {
+    condition     = !var.enable_transfer_acceleration
+    error_message = "enable_transfer_acceleration must be false: accelerated transfers route PHI through public CloudFront edge locations instead of the VPC endpoint path"
+  }
+}
+
 variable "documents_bucket_name" {
   type        = string
   description = "Override default documents bucket name (optional, defaults to hipaa-compliant-docs-{environment}-{account-id})"
   default     = ""
 }
 
+variable "enable_replication" {
+  type        = bool
+  description = "Replicate the documents bucket to a bucket in the aws.replica provider region"
+  default     = false
+}
+
+variable "replica_kms_key_arn" {
+  type        = string
+  description = "KMS key ARN in the replica region used to encrypt replicated objects (required when enable_replication is true)"
+  default     = ""
+
+  validation {
+    condition     = var.replica_kms_key_arn == "" || can(regex("^arn:aws[a-z-]*:kms:[a-z0-9-]+:[0-9]{12}:key/.+$", var.replica_kms_key_arn))
+    error_message = "replica_kms_key_arn must be a valid KMS key ARN"
+  }
+}
+
+variable "create_canary_bucket" {
+  type        = bool
+  description = "Create a canary bucket that alarms on any GetObject (tripwire for compromised credentials)"
+  default     = false
+}
+
+variable "canary_alert_email" {
+  type        = string
+  description = "Email address subscribed to canary access alerts (optional)"
+  default     = ""
+}
+
+variable "enable_request_metrics" {
+  type        = bool
+  description = "Enable CloudWatch request metrics on the documents bucket with alarms on elevated 4xx/5xx responses"
+  default     = false
+}
+
+variable "request_4xx_alarm_threshold" {
+  type        = number
+  description = "4xx responses per 5 minutes on the documents bucket above which the alarm fires"
+  default     = 50
+
+  validation {
+    condition     = var.request_4xx_alarm_threshold >= 0
+    error_message = "request_4xx_alarm_threshold must not be negative"
+  }
+}
+
+variable "request_5xx_alarm_threshold" {
+  type        = number
+  description = "5xx responses per 5 minutes on the documents bucket above which the alarm fires"
+  default     = 10
+
+  validation {
+    condition     = var.request_5xx_alarm_threshold >= 0
+    error_message = "request_5xx_alarm_threshold must not be negative"
+  }
+}
+
+variable "request_alarm_actions" {
+  type        = list(string)
+  description = "SNS topic ARNs notified by the documents bucket request error alarms"
+  default     = []
+}
+
+variable "enable_eventbridge_notifications" {
+  type        = bool
+  description = "Send every object create, delete and restore event on the documents and backups buckets to EventBridge for the audit pipeline"
+  default     = false
+}
+
+variable "create_quarantine_bucket" {
+  type        = bool
+  description = "Create a quarantine bucket for objects flagged by Macie/GuardDuty, accessible only to the quarantine role"
+  default     = false
+}
+
+variable "quarantine_role_arn" {
+  type        = string
+  description = "IAM role granted exclusive object access to the quarantine bucket (empty creates an MFA-protected role)"
+  default     = ""
+
+  validation {
+    condition     = var.quarantine_role_arn == "" || can(regex("^arn:aws[a-z-]*:iam::[0-9]{12}:role/.+$", var.quarantine_role_arn))
+    error_message = "quarantine_role_arn must be an IAM role ARN"
+  }
+}
+
+variable "quarantine_retention_days" {
+  type        = number
+  description = "Object Lock governance retention applied to quarantined objects (days)"
+  default     = 365
+
+  validation {
+    condition     = var.quarantine_retention_days >= 1
+    error_message = "quarantine_retention_days must be at least 1"
+  }
+}
+
+variable "enable_quarantine_automation" {
+  type        = bool
+  description = "Deploy a Lambda that moves objects flagged by high-severity Macie/GuardDuty findings into the quarantine bucket (requires create_quarantine_bucket)"
+  default     = false
+}
+
+variable "quarantine_alert_email" {
+  type        = string
+  description = "Email address subscribed to quarantine alerts (optional)"
+  default     = ""
+}
+
+variable "allow_destroy" {
+  type        = bool
+  description = "Test teardown escape hatch: let terraform destroy delete non-empty buckets (never enable in production)"
+  default     = false
+}
+
+variable "partition" {
+  type        = string
+  description = "AWS partition used to build ARNs (aws, aws-us-gov or aws-cn)"
+  default     = "aws"
+
+  validation {
+    condition     = contains(["aws", "aws-us-gov", "aws-cn"], var.partition)
+    error_message = "partition must be aws, aws-us-gov or aws-cn"
+  }
+}
+
+variable "dns_suffix" {
+  type        = string
+  description = "DNS suffix of the partition used to build service principals (amazonaws.com.cn in China)"
+  default     = "amazonaws.com"
+
+  validation {
+    condition     = contains(["amazonaws.com", "amazonaws.com.cn"], var.dns_suffix)
+    error_message = "dns_suffix must be amazonaws.com or amazonaws.com.cn"
+  }
+}
+
 variable "tags" {
   type        = map(string)
   description = "Additional resource tags to apply to all S3 buckets"
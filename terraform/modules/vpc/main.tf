@@ -2,7 +2,8 @@
 # VPC Module - Main Configuration
 # ==============================================================================
 # This module provisions VPC, subnets, routing, NAT gateways, Internet Gateway,
-# and VPC endpoints for secure, multi-AZ AWS infrastructure
+# VPC endpoints and an optional egress firewall for secure, multi-AZ AWS
+# infrastructure
 # ==============================================================================
 
 locals {
@@ -11,8 +12,70 @@ locals {
   full_suffix = var.name_suffix == "" ? local.env_label : "${local.env_label}-${var.name_suffix}"
 
   # Calculate subnet CIDRs dynamically
-  public_subnet_cidrs  = [for i in range(3) : cidrsubnet(var.vpc_cidr, 8, i)]
-  private_subnet_cidrs = [for i in range(3) : cidrsubnet(var.vpc_cidr, 8, i + 10)]
+  public_subnet_cidrs   = [for i in range(3) : cidrsubnet(var.vpc_cidr, 8, i)]
+  private_subnet_cidrs  = [for i in range(3) : cidrsubnet(var.vpc_cidr, 8, i + 10)]
+  firewall_subnet_cidrs = [for i in range(3) : cidrsubnet(var.vpc_cidr, 8, i + 20)]
+
+  # Dedicated interface endpoint subnets are carved out of the next unused /24
+  # block so endpoint ENIs don't take addresses from the busy private subnets
+  endpoint_subnets_enabled = var.endpoint_subnet_newbits > 0
+  endpoint_subnet_cidrs = [
+    for i in range(local.endpoint_subnets_enabled ? 3 : 0) :
+    cidrsubnet(cidrsubnet(var.vpc_cidr, 8, 30), var.endpoint_subnet_newbits - 8, i)
+  ]
+
+  # Zones that get billable per-AZ infrastructure (NAT gateways, firewall
+  # endpoints, interface endpoint ENIs). Subnets stay in all three zones: they
+  # cost nothing and RDS subnet groups must span two AZs. In single-AZ mode
+  # every private subnet egresses through the first zone.
+  active_az_count = var.single_az_mode ? 1 : 3
+  active_azs      = slice(var.availability_zones, 0, local.active_az_count)
+  egress_az_index = [for i in range(3) : var.single_az_mode ? 0 : i]
+
+  # nat_gateway_mode takes precedence over enable_nat_gateway; left empty, the
+  # older flag picks between per_az and none. With no NAT the private route
+  # tables get no default route and AWS APIs are reached only through the VPC
+  # endpoints.
+  nat_gateway_mode  = var.nat_gateway_mode != "" ? var.nat_gateway_mode : (var.enable_nat_gateway ? "per_az" : "none")
+  nat_gateway_count = local.nat_gateway_mode == "none" ? 0 : (local.nat_gateway_mode == "single" ? 1 : local.active_az_count)
+  nat_az_index      = [for i in range(3) : local.nat_gateway_count == 1 ? 0 : i]
+
+  private_subnets_have_internet_egress = local.nat_gateway_count > 0
+
+  interface_endpoint_security_group_ids = length(var.endpoint_security_group_ids) > 0 ? var.endpoint_security_group_ids : aws_security_group.vpc_endpoints[*].id
+
+  interface_endpoint_subnet_ids = slice(
+    local.endpoint_subnets_enabled ? aws_subnet.endpoint[*].id : aws_subnet.private[*].id,
+    0,
+    local.active_az_count
+  )
+
+  # Endpoint service names differ by partition: China interface endpoints use
+  # the reversed DNS suffix (cn.com.amazonaws), while the S3 gateway endpoint
+  # keeps com.amazonaws everywhere
+  interface_endpoint_prefix = join(".", reverse(split(".", var.dns_suffix)))
+
+  default_vpc_present = var.detect_default_vpc ? length(data.aws_vpcs.default[0].ids) > 0 : false
+
+  # Firewall endpoint per AZ, so each private subnet inspects through its own zone
+  firewall_endpoint_ids = var.enable_network_firewall ? {
+    for state in aws_networkfirewall_firewall.main[0].firewall_status[0].sync_states :
+    state.availability_zone => state.attachment[0].endpoint_id
+  } : {}
+
+  endpoint_service_names = {
+    s3      = "com.amazonaws.${data.aws_region.current.name}.s3"
+    rds     = "${local.interface_endpoint_prefix}.${data.aws_region.current.name}.rds"
+    bedrock = "${local.interface_endpoint_prefix}.${data.aws_region.current.name}.bedrock-runtime"
+  }
+
+  # Endpoint resources keyed like endpoint_service_names; each list is empty
+  # when enable_vpc_endpoints is off
+  vpc_endpoint_resources = {
+    s3      = aws_vpc_endpoint.s3
+    rds     = aws_vpc_endpoint.rds
+    bedrock = aws_vpc_endpoint.bedrock
+  }
 
   # Common tags for all resources
   common_tags = merge(
@@ -41,6 +104,14 @@ resource "aws_vpc" "main" {
       Name = "hipaa-compliant-vpc-${local.full_suffix}"
     }
   )
+
+  lifecycle {
+    # One AZ is a single point of failure for PHI egress and the database
+    precondition {
+      condition     = !(var.single_az_mode && var.environment == "production")
+      error_message = "single_az_mode is for cost-sensitive non-production environments; production must keep NAT gateways and the database in every Availability Zone."
+    }
+  }
 }
 
 # ==============================================================================
@@ -58,7 +129,7 @@ resource "aws_subnet" "public" {
     local.common_tags,
     {
       Name = "hipaa-public-subnet-${var.environment}-${count.index + 1}"
-      Tier = "Public"
+      Tier = "public"
       AZ   = var.availability_zones[count.index]
     }
   )
@@ -78,7 +149,29 @@ resource "aws_subnet" "private" {
     local.common_tags,
     {
       Name = "hipaa-private-subnet-${var.environment}-${count.index + 1}"
-      Tier = "Private"
+      Tier = "private"
+      AZ   = var.availability_zones[count.index]
+    }
+  )
+}
+
+# ==============================================================================
+# Endpoint Subnets (optional, for interface endpoint ENIs only)
+# ==============================================================================
+# Interface endpoints only need the VPC local route, so these subnets stay on
+# the main route table and have no path to NAT or the internet
+
+resource "aws_subnet" "endpoint" {
+  count             = local.endpoint_subnets_enabled ? 3 : 0
+  vpc_id            = aws_vpc.main.id
+  cidr_block        = local.endpoint_subnet_cidrs[count.index]
+  availability_zone = var.availability_zones[count.index]
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "hipaa-endpoint-subnet-${var.environment}-${count.index + 1}"
+      Tier = "endpoint"
       AZ   = var.availability_zones[count.index]
     }
   )
@@ -104,13 +197,13 @@ resource "aws_internet_gateway" "main" {
 # ==============================================================================
 
 resource "aws_eip" "nat" {
-  count  = var.enable_nat_gateway ? 3 : 0
+  count  = local.nat_gateway_count
   domain = "vpc"
 
   tags = merge(
     local.common_tags,
     {
-      Name = "hipaa-nat-eip-${var.environment}-${count.index + 1}"
+      Name = "hipaa-nat-eip-${local.full_suffix}-${count.index + 1}"
     }
   )
 
@@ -118,18 +211,19 @@ resource "aws_eip" "nat" {
 }
 
 # ==============================================================================
-# NAT Gateways (one per AZ for high availability)
+# NAT Gateways (one per AZ for high availability, one in single mode or
+# single-AZ mode, none when private subnets rely on VPC endpoints alone)
 # ==============================================================================
 
 resource "aws_nat_gateway" "main" {
-  count         = var.enable_nat_gateway ? 3 : 0
+  count         = local.nat_gateway_count
   allocation_id = aws_eip.nat[count.index].id
   subnet_id     = aws_subnet.public[count.index].id
 
   tags = merge(
     local.common_tags,
     {
-      Name = "hipaa-nat-gw-${var.environment}-${count.index + 1}"
+      Name = "hipaa-nat-gw-${local.full_suffix}-${count.index + 1}"
       AZ   = var.availability_zones[count.index]
     }
   )
@@ -148,7 +242,7 @@ resource "aws_route_table" "public" {
     local.common_tags,
     {
       Name = "hipaa-public-rt-${var.environment}"
-      Tier = "Public"
+      Tier = "public"
     }
   )
 }
@@ -177,17 +271,21 @@ resource "aws_route_table" "private" {
     local.common_tags,
     {
       Name = "hipaa-private-rt-${var.environment}-${count.index + 1}"
-      Tier = "Private"
+      Tier = "private"
       AZ   = var.availability_zones[count.index]
     }
   )
 }
 
+# With the network firewall enabled, private egress goes to the firewall
+# endpoint first and only reaches the NAT gateway once inspected. In
+# nat_gateway_mode = "none" there is no default route at all.
 resource "aws_route" "private_nat" {
-  count                  = var.enable_nat_gateway ? 3 : 0
+  count                  = local.private_subnets_have_internet_egress ? 3 : 0
   route_table_id         = aws_route_table.private[count.index].id
   destination_cidr_block = "0.0.0.0/0"
-  nat_gateway_id         = aws_nat_gateway.main[count.index].id
+  nat_gateway_id         = var.enable_network_firewall ? null : aws_nat_gateway.main[local.nat_az_index[count.index]].id
+  vpc_endpoint_id        = var.enable_network_firewall ? local.firewall_endpoint_ids[var.availability_zones[local.egress_az_index[count.index]]] : null
 }
 
 resource "aws_route_table_association" "private" {
@@ -196,6 +294,156 @@ resource "aws_route_table_association" "private" {
   route_table_id = aws_route_table.private[count.index].id
 }
 
+# ==============================================================================
+# Network Firewall - AWS API Egress Allow-List
+# ==============================================================================
+# Services without an interface endpoint in the region are reached through the
+# firewall instead of open NAT egress. The stateful rule group allows TLS SNI
+# and HTTP Host values from allowed_aws_service_domains and drops every other
+# established flow. Traffic path: private subnet -> firewall endpoint (same AZ)
+# -> NAT gateway -> internet, with public subnet return routes pointing back at
+# the firewall so both directions are inspected.
+# ==============================================================================
+
+resource "aws_subnet" "firewall" {
+  count             = var.enable_network_firewall ? local.active_az_count : 0
+  vpc_id            = aws_vpc.main.id
+  cidr_block        = local.firewall_subnet_cidrs[count.index]
+  availability_zone = var.availability_zones[count.index]
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "hipaa-firewall-subnet-${var.environment}-${count.index + 1}"
+      Tier = "firewall"
+      AZ   = var.availability_zones[count.index]
+    }
+  )
+}
+
+resource "aws_networkfirewall_rule_group" "aws_api_egress" {
+  count    = var.enable_network_firewall ? 1 : 0
+  name     = "hipaa-aws-api-egress-${local.full_suffix}"
+  type     = "STATEFUL"
+  capacity = 100
+
+  rule_group {
+    rules_source {
+      rules_source_list {
+        generated_rules_type = "ALLOWLIST"
+        target_types         = ["TLS_SNI", "HTTP_HOST"]
+        targets              = var.allowed_aws_service_domains
+      }
+    }
+
+    stateful_rule_options {
+      rule_order = "STRICT_ORDER"
+    }
+  }
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "hipaa-aws-api-egress-${local.full_suffix}"
+    }
+  )
+}
+
+resource "aws_networkfirewall_firewall_policy" "main" {
+  count = var.enable_network_firewall ? 1 : 0
+  name  = "hipaa-egress-policy-${local.full_suffix}"
+
+  firewall_policy {
+    stateless_default_actions          = ["aws:forward_to_sfe"]
+    stateless_fragment_default_actions = ["aws:forward_to_sfe"]
+
+    # drop_established still lets the TCP handshake through so the SNI or
+    # Host header can be matched before anything else is dropped
+    stateful_default_actions = ["aws:drop_established", "aws:alert_established"]
+
+    stateful_engine_options {
+      rule_order = "STRICT_ORDER"
+    }
+
+    stateful_rule_group_reference {
+      priority     = 100
+      resource_arn = aws_networkfirewall_rule_group.aws_api_egress[0].arn
+    }
+  }
+
+  tags = local.common_tags
+}
+
+resource "aws_networkfirewall_firewall" "main" {
+  count               = var.enable_network_firewall ? 1 : 0
+  name                = "hipaa-egress-firewall-${local.full_suffix}"
+  firewall_policy_arn = aws_networkfirewall_firewall_policy.main[0].arn
+  vpc_id              = aws_vpc.main.id
+
+  dynamic "subnet_mapping" {
+    for_each = aws_subnet.firewall[*].id
+    content {
+      subnet_id = subnet_mapping.value
+    }
+  }
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "hipaa-egress-firewall-${local.full_suffix}"
+    }
+  )
+
+  lifecycle {
+    precondition {
+      condition     = local.private_subnets_have_internet_egress
+      error_message = "enable_network_firewall requires a NAT gateway (nat_gateway_mode other than none); the firewall forwards allowed traffic to the NAT gateways."
+    }
+
+    precondition {
+      condition     = length(var.allowed_aws_service_domains) > 0
+      error_message = "enable_network_firewall requires at least one entry in allowed_aws_service_domains; an empty allow-list drops all egress."
+    }
+  }
+}
+
+resource "aws_route_table" "firewall" {
+  count  = var.enable_network_firewall ? local.active_az_count : 0
+  vpc_id = aws_vpc.main.id
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "hipaa-firewall-rt-${var.environment}-${count.index + 1}"
+      Tier = "firewall"
+      AZ   = var.availability_zones[count.index]
+    }
+  )
+}
+
+resource "aws_route" "firewall_nat" {
+  count                  = var.enable_network_firewall ? local.active_az_count : 0
+  route_table_id         = aws_route_table.firewall[count.index].id
+  destination_cidr_block = "0.0.0.0/0"
+  nat_gateway_id         = aws_nat_gateway.main[local.nat_az_index[count.index]].id
+}
+
+resource "aws_route_table_association" "firewall" {
+  count          = var.enable_network_firewall ? local.active_az_count : 0
+  subnet_id      = aws_subnet.firewall[count.index].id
+  route_table_id = aws_route_table.firewall[count.index].id
+}
+
+# Return traffic from the NAT gateways to each private subnet goes back
+# through the firewall endpoint that subnet egresses through so the stateful
+# engine sees both sides
+resource "aws_route" "public_return_via_firewall" {
+  count                  = var.enable_network_firewall ? 3 : 0
+  route_table_id         = aws_route_table.public.id
+  destination_cidr_block = local.private_subnet_cidrs[count.index]
+  vpc_endpoint_id        = local.firewall_endpoint_ids[var.availability_zones[local.egress_az_index[count.index]]]
+}
+
 # ==============================================================================
 # VPC Endpoints - Gateway Endpoint for S3
 # ==============================================================================
@@ -203,7 +451,7 @@ resource "aws_route_table_association" "private" {
 resource "aws_vpc_endpoint" "s3" {
   count        = var.enable_vpc_endpoints ? 1 : 0
   vpc_id       = aws_vpc.main.id
-  service_name = "com.amazonaws.${data.aws_region.current.name}.s3"
+  service_name = local.endpoint_service_names["s3"]
 
   tags = merge(
     local.common_tags,
@@ -223,9 +471,11 @@ resource "aws_vpc_endpoint_route_table_association" "s3_private" {
 # VPC Endpoints - Interface Endpoints
 # ==============================================================================
 
-# Security group for interface endpoints
+# Fallback security group for interface endpoints when endpoint_security_group_ids
+# is empty. It admits HTTPS from anything in the VPC; the root module passes the
+# networking module's group instead, which only admits the app security group.
 resource "aws_security_group" "vpc_endpoints" {
-  count       = var.enable_vpc_endpoints ? 1 : 0
+  count       = var.enable_vpc_endpoints && length(var.endpoint_security_group_ids) == 0 ? 1 : 0
   name        = "hipaa-vpc-endpoints-sg-${var.environment}"
   description = "Security group for VPC interface endpoints"
   vpc_id      = aws_vpc.main.id
@@ -258,11 +508,11 @@ resource "aws_security_group" "vpc_endpoints" {
 resource "aws_vpc_endpoint" "rds" {
   count               = var.enable_vpc_endpoints ? 1 : 0
   vpc_id              = aws_vpc.main.id
-  service_name        = "com.amazonaws.${data.aws_region.current.name}.rds"
+  service_name        = local.endpoint_service_names["rds"]
   vpc_endpoint_type   = "Interface"
-  subnet_ids          = aws_subnet.private[*].id
-  security_group_ids  = [aws_security_group.vpc_endpoints[0].id]
-  private_dns_enabled = true
+  subnet_ids          = local.interface_endpoint_subnet_ids
+  security_group_ids  = local.interface_endpoint_security_group_ids
+  private_dns_enabled = var.endpoint_private_dns_enabled
 
   tags = merge(
     local.common_tags,
@@ -276,11 +526,11 @@ resource "aws_vpc_endpoint" "rds" {
 resource "aws_vpc_endpoint" "bedrock" {
   count               = var.enable_vpc_endpoints ? 1 : 0
   vpc_id              = aws_vpc.main.id
-  service_name        = "com.amazonaws.${data.aws_region.current.name}.bedrock-runtime"
+  service_name        = local.endpoint_service_names["bedrock"]
   vpc_endpoint_type   = "Interface"
-  subnet_ids          = aws_subnet.private[*].id
-  security_group_ids  = [aws_security_group.vpc_endpoints[0].id]
-  private_dns_enabled = true
+  subnet_ids          = local.interface_endpoint_subnet_ids
+  security_group_ids  = local.interface_endpoint_security_group_ids
+  private_dns_enabled = var.endpoint_private_dns_enabled
 
   tags = merge(
     local.common_tags,
@@ -295,3 +545,28 @@ resource "aws_vpc_endpoint" "bedrock" {
 # ==============================================================================
 
 data "aws_region" "current" {}
+
+# The default VPC has open default security groups and public subnets; it is
+# not managed here, only reported so operators can delete it
+data "aws_vpcs" "default" {
+  count = var.detect_default_vpc ? 1 : 0
+
+  filter {
+    name   = "isDefault"
+    values = ["true"]
+  }
+}
+
+check "private_subnets_reach_aws_apis" {
+  assert {
+    condition     = local.private_subnets_have_internet_egress || var.enable_vpc_endpoints
+    error_message = "nat_gateway_mode is none and enable_vpc_endpoints is false, so private subnets cannot reach S3, RDS or Bedrock APIs."
+  }
+}
+
+check "default_vpc_absent" {
+  assert {
+    condition     = !local.default_vpc_present
+    error_message = "The default VPC still exists in ${data.aws_region.current.name}. Auditors flag it in accounts holding PHI; delete it once nothing depends on it."
+  }
+}
@@ -13,6 +13,11 @@ output "private_subnet_ids" {
   description = "Private subnet IDs for RDS and app endpoints"
 }
 
+output "active_availability_zones" {
+  value       = local.active_azs
+  description = "Zones holding NAT gateways, firewall endpoints and interface endpoint ENIs (only the first zone in single_az_mode)"
+}
+
 output "public_subnet_ids" {
   value       = aws_subnet.public[*].id
   description = "Public subnet IDs for NAT gateways"
@@ -33,11 +38,36 @@ output "vpc_endpoint_bedrock_id" {
   description = "Bedrock VPC endpoint ID"
 }
 
+output "vpc_endpoints" {
+  value = flatten([
+    for name, endpoints in local.vpc_endpoint_resources : [
+      for endpoint in endpoints : {
+        name                = name
+        id                  = endpoint.id
+        service_name        = endpoint.service_name
+        type                = lower(endpoint.vpc_endpoint_type)
+        private_dns_enabled = endpoint.private_dns_enabled
+      }
+    ]
+  ])
+  description = "Every enabled VPC endpoint with its name, ID, service name, type (gateway or interface) and private DNS flag"
+}
+
 output "nat_gateway_ids" {
   value       = aws_nat_gateway.main[*].id
   description = "NAT Gateway IDs"
 }
 
+output "nat_gateway_eips" {
+  value       = aws_eip.nat[*].public_ip
+  description = "Elastic IPs of the NAT gateways, the stable egress addresses for partner allowlists (empty if NAT disabled)"
+}
+
+output "private_subnets_have_internet_egress" {
+  value       = local.private_subnets_have_internet_egress
+  description = "Whether private route tables have a default route through a NAT gateway (false in nat_gateway_mode none, where only VPC endpoints are reachable)"
+}
+
 output "internet_gateway_id" {
   value       = aws_internet_gateway.main.id
   description = "Internet Gateway ID"
@@ -52,3 +82,33 @@ output "public_route_table_id" {
   value       = aws_route_table.public.id
   description = "Public route table ID"
 }
+
+output "default_vpc_present" {
+  value       = local.default_vpc_present
+  description = "Whether the region still has a default VPC (always false when detect_default_vpc is off)"
+}
+
+output "endpoint_service_names" {
+  value       = local.endpoint_service_names
+  description = "Partition-aware VPC endpoint service names keyed by s3, rds and bedrock"
+}
+
+output "network_firewall_arn" {
+  value       = var.enable_network_firewall ? aws_networkfirewall_firewall.main[0].arn : ""
+  description = "Egress network firewall ARN (empty if disabled)"
+}
+
+output "network_firewall_rule_group_arn" {
+  value       = var.enable_network_firewall ? aws_networkfirewall_rule_group.aws_api_egress[0].arn : ""
+  description = "Stateful rule group ARN holding the allowed AWS service domains (empty if the firewall is disabled)"
+}
+
+output "firewall_subnet_ids" {
+  value       = aws_subnet.firewall[*].id
+  description = "Firewall subnet IDs, one per AZ (empty if the firewall is disabled)"
+}
+
+output "endpoint_subnet_ids" {
+  value       = aws_subnet.endpoint[*].id
+  description = "Dedicated interface endpoint subnet IDs (empty when endpoint_subnet_newbits is 0)"
+}
@@ -30,10 +30,27 @@ variable "availability_zones" {
   description = "Availability zones for multi-AZ deployment"
 }
 
+variable "single_az_mode" {
+  type        = bool
+  default     = false
+  description = "Cost-sensitive dev/staging: place the NAT gateway, firewall endpoint and interface endpoint ENIs in the first availability zone only. Subnets stay in every zone. Rejected for production"
+}
+
 variable "enable_nat_gateway" {
   type        = bool
   default     = true
-  description = "Enable NAT gateway for private subnet internet access"
+  description = "Enable NAT gateway for private subnet internet access (ignored when nat_gateway_mode is set)"
+}
+
+variable "nat_gateway_mode" {
+  type        = string
+  default     = ""
+  description = "NAT gateways for private subnet egress: per_az, single (one shared gateway) or none (no default route; AWS APIs only through VPC endpoints). Empty follows enable_nat_gateway"
+
+  validation {
+    condition     = contains(["", "per_az", "single", "none"], var.nat_gateway_mode)
+    error_message = "nat_gateway_mode must be per_az, single, none or empty."
+  }
 }
 
 variable "enable_vpc_endpoints" {
@@ -42,6 +59,71 @@ variable "enable_vpc_endpoints" {
   description = "Enable VPC endpoints for S3, RDS, Bedrock"
 }
 
+variable "endpoint_private_dns_enabled" {
+  type        = bool
+  default     = true
+  description = "Enable private DNS on the RDS and Bedrock interface endpoints so default service hostnames resolve to the endpoint"
+}
+
+variable "endpoint_security_group_ids" {
+  type        = list(string)
+  default     = []
+  description = "Security groups for the RDS and Bedrock interface endpoints, such as the networking module's app-only endpoint group (empty creates a group allowing HTTPS from the whole VPC CIDR)"
+}
+
+variable "endpoint_subnet_newbits" {
+  type        = number
+  default     = 0
+  description = "Bits added to vpc_cidr for dedicated interface endpoint subnets carved from the unused 31st /24 block (0 keeps endpoints in the private subnets; 9-12 gives /25-/28 with a /16 VPC)"
+
+  validation {
+    condition     = var.endpoint_subnet_newbits == 0 || (var.endpoint_subnet_newbits >= 9 && var.endpoint_subnet_newbits <= 12)
+    error_message = "endpoint_subnet_newbits must be 0 (disabled) or between 9 and 12 so three endpoint subnets fit in one /24 block."
+  }
+}
+
+variable "detect_default_vpc" {
+  type        = bool
+  default     = true
+  description = "Look up the region's default VPC and warn when it exists, since auditors flag it in PHI accounts"
+}
+
+variable "enable_network_firewall" {
+  type        = bool
+  default     = false
+  description = "Route private subnet egress through an AWS Network Firewall that only allows allowed_aws_service_domains, instead of open NAT egress (requires enable_nat_gateway)"
+}
+
+variable "allowed_aws_service_domains" {
+  type        = list(string)
+  default     = []
+  description = "AWS API domains the network firewall allows for services without an interface endpoint in the region (a leading dot matches subdomains, e.g. .textract.us-east-1.amazonaws.com)"
+
+  validation {
+    condition = alltrue([
+      for domain in var.allowed_aws_service_domains :
+      can(regex("^\\.?([a-z0-9-]+\\.)+[a-z]{2,}$", domain))
+    ])
+    error_message = "allowed_aws_service_domains entries must be lowercase domain names, optionally with a leading dot."
+  }
+
+  validation {
+    condition     = length(var.allowed_aws_service_domains) <= 50
+    error_message = "allowed_aws_service_domains is limited to 50 domains by the rule group capacity."
+  }
+}
+
+variable "dns_suffix" {
+  type        = string
+  description = "DNS suffix of the partition used to build interface endpoint service names (amazonaws.com.cn in China)"
+  default     = "amazonaws.com"
+
+  validation {
+    condition     = contains(["amazonaws.com", "amazonaws.com.cn"], var.dns_suffix)
+    error_message = "dns_suffix must be amazonaws.com or amazonaws.com.cn"
+  }
+}
+
 variable "tags" {
   type        = map(string)
   default     = {}
@@ -0,0 +1,111 @@
+# ==============================================================================
+# WAF Module - Railway Egress Allow-List
+# ==============================================================================
+# Purpose: Pin the source IPs allowed to reach protected endpoints in one
+# place: an IP set of Railway egress ranges referenced by an allow rule, with
+# every other request blocked by the web ACL's default action
+# Dependencies: None (protected resources are associated by ARN)
+# ==============================================================================
+
+locals {
+  # Construct environment label with optional suffix for test isolation
+  env_label   = var.environment
+  full_suffix = var.name_suffix == "" ? local.env_label : "${local.env_label}-${var.name_suffix}"
+
+  web_acl_name = "hipaa-railway-allowlist-${local.full_suffix}"
+
+  # CloudWatch metric names may not contain hyphens
+  metric_prefix = replace(local.web_acl_name, "-", "")
+
+  common_tags = merge(
+    var.tags,
+    {
+      Module      = "waf"
+      Environment = var.environment
+      Context     = var.name_suffix
+      ManagedBy   = "Terraform"
+    }
+  )
+}
+
+# ------------------------------------------------------------------------------
+# Railway Egress IP Set
+# ------------------------------------------------------------------------------
+# Single source of truth for Railway source addresses; an empty list blocks
+# all traffic to protected endpoints rather than opening them up.
+
+resource "aws_wafv2_ip_set" "railway" {
+  name               = "hipaa-railway-egress-${local.full_suffix}"
+  description        = "Railway egress ranges allowed to reach protected endpoints"
+  scope              = var.scope
+  ip_address_version = "IPV4"
+  addresses          = var.railway_ip_ranges
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "hipaa-railway-egress-${local.full_suffix}"
+    }
+  )
+}
+
+# ------------------------------------------------------------------------------
+# Web ACL (Default Block)
+# ------------------------------------------------------------------------------
+
+resource "aws_wafv2_web_acl" "main" {
+  name        = local.web_acl_name
+  description = "Allows only Railway egress ranges to protected endpoints"
+  scope       = var.scope
+
+  default_action {
+    block {}
+  }
+
+  rule {
+    name     = "allow-railway-egress"
+    priority = 0
+
+    action {
+      allow {}
+    }
+
+    statement {
+      ip_set_reference_statement {
+        arn = aws_wafv2_ip_set.railway.arn
+      }
+    }
+
+    visibility_config {
+      cloudwatch_metrics_enabled = true
+      metric_name                = "${local.metric_prefix}RailwayAllowed"
+      sampled_requests_enabled   = true
+    }
+  }
+
+  visibility_config {
+    cloudwatch_metrics_enabled = true
+    metric_name                = "${local.metric_prefix}Blocked"
+    sampled_requests_enabled   = true
+  }
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = local.web_acl_name
+    }
+  )
+}
+
+# ------------------------------------------------------------------------------
+# Protected Resource Associations
+# ------------------------------------------------------------------------------
+# CloudFront distributions reference the web ACL from the distribution itself,
+# so associations only apply to REGIONAL scope.
+
+resource "aws_wafv2_web_acl_association" "protected" {
+  count = var.scope == "REGIONAL" ? length(var.protected_resource_arns) : 0
+
+  resource_arn = var.protected_resource_arns[count.index]
+  web_acl_arn  = aws_wafv2_web_acl.main.arn
+}
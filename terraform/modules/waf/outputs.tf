@@ -0,0 +1,18 @@
+# ==============================================================================
+# WAF Module - Output Values
+# ==============================================================================
+
+output "waf_ip_set_arn" {
+  value       = aws_wafv2_ip_set.railway.arn
+  description = "ARN of the IP set holding the Railway egress ranges"
+}
+
+output "web_acl_arn" {
+  value       = aws_wafv2_web_acl.main.arn
+  description = "ARN of the web ACL allowing only Railway egress ranges"
+}
+
+output "web_acl_name" {
+  value       = aws_wafv2_web_acl.main.name
+  description = "Name of the web ACL"
+}
@@ -0,0 +1,58 @@
+# ==============================================================================
+# WAF Module - Input Variables
+# ==============================================================================
+
+variable "environment" {
+  type        = string
+  description = "Deployment tier (dev, staging, production)"
+
+  validation {
+    condition     = contains(["dev", "staging", "production"], var.environment)
+    error_message = "Environment must be one of dev, staging, production."
+  }
+}
+
+variable "name_suffix" {
+  type        = string
+  default     = ""
+  description = "Optional suffix for resource names (tests/ephemeral runs)"
+
+  validation {
+    condition     = can(regex("^[a-z0-9-]*$", var.name_suffix))
+    error_message = "name_suffix may contain only lowercase letters, digits, and hyphens."
+  }
+}
+
+variable "railway_ip_ranges" {
+  type        = list(string)
+  description = "Railway egress CIDR ranges allowed through the web ACL; everything else is blocked"
+  default     = []
+
+  validation {
+    condition     = alltrue([for cidr in var.railway_ip_ranges : can(cidrhost(cidr, 0)) && !can(regex(":", cidr))])
+    error_message = "railway_ip_ranges must contain IPv4 CIDR blocks"
+  }
+}
+
+variable "scope" {
+  type        = string
+  description = "WAF scope: REGIONAL for ALB/API Gateway, CLOUDFRONT for distributions (requires us-east-1)"
+  default     = "REGIONAL"
+
+  validation {
+    condition     = contains(["REGIONAL", "CLOUDFRONT"], var.scope)
+    error_message = "scope must be REGIONAL or CLOUDFRONT"
+  }
+}
+
+variable "protected_resource_arns" {
+  type        = list(string)
+  description = "Regional resource ARNs (ALB, API Gateway stage) to associate with the web ACL"
+  default     = []
+}
+
+variable "tags" {
+  type        = map(string)
+  description = "Additional resource tags"
+  default     = {}
+}
@@ -3,6 +3,8 @@
 # ==============================================================================
 # Output values for Railway integration and application configuration
 # These outputs are exported to JSON for consumption by the backend application
+# Add a field to tests/pkg/stackoutputs for every new output; the typed loader
+# rejects outputs it does not know about
 # ==============================================================================
 
 # ------------------------------------------------------------------------------
@@ -14,11 +16,41 @@ output "rds_endpoint" {
   description = "RDS primary endpoint (host:port) for database connections"
 }
 
+output "aurora_cluster_endpoint" {
+  value       = module.rds.aurora_cluster_endpoint
+  description = "Aurora cluster writer endpoint (empty unless rds_engine_type is aurora-postgresql)"
+}
+
+output "aurora_reader_endpoint" {
+  value       = module.rds.aurora_reader_endpoint
+  description = "Aurora cluster reader endpoint (empty unless rds_engine_type is aurora-postgresql)"
+}
+
 output "rds_reader_endpoint" {
   value       = module.rds.rds_reader_endpoint
   description = "RDS reader endpoint for read replica (empty if no replica)"
 }
 
+output "rds_reporting_endpoint" {
+  value       = module.rds.rds_reporting_endpoint
+  description = "RDS reporting replica endpoint for analytics workloads (empty if disabled)"
+}
+
+output "rds_proxy_endpoint" {
+  value       = module.rds.rds_proxy_endpoint
+  description = "RDS Proxy endpoint for pooled connections (empty if disabled)"
+}
+
+output "rds_proxy_reader_endpoint" {
+  value       = module.rds.rds_proxy_reader_endpoint
+  description = "Read-only RDS Proxy endpoint routed to the read replica (empty unless proxy and read replica are enabled)"
+}
+
+output "rds_proxy_require_tls" {
+  value       = module.rds.rds_proxy_require_tls
+  description = "Whether RDS Proxy enforces TLS on client connections"
+}
+
 output "rds_db_name" {
   value       = module.rds.rds_db_name
   description = "Database name"
@@ -30,11 +62,46 @@ output "rds_username" {
   sensitive   = true
 }
 
+output "rds_ca_rotation_needed" {
+  value       = module.rds.rds_ca_rotation_needed
+  description = "Whether the RDS primary is on an older CA than the latest available one"
+}
+
+output "rds_strong_password_enforced" {
+  value       = module.rds.strong_password_enforced
+  description = "Whether the RDS master password meets the length and character-class requirements"
+}
+
 output "rds_arn" {
   value       = module.rds.rds_arn
   description = "RDS instance ARN for IAM authentication and monitoring"
 }
 
+output "rds_sizing_recommendation" {
+  value       = module.rds.rds_sizing_recommendation
+  description = "Recommended RDS instance class and storage for the expected workload, with undersizing warnings"
+}
+
+output "rds_snapshot_copy_configuration" {
+  value       = module.rds.snapshot_copy_configuration
+  description = "Cross-account snapshot copy settings (empty if snapshot_copy_account_id is unset)"
+}
+
+output "rds_schedule_expressions" {
+  value       = module.rds.schedule_expressions
+  description = "RDS stop and start schedules keyed by action (empty if enable_rds_scheduling is false or in production)"
+}
+
+output "data_masking_status" {
+  value       = var.enable_data_masking ? module.data_masking[0].masking_job_status : ""
+  description = "Latest restore masking job status as of the last refresh: never_run, isolated, masking, completed or failed (empty if enable_data_masking is false)"
+}
+
+output "data_masking_isolation_security_group_id" {
+  value       = var.enable_data_masking ? module.data_masking[0].isolation_security_group_id : ""
+  description = "Security group restores into this environment must use until masking exposes them (empty if enable_data_masking is false)"
+}
+
 # ------------------------------------------------------------------------------
 # S3 Storage Outputs
 # ------------------------------------------------------------------------------
@@ -59,6 +126,36 @@ output "s3_bucket_documents_arn" {
   description = "Documents bucket ARN for IAM policy references"
 }
 
+output "s3_bucket_backups_arn" {
+  value       = module.s3.s3_bucket_backups_arn
+  description = "Backups bucket ARN for IAM policy references"
+}
+
+output "s3_bucket_audit_logs_arn" {
+  value       = module.s3.s3_bucket_audit_logs_arn
+  description = "Audit logs bucket ARN for IAM policy references"
+}
+
+output "canary_bucket_name" {
+  value       = module.s3.canary_bucket_name
+  description = "Canary bucket name - any access is a security incident (empty if disabled)"
+}
+
+output "quarantine_bucket_arn" {
+  value       = module.s3.quarantine_bucket_arn
+  description = "Quarantine bucket ARN for incident-response tooling (empty if disabled)"
+}
+
+output "s3_bucket_documents_replica" {
+  value       = module.s3.s3_bucket_documents_replica
+  description = "Documents replica bucket name in replica_region (empty if replica_region unset)"
+}
+
+output "s3_eventbridge_notifications" {
+  value       = module.s3.eventbridge_notifications
+  description = "Whether the documents and backups buckets send object events to EventBridge"
+}
+
 # ------------------------------------------------------------------------------
 # KMS Encryption Outputs
 # ------------------------------------------------------------------------------
@@ -73,6 +170,86 @@ output "kms_master_key_arn" {
   description = "KMS master key ARN for policy references"
 }
 
+output "backup_kms_key_arn" {
+  value       = module.kms.backup_kms_key_arn
+  description = "KMS key ARN for the backups bucket and RDS (the master key unless use_separate_bucket_keys is true)"
+}
+
+output "log_kms_key_arn" {
+  value       = module.kms.log_kms_key_arn
+  description = "KMS key ARN for CloudTrail, Config delivery and the CloudTrail log group (the master key unless create_log_key is true)"
+}
+
+output "kms_replica_key_arn" {
+  value       = module.kms.kms_replica_key_arn
+  description = "KMS replica key ARN in replica_region (empty if replica_region unset)"
+}
+
+# ------------------------------------------------------------------------------
+# CloudTrail Outputs
+# ------------------------------------------------------------------------------
+
+output "cloudtrail_name" {
+  value       = module.cloudtrail.cloudtrail_name
+  description = "CloudTrail trail name for audit evidence lookups"
+}
+
+output "cloudtrail_arn" {
+  value       = module.cloudtrail.cloudtrail_arn
+  description = "CloudTrail trail ARN"
+}
+
+# ------------------------------------------------------------------------------
+# PHI Data Flow Outputs
+# ------------------------------------------------------------------------------
+# Data-flow-diagram evidence for auditors, derived from the deployed wiring
+# rather than maintained by hand
+
+output "phi_data_flow" {
+  value = jsonencode({
+    paths = [
+      {
+        name                     = "app-to-rds"
+        source                   = "app"
+        destination              = "rds"
+        protocol                 = "tcp"
+        port                     = module.rds.rds_port
+        source_security_group_id = module.networking.app_security_group_id
+        target_security_group_id = module.networking.rds_security_group_id
+        network_path             = module.rds.publicly_accessible ? "internet" : "private-subnet"
+        db_subnet_group          = module.rds.db_subnet_group_name
+        subnet_ids               = module.vpc.private_subnet_ids
+        encryption_in_transit    = "tls"
+        encryption_at_rest       = module.kms.backup_kms_key_arn
+      },
+      {
+        name                     = "app-to-s3-documents"
+        source                   = "app"
+        destination              = module.s3.s3_bucket_documents
+        protocol                 = "https"
+        port                     = 443
+        source_security_group_id = module.networking.app_security_group_id
+        network_path             = module.vpc.vpc_endpoint_s3_id != "" ? "vpc-endpoint" : "internet"
+        vpc_endpoint_id          = module.vpc.vpc_endpoint_s3_id
+        encryption_in_transit    = var.app_require_secure_transport ? "tls-required" : "tls"
+        encryption_at_rest       = module.kms.kms_master_key_arn
+      },
+      {
+        name                  = "rds-to-backups"
+        source                = "rds"
+        destination           = "rds-automated-backups"
+        retention_days        = var.backup_retention_days
+        replica_region        = local.multi_region_enabled ? var.replica_region : ""
+        network_path          = "aws-managed"
+        encryption_at_rest    = module.kms.backup_kms_key_arn
+        replica_backups_arn   = module.rds.backup_replication_arn
+        encryption_in_transit = "aws-managed"
+      }
+    ]
+  })
+  description = "PHI data flow paths (JSON) between app, RDS, S3 and backups, derived from module wiring"
+}
+
 # ------------------------------------------------------------------------------
 # VPC Networking Outputs
 # ------------------------------------------------------------------------------
@@ -97,6 +274,46 @@ output "vpc_endpoint_bedrock" {
   description = "Bedrock VPC endpoint ID for private Bedrock API access"
 }
 
+output "vpc_endpoints" {
+  value       = module.vpc.vpc_endpoints
+  description = "Enabled VPC endpoints with service name, type (gateway/interface), ID and private DNS flag"
+}
+
+output "default_vpc_present" {
+  value       = module.vpc.default_vpc_present
+  description = "Whether the region still has a default VPC, a common audit finding (plan also warns)"
+}
+
+output "network_firewall_rule_group_arn" {
+  value       = module.vpc.network_firewall_rule_group_arn
+  description = "Network Firewall rule group holding the allowed AWS service domains (empty if disabled)"
+}
+
+output "nat_gateway_ids" {
+  value       = module.vpc.nat_gateway_ids
+  description = "NAT gateway IDs (empty if NAT disabled)"
+}
+
+output "nat_gateway_eips" {
+  value       = module.vpc.nat_gateway_eips
+  description = "NAT gateway Elastic IPs to allowlist for outbound traffic (empty if NAT disabled)"
+}
+
+output "private_subnets_have_internet_egress" {
+  value       = module.vpc.private_subnets_have_internet_egress
+  description = "Whether private subnets have a default route through a NAT gateway (false when nat_gateway_mode is none)"
+}
+
+output "waf_ip_set_arn" {
+  value       = var.enable_waf ? module.waf[0].waf_ip_set_arn : ""
+  description = "WAF IP set ARN holding the Railway egress allow-list (empty if WAF disabled)"
+}
+
+output "privatelink_endpoint_service_name" {
+  value       = var.enable_privatelink ? module.privatelink[0].endpoint_service_name : ""
+  description = "PrivateLink endpoint service name partners use to create interface endpoints (empty if disabled)"
+}
+
 output "private_subnet_ids" {
   value       = module.vpc.private_subnet_ids
   description = "Private subnet IDs for RDS and application resources"
@@ -130,11 +347,74 @@ output "config_recorder_name" {
   description = "AWS Config recorder name for compliance monitoring"
 }
 
+output "config_rules" {
+  value       = module.config.config_rules
+  description = "AWS Config rule names for HIPAA compliance monitoring, keyed by control"
+}
+
+output "config_snapshot_bucket" {
+  value       = module.config.config_snapshot_bucket
+  description = "Bucket AWS Config delivers snapshots and history to (the audit logs bucket unless config_snapshot_bucket is set)"
+}
+
 output "config_sns_topic_arn" {
   value       = module.config.config_sns_topic_arn
   description = "SNS topic ARN for Config compliance alerts"
 }
 
+output "config_aggregator_arn" {
+  value       = module.config.config_aggregator_arn
+  description = "AWS Config aggregator ARN for multi-account compliance (empty if disabled)"
+}
+
+# ------------------------------------------------------------------------------
+# Monitoring Outputs
+# ------------------------------------------------------------------------------
+
+output "alarm_topic_arn" {
+  value       = module.monitoring.alarm_topic_arn
+  description = "SNS topic ARN notified by critical CloudWatch alarms"
+}
+
+output "central_alarm_topic_arn" {
+  value       = module.monitoring.central_alarm_topic_arn
+  description = "Central-region SNS topic ARN for critical alarms (empty if central_alarm_region is unset)"
+}
+
+output "cert_expiry_alarm_arns" {
+  value       = module.monitoring.cert_expiry_alarm_arns
+  description = "Certificate expiry alarm ARNs keyed rds_ca and acm-<certificate id>"
+}
+
+output "dashboard_name" {
+  value       = var.enable_dashboard ? module.dashboard[0].dashboard_name : ""
+  description = "CloudWatch dashboard aggregating RDS, S3 and GuardDuty health (empty if disabled)"
+}
+
+output "siem_firehose_arn" {
+  value       = module.monitoring.siem_firehose_arn
+  description = "Firehose stream ARN forwarding Config and GuardDuty findings to the SIEM (empty if disabled)"
+}
+
+output "xray_encryption_key_arn" {
+  value       = module.monitoring.xray_encryption_key_arn
+  description = "KMS key ARN encrypting X-Ray traces (empty if tracing is disabled)"
+}
+
+# ------------------------------------------------------------------------------
+# Railway Integration Outputs
+# ------------------------------------------------------------------------------
+
+output "railway_env" {
+  value       = module.railway_env.env_file_content
+  description = "Dotenv content for Railway service variables (secrets as *_SSM_PARAMETER paths)"
+}
+
+output "railway_env_file" {
+  value       = module.railway_env.env_file_path
+  description = "Path of the written dotenv file (empty unless write_env_file is true)"
+}
+
 # ------------------------------------------------------------------------------
 # Environment Metadata
 # ------------------------------------------------------------------------------
@@ -155,6 +435,11 @@ output "environment" {
   description = "Environment name (dev, staging, production)"
 }
 
+output "missing_cost_tags" {
+  value       = local.missing_cost_tags
+  description = "Required cost allocation tags (CostCenter, Environment) that are empty; should be []"
+}
+
 output "terraform_workspace" {
   value       = terraform.workspace
   description = "Terraform workspace used for deployment"
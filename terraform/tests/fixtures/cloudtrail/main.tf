@@ -0,0 +1,141 @@
+# ==============================================================================
+# Test Fixture: CloudTrail
+# ==============================================================================
+# Wires the KMS, S3, and CloudTrail modules so the trail can deliver
+# encrypted logs to a real audit bucket with the required policies.
+# ==============================================================================
+
+terraform {
+  required_version = ">= 1.6.0"
+
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+  }
+}
+
+variable "aws_region" {
+  type    = string
+  default = "us-east-1"
+}
+
+variable "environment" {
+  type    = string
+  default = "dev"
+}
+
+variable "name_suffix" {
+  type = string
+}
+
+variable "create_canary_bucket" {
+  type    = bool
+  default = false
+}
+
+variable "enable_cloudwatch_logs" {
+  type    = bool
+  default = false
+}
+
+variable "cloudtrail_log_retention_days" {
+  type    = number
+  default = 365
+}
+
+provider "aws" {
+  region = var.aws_region
+}
+
+# Replica alias is required by the KMS and S3 modules; no replica resources
+# are created here so it points at the primary region
+provider "aws" {
+  alias  = "replica"
+  region = var.aws_region
+}
+
+data "aws_caller_identity" "current" {}
+
+module "kms" {
+  source = "../../../modules/kms"
+
+  providers = {
+    aws         = aws
+    aws.replica = aws.replica
+  }
+
+  environment    = var.environment
+  name_suffix    = var.name_suffix
+  aws_account_id = data.aws_caller_identity.current.account_id
+}
+
+module "s3" {
+  source = "../../../modules/s3"
+
+  providers = {
+    aws         = aws
+    aws.replica = aws.replica
+  }
+
+  environment               = var.environment
+  name_suffix               = var.name_suffix
+  aws_account_id            = data.aws_caller_identity.current.account_id
+  kms_key_id                = module.kms.kms_master_key_id
+  enable_lifecycle_policies = false
+  create_canary_bucket      = var.create_canary_bucket
+}
+
+module "cloudtrail" {
+  source = "../../../modules/cloudtrail"
+
+  environment          = var.environment
+  name_suffix          = var.name_suffix
+  s3_bucket_audit_logs = module.s3.s3_bucket_audit_logs
+  kms_key_arn          = module.kms.kms_master_key_arn
+
+  enable_cloudwatch_logs        = var.enable_cloudwatch_logs
+  cloudtrail_log_retention_days = var.cloudtrail_log_retention_days
+
+  data_event_bucket_arns = concat(
+    [module.s3.s3_bucket_documents_arn, module.s3.s3_bucket_backups_arn],
+    var.create_canary_bucket ? [module.s3.canary_bucket_arn] : []
+  )
+}
+
+output "cloudtrail_name" {
+  value = module.cloudtrail.cloudtrail_name
+}
+
+output "cloudwatch_log_group_name" {
+  value = module.cloudtrail.cloudwatch_log_group_name
+}
+
+output "kms_master_key_arn" {
+  value = module.kms.kms_master_key_arn
+}
+
+output "s3_bucket_documents_arn" {
+  value = module.s3.s3_bucket_documents_arn
+}
+
+output "s3_bucket_backups_arn" {
+  value = module.s3.s3_bucket_backups_arn
+}
+
+output "s3_bucket_audit_logs" {
+  value = module.s3.s3_bucket_audit_logs
+}
+
+output "canary_bucket_arn" {
+  value = module.s3.canary_bucket_arn
+}
+
+output "canary_alarm_name" {
+  value = module.s3.canary_alarm_name
+}
+
+output "canary_alert_topic_arn" {
+  value = module.s3.canary_alert_topic_arn
+}
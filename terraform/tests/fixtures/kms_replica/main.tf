@@ -0,0 +1,90 @@
+# ==============================================================================
+# Test Fixture: KMS Multi-Region Replica Key
+# ==============================================================================
+# Wires the KMS module with a primary and replica provider and an app role
+# granted as a key user, so tests can inspect the replica key's policy in the
+# DR region.
+# ==============================================================================
+
+terraform {
+  required_version = ">= 1.6.0"
+
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+  }
+}
+
+variable "aws_region" {
+  type    = string
+  default = "us-east-1"
+}
+
+variable "replica_region" {
+  type    = string
+  default = "us-west-2"
+}
+
+variable "environment" {
+  type    = string
+  default = "dev"
+}
+
+variable "name_suffix" {
+  type = string
+}
+
+provider "aws" {
+  region = var.aws_region
+}
+
+provider "aws" {
+  alias  = "replica"
+  region = var.replica_region
+}
+
+data "aws_caller_identity" "current" {}
+
+# Stand-in for the backend application role; key policy principals must exist
+resource "aws_iam_role" "app" {
+  name = "hipaa-kms-replica-app-${var.name_suffix}"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Principal = {
+          AWS = "arn:aws:iam::${data.aws_caller_identity.current.account_id}:root"
+        }
+        Action = "sts:AssumeRole"
+      }
+    ]
+  })
+}
+
+module "kms" {
+  source = "../../../modules/kms"
+
+  providers = {
+    aws         = aws
+    aws.replica = aws.replica
+  }
+
+  environment        = var.environment
+  name_suffix        = var.name_suffix
+  aws_account_id     = data.aws_caller_identity.current.account_id
+  create_replica_key = true
+  key_user_arns      = [aws_iam_role.app.arn]
+  allow_destroy      = true
+}
+
+output "kms_replica_key_arn" {
+  value = module.kms.kms_replica_key_arn
+}
+
+output "app_role_arn" {
+  value = aws_iam_role.app.arn
+}
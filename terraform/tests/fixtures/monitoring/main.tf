@@ -0,0 +1,169 @@
+# ==============================================================================
+# Test Fixture: Central Alarm Region
+# ==============================================================================
+# Wires the monitoring module with a primary and central provider so tests
+# can exercise the cross-region alarm topic that requires the aws.central alias.
+# ==============================================================================
+
+terraform {
+  required_version = ">= 1.6.0"
+
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+  }
+}
+
+variable "aws_region" {
+  type    = string
+  default = "us-east-1"
+}
+
+variable "central_alarm_region" {
+  type    = string
+  default = "us-west-2"
+}
+
+variable "environment" {
+  type    = string
+  default = "dev"
+}
+
+variable "name_suffix" {
+  type = string
+}
+
+variable "enable_siem_forwarding" {
+  type    = bool
+  default = false
+}
+
+variable "enable_xray_tracing" {
+  type    = bool
+  default = false
+}
+
+variable "enable_acm_certificate" {
+  type    = bool
+  default = false
+}
+
+variable "cert_expiry_warning_days" {
+  type    = number
+  default = 30
+}
+
+provider "aws" {
+  region = var.aws_region
+}
+
+provider "aws" {
+  alias  = "central"
+  region = coalesce(var.central_alarm_region, var.aws_region)
+}
+
+module "monitoring" {
+  source = "../../../modules/monitoring"
+
+  providers = {
+    aws         = aws
+    aws.central = aws.central
+  }
+
+  environment = var.environment
+  name_suffix = var.name_suffix
+
+  # Alarms can reference an instance that does not exist; they stay INSUFFICIENT_DATA
+  rds_instance_identifier = "${var.environment}-hipaa-db-${var.name_suffix}"
+  central_alarm_region    = var.central_alarm_region
+
+  enable_siem_forwarding = var.enable_siem_forwarding
+  siem_s3_bucket_arn     = var.enable_siem_forwarding ? aws_s3_bucket.siem[0].arn : ""
+  siem_kms_key_arn       = var.enable_siem_forwarding ? aws_kms_key.siem[0].arn : ""
+
+  enable_xray_tracing = var.enable_xray_tracing
+  xray_kms_key_arn    = var.enable_xray_tracing ? aws_kms_key.xray[0].arn : ""
+
+  acm_certificate_arns     = var.enable_acm_certificate ? [aws_acm_certificate.test[0].arn] : []
+  cert_expiry_warning_days = var.cert_expiry_warning_days
+}
+
+# Stand-ins for the audit bucket and master key the root module passes
+resource "aws_kms_key" "siem" {
+  count                   = var.enable_siem_forwarding ? 1 : 0
+  description             = "SIEM forwarding test key ${var.name_suffix}"
+  deletion_window_in_days = 7
+}
+
+resource "aws_kms_key" "xray" {
+  count                   = var.enable_xray_tracing ? 1 : 0
+  description             = "X-Ray tracing test key ${var.name_suffix}"
+  deletion_window_in_days = 7
+}
+
+# Never validated; the certificate only needs an ARN for the expiry alarm
+resource "aws_acm_certificate" "test" {
+  count             = var.enable_acm_certificate ? 1 : 0
+  domain_name       = "${var.name_suffix}.example.com"
+  validation_method = "DNS"
+}
+
+resource "aws_s3_bucket" "siem" {
+  count         = var.enable_siem_forwarding ? 1 : 0
+  bucket        = "hipaa-siem-test-${var.name_suffix}"
+  force_destroy = true
+}
+
+output "alarm_topic_arn" {
+  value = module.monitoring.alarm_topic_arn
+}
+
+output "central_alarm_topic_arn" {
+  value = module.monitoring.central_alarm_topic_arn
+}
+
+output "critical_alarm_names" {
+  value = module.monitoring.critical_alarm_names
+}
+
+output "rds_throughput_alarm_arn" {
+  value = module.monitoring.rds_throughput_alarm_arn
+}
+
+output "cert_expiry_alarm_arns" {
+  value = module.monitoring.cert_expiry_alarm_arns
+}
+
+output "rds_ca_valid_till" {
+  value = module.monitoring.rds_ca_valid_till
+}
+
+output "acm_certificate_arn" {
+  value = var.enable_acm_certificate ? aws_acm_certificate.test[0].arn : ""
+}
+
+output "rds_instance_identifier" {
+  value = "${var.environment}-hipaa-db-${var.name_suffix}"
+}
+
+output "siem_firehose_arn" {
+  value = module.monitoring.siem_firehose_arn
+}
+
+output "siem_event_rule_names" {
+  value = module.monitoring.siem_event_rule_names
+}
+
+output "siem_kms_key_arn" {
+  value = var.enable_siem_forwarding ? aws_kms_key.siem[0].arn : ""
+}
+
+output "xray_encryption_key_arn" {
+  value = module.monitoring.xray_encryption_key_arn
+}
+
+output "xray_test_key_arn" {
+  value = var.enable_xray_tracing ? aws_kms_key.xray[0].arn : ""
+}
@@ -0,0 +1,90 @@
+# ==============================================================================
+# Test Fixture: S3 Cross-Region Replication
+# ==============================================================================
+# Wires the KMS and S3 modules with a primary and replica provider so tests
+# can exercise multi-region resources that require the aws.replica alias.
+# ==============================================================================
+
+terraform {
+  required_version = ">= 1.6.0"
+
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+  }
+}
+
+variable "aws_region" {
+  type    = string
+  default = "us-east-1"
+}
+
+variable "replica_region" {
+  type    = string
+  default = "us-west-2"
+}
+
+variable "environment" {
+  type    = string
+  default = "dev"
+}
+
+variable "name_suffix" {
+  type = string
+}
+
+provider "aws" {
+  region = var.aws_region
+}
+
+provider "aws" {
+  alias  = "replica"
+  region = var.replica_region
+}
+
+data "aws_caller_identity" "current" {}
+
+module "kms" {
+  source = "../../../modules/kms"
+
+  providers = {
+    aws         = aws
+    aws.replica = aws.replica
+  }
+
+  environment        = var.environment
+  name_suffix        = var.name_suffix
+  aws_account_id     = data.aws_caller_identity.current.account_id
+  create_replica_key = true
+}
+
+module "s3" {
+  source = "../../../modules/s3"
+
+  providers = {
+    aws         = aws
+    aws.replica = aws.replica
+  }
+
+  environment               = var.environment
+  name_suffix               = var.name_suffix
+  aws_account_id            = data.aws_caller_identity.current.account_id
+  kms_key_id                = module.kms.kms_master_key_id
+  enable_lifecycle_policies = false
+  enable_replication        = true
+  replica_kms_key_arn       = module.kms.kms_replica_key_arn
+}
+
+output "s3_bucket_documents" {
+  value = module.s3.s3_bucket_documents
+}
+
+output "s3_bucket_documents_replica" {
+  value = module.s3.s3_bucket_documents_replica
+}
+
+output "kms_replica_key_arn" {
+  value = module.kms.kms_replica_key_arn
+}
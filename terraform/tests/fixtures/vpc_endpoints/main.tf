@@ -0,0 +1,67 @@
+# ==============================================================================
+# Test Fixture: VPC Endpoints
+# ==============================================================================
+# Wires the VPC and networking modules the way the root module does, so the
+# interface endpoints use the networking module's app-only security group.
+# ==============================================================================
+
+terraform {
+  required_version = ">= 1.6.0"
+
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+  }
+}
+
+variable "aws_region" {
+  type    = string
+  default = "us-east-1"
+}
+
+variable "environment" {
+  type    = string
+  default = "dev"
+}
+
+variable "name_suffix" {
+  type = string
+}
+
+provider "aws" {
+  region = var.aws_region
+}
+
+module "vpc" {
+  source = "../../../modules/vpc"
+
+  environment          = var.environment
+  name_suffix          = var.name_suffix
+  enable_nat_gateway   = false
+  enable_vpc_endpoints = true
+
+  endpoint_security_group_ids = [module.networking.vpc_endpoint_security_group_id]
+}
+
+module "networking" {
+  source = "../../../modules/networking"
+
+  environment       = var.environment
+  name_suffix       = var.name_suffix
+  vpc_id            = module.vpc.vpc_id
+  railway_ip_ranges = ["192.0.2.0/24"]
+}
+
+output "vpc_endpoint_bedrock_id" {
+  value = module.vpc.vpc_endpoint_bedrock_id
+}
+
+output "app_security_group_id" {
+  value = module.networking.app_security_group_id
+}
+
+output "vpc_endpoint_security_group_id" {
+  value = module.networking.vpc_endpoint_security_group_id
+}
@@ -0,0 +1,92 @@
+// Package helpers provides AWS lookups shared by the unit and integration
+// Terratest suites.
+package helpers
+
+import (
+	"fmt"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// CloudTrailConfig holds the trail settings used as audit-trail evidence
+type CloudTrailConfig struct {
+	Name                     string
+	KMSKeyID                 string
+	LogFileValidationEnabled bool
+	IsMultiRegionTrail       bool
+	S3BucketName             string
+	HomeRegion               string
+	CloudWatchLogsGroupArn   string
+}
+
+// GetCloudTrailConfig returns the KMS key, log validation, and multi-region settings of a trail
+func GetCloudTrailConfig(t testing.TestingT, region string, trailName string) CloudTrailConfig {
+	trailConfig, err := GetCloudTrailConfigE(t, region, trailName)
+	require.NoError(t, err)
+	return trailConfig
+}
+
+// GetCloudTrailConfigE returns the KMS key, log validation, and multi-region settings of a trail
+func GetCloudTrailConfigE(t testing.TestingT, region string, trailName string) (CloudTrailConfig, error) {
+	sess, err := aws.NewAuthenticatedSession(region)
+	if err != nil {
+		return CloudTrailConfig{}, err
+	}
+
+	output, err := cloudtrail.New(sess).GetTrail(&cloudtrail.GetTrailInput{
+		Name: awssdk.String(trailName),
+	})
+	if err != nil {
+		return CloudTrailConfig{}, err
+	}
+	if output.Trail == nil {
+		return CloudTrailConfig{}, fmt.Errorf("trail %s not found in %s", trailName, region)
+	}
+
+	return CloudTrailConfig{
+		Name:                     awssdk.StringValue(output.Trail.Name),
+		KMSKeyID:                 awssdk.StringValue(output.Trail.KmsKeyId),
+		LogFileValidationEnabled: awssdk.BoolValue(output.Trail.LogFileValidationEnabled),
+		IsMultiRegionTrail:       awssdk.BoolValue(output.Trail.IsMultiRegionTrail),
+		S3BucketName:             awssdk.StringValue(output.Trail.S3BucketName),
+		HomeRegion:               awssdk.StringValue(output.Trail.HomeRegion),
+		CloudWatchLogsGroupArn:   awssdk.StringValue(output.Trail.CloudWatchLogsLogGroupArn),
+	}, nil
+}
+
+// GetCloudTrailS3DataEventResources returns the S3 object ARN prefixes recorded as data events by a trail
+func GetCloudTrailS3DataEventResources(t testing.TestingT, region string, trailName string) []string {
+	resources, err := GetCloudTrailS3DataEventResourcesE(t, region, trailName)
+	require.NoError(t, err)
+	return resources
+}
+
+// GetCloudTrailS3DataEventResourcesE returns the S3 object ARN prefixes recorded as data events by a trail
+func GetCloudTrailS3DataEventResourcesE(t testing.TestingT, region string, trailName string) ([]string, error) {
+	sess, err := aws.NewAuthenticatedSession(region)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := cloudtrail.New(sess).GetEventSelectors(&cloudtrail.GetEventSelectorsInput{
+		TrailName: awssdk.String(trailName),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []string
+	for _, selector := range output.EventSelectors {
+		for _, dataResource := range selector.DataResources {
+			if awssdk.StringValue(dataResource.Type) == "AWS::S3::Object" {
+				resources = append(resources, awssdk.StringValueSlice(dataResource.Values)...)
+			}
+		}
+	}
+
+	return resources, nil
+}
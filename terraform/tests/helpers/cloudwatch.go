@@ -0,0 +1,70 @@
+package helpers
+
+import (
+	"fmt"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// GetMetricAlarm returns the CloudWatch metric alarm with the given name
+func GetMetricAlarm(t testing.TestingT, region string, alarmName string) *cloudwatch.MetricAlarm {
+	alarm, err := GetMetricAlarmE(t, region, alarmName)
+	require.NoError(t, err)
+	return alarm
+}
+
+// GetMetricAlarmE returns the CloudWatch metric alarm with the given name
+func GetMetricAlarmE(t testing.TestingT, region string, alarmName string) (*cloudwatch.MetricAlarm, error) {
+	sess, err := aws.NewAuthenticatedSession(region)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := cloudwatch.New(sess).DescribeAlarms(&cloudwatch.DescribeAlarmsInput{
+		AlarmNames: awssdk.StringSlice([]string{alarmName}),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.MetricAlarms) == 0 {
+		return nil, fmt.Errorf("metric alarm %s not found in %s", alarmName, region)
+	}
+
+	return output.MetricAlarms[0], nil
+}
+
+// GetLogGroup returns the CloudWatch Logs group with the given name
+func GetLogGroup(t testing.TestingT, region string, logGroupName string) *cloudwatchlogs.LogGroup {
+	logGroup, err := GetLogGroupE(t, region, logGroupName)
+	require.NoError(t, err)
+	return logGroup
+}
+
+// GetLogGroupE returns the CloudWatch Logs group with the given name
+func GetLogGroupE(t testing.TestingT, region string, logGroupName string) (*cloudwatchlogs.LogGroup, error) {
+	sess, err := aws.NewAuthenticatedSession(region)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := cloudwatchlogs.New(sess).DescribeLogGroups(&cloudwatchlogs.DescribeLogGroupsInput{
+		LogGroupNamePrefix: awssdk.String(logGroupName),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The lookup is by prefix, so skip groups that only share a leading name
+	for _, logGroup := range output.LogGroups {
+		if awssdk.StringValue(logGroup.LogGroupName) == logGroupName {
+			return logGroup, nil
+		}
+	}
+
+	return nil, fmt.Errorf("log group %s not found in %s", logGroupName, region)
+}
@@ -0,0 +1,275 @@
+package helpers
+
+import (
+	"fmt"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/configservice"
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// NewConfigServiceClientE returns an AWS Config client for the given region
+func NewConfigServiceClientE(t testing.TestingT, region string) (*configservice.ConfigService, error) {
+	sess, err := aws.NewAuthenticatedSession(region)
+	if err != nil {
+		return nil, err
+	}
+
+	return configservice.New(sess), nil
+}
+
+// GetConfigRule returns the deployed Config rule with the given name
+func GetConfigRule(t testing.TestingT, region string, ruleName string) *configservice.ConfigRule {
+	rule, err := GetConfigRuleE(t, region, ruleName)
+	require.NoError(t, err)
+	return rule
+}
+
+// GetConfigRuleE returns the deployed Config rule with the given name
+func GetConfigRuleE(t testing.TestingT, region string, ruleName string) (*configservice.ConfigRule, error) {
+	client, err := NewConfigServiceClientE(t, region)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.DescribeConfigRules(&configservice.DescribeConfigRulesInput{
+		ConfigRuleNames: []*string{awssdk.String(ruleName)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.ConfigRules) == 0 {
+		return nil, fmt.Errorf("Config rule %s not found in %s", ruleName, region)
+	}
+
+	return output.ConfigRules[0], nil
+}
+
+// GetConfigurationAggregator returns the Config aggregator with the given ARN
+func GetConfigurationAggregator(t testing.TestingT, region string, aggregatorARN string) *configservice.ConfigurationAggregator {
+	aggregator, err := GetConfigurationAggregatorE(t, region, aggregatorARN)
+	require.NoError(t, err)
+	return aggregator
+}
+
+// GetConfigurationAggregatorE returns the Config aggregator with the given ARN
+func GetConfigurationAggregatorE(t testing.TestingT, region string, aggregatorARN string) (*configservice.ConfigurationAggregator, error) {
+	client, err := NewConfigServiceClientE(t, region)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &configservice.DescribeConfigurationAggregatorsInput{}
+	for {
+		output, err := client.DescribeConfigurationAggregators(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, aggregator := range output.ConfigurationAggregators {
+			if awssdk.StringValue(aggregator.ConfigurationAggregatorArn) == aggregatorARN {
+				return aggregator, nil
+			}
+		}
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	return nil, fmt.Errorf("Config aggregator %s not found in %s", aggregatorARN, region)
+}
+
+// GetAggregateAuthorizations returns the aggregation authorizations in a region as authorized account ID to authorized regions
+func GetAggregateAuthorizations(t testing.TestingT, region string) map[string][]string {
+	authorizations, err := GetAggregateAuthorizationsE(t, region)
+	require.NoError(t, err)
+	return authorizations
+}
+
+// GetAggregateAuthorizationsE returns the aggregation authorizations in a region as authorized account ID to authorized regions
+func GetAggregateAuthorizationsE(t testing.TestingT, region string) (map[string][]string, error) {
+	client, err := NewConfigServiceClientE(t, region)
+	if err != nil {
+		return nil, err
+	}
+
+	authorizations := map[string][]string{}
+	input := &configservice.DescribeAggregationAuthorizationsInput{}
+	for {
+		output, err := client.DescribeAggregationAuthorizations(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, authorization := range output.AggregationAuthorizations {
+			accountID := awssdk.StringValue(authorization.AuthorizedAccountId)
+			authorizations[accountID] = append(authorizations[accountID], awssdk.StringValue(authorization.AuthorizedAwsRegion))
+		}
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	return authorizations, nil
+}
+
+// GetRemediationConfigurations returns the remediation configurations attached to the given rules, keyed by rule name
+func GetRemediationConfigurations(t testing.TestingT, region string, ruleNames []string) map[string]*configservice.RemediationConfiguration {
+	configs, err := GetRemediationConfigurationsE(t, region, ruleNames)
+	require.NoError(t, err)
+	return configs
+}
+
+// GetRemediationConfigurationsE returns the remediation configurations attached to the given rules, keyed by rule name
+func GetRemediationConfigurationsE(t testing.TestingT, region string, ruleNames []string) (map[string]*configservice.RemediationConfiguration, error) {
+	client, err := NewConfigServiceClientE(t, region)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.DescribeRemediationConfigurations(&configservice.DescribeRemediationConfigurationsInput{
+		ConfigRuleNames: awssdk.StringSlice(ruleNames),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	configs := map[string]*configservice.RemediationConfiguration{}
+	for _, config := range output.RemediationConfigurations {
+		configs[awssdk.StringValue(config.ConfigRuleName)] = config
+	}
+
+	return configs, nil
+}
+
+// GetConfigurationRecorderStatus returns the recording status of the named Config recorder
+func GetConfigurationRecorderStatus(t testing.TestingT, region string, recorderName string) *configservice.ConfigurationRecorderStatus {
+	status, err := GetConfigurationRecorderStatusE(t, region, recorderName)
+	require.NoError(t, err)
+	return status
+}
+
+// GetConfigurationRecorderStatusE returns the recording status of the named Config recorder
+func GetConfigurationRecorderStatusE(t testing.TestingT, region string, recorderName string) (*configservice.ConfigurationRecorderStatus, error) {
+	client, err := NewConfigServiceClientE(t, region)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.DescribeConfigurationRecorderStatus(&configservice.DescribeConfigurationRecorderStatusInput{
+		ConfigurationRecorderNames: []*string{awssdk.String(recorderName)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.ConfigurationRecordersStatus) == 0 {
+		return nil, fmt.Errorf("Config recorder %s not found in %s", recorderName, region)
+	}
+
+	return output.ConfigurationRecordersStatus[0], nil
+}
+
+// GetConfigDeliveryChannel returns the named Config delivery channel
+func GetConfigDeliveryChannel(t testing.TestingT, region string, channelName string) *configservice.DeliveryChannel {
+	channel, err := GetConfigDeliveryChannelE(t, region, channelName)
+	require.NoError(t, err)
+	return channel
+}
+
+// GetConfigDeliveryChannelE returns the named Config delivery channel
+func GetConfigDeliveryChannelE(t testing.TestingT, region string, channelName string) (*configservice.DeliveryChannel, error) {
+	client, err := NewConfigServiceClientE(t, region)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.DescribeDeliveryChannels(&configservice.DescribeDeliveryChannelsInput{
+		DeliveryChannelNames: []*string{awssdk.String(channelName)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.DeliveryChannels) == 0 {
+		return nil, fmt.Errorf("Config delivery channel %s not found in %s", channelName, region)
+	}
+
+	return output.DeliveryChannels[0], nil
+}
+
+// GetConfigRuleEvaluationStatuses returns the evaluation status of the given Config rules, keyed by rule name
+func GetConfigRuleEvaluationStatuses(t testing.TestingT, region string, ruleNames []string) map[string]*configservice.ConfigRuleEvaluationStatus {
+	statuses, err := GetConfigRuleEvaluationStatusesE(t, region, ruleNames)
+	require.NoError(t, err)
+	return statuses
+}
+
+// GetConfigRuleEvaluationStatusesE returns the evaluation status of the given Config rules, keyed by rule name
+func GetConfigRuleEvaluationStatusesE(t testing.TestingT, region string, ruleNames []string) (map[string]*configservice.ConfigRuleEvaluationStatus, error) {
+	client, err := NewConfigServiceClientE(t, region)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := map[string]*configservice.ConfigRuleEvaluationStatus{}
+	input := &configservice.DescribeConfigRuleEvaluationStatusInput{
+		ConfigRuleNames: awssdk.StringSlice(ruleNames),
+	}
+	for {
+		output, err := client.DescribeConfigRuleEvaluationStatus(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, status := range output.ConfigRulesEvaluationStatus {
+			statuses[awssdk.StringValue(status.ConfigRuleName)] = status
+		}
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	return statuses, nil
+}
+
+// GetConfigRuleComplianceTypes returns the compliance type of the given Config rules, keyed by rule name; rules without results are reported as INSUFFICIENT_DATA
+func GetConfigRuleComplianceTypes(t testing.TestingT, region string, ruleNames []string) map[string]string {
+	complianceTypes, err := GetConfigRuleComplianceTypesE(t, region, ruleNames)
+	require.NoError(t, err)
+	return complianceTypes
+}
+
+// GetConfigRuleComplianceTypesE returns the compliance type of the given Config rules, keyed by rule name; rules without results are reported as INSUFFICIENT_DATA
+func GetConfigRuleComplianceTypesE(t testing.TestingT, region string, ruleNames []string) (map[string]string, error) {
+	client, err := NewConfigServiceClientE(t, region)
+	if err != nil {
+		return nil, err
+	}
+
+	complianceTypes := map[string]string{}
+	for _, ruleName := range ruleNames {
+		complianceTypes[ruleName] = configservice.ComplianceTypeInsufficientData
+	}
+
+	input := &configservice.DescribeComplianceByConfigRuleInput{
+		ConfigRuleNames: awssdk.StringSlice(ruleNames),
+	}
+	for {
+		output, err := client.DescribeComplianceByConfigRule(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, compliance := range output.ComplianceByConfigRules {
+			if compliance.Compliance != nil {
+				complianceTypes[awssdk.StringValue(compliance.ConfigRuleName)] = awssdk.StringValue(compliance.Compliance.ComplianceType)
+			}
+		}
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	return complianceTypes, nil
+}
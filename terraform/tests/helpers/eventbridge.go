@@ -0,0 +1,76 @@
+package helpers
+
+import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// NewEventBridgeClientE returns an EventBridge client for the given region
+func NewEventBridgeClientE(t testing.TestingT, region string) (*eventbridge.EventBridge, error) {
+	sess, err := aws.NewAuthenticatedSession(region)
+	if err != nil {
+		return nil, err
+	}
+
+	return eventbridge.New(sess), nil
+}
+
+// GetEventRulePattern returns the event pattern JSON of an EventBridge rule on the default bus
+func GetEventRulePattern(t testing.TestingT, region string, ruleName string) string {
+	pattern, err := GetEventRulePatternE(t, region, ruleName)
+	require.NoError(t, err)
+	return pattern
+}
+
+// GetEventRulePatternE returns the event pattern JSON of an EventBridge rule on the default bus
+func GetEventRulePatternE(t testing.TestingT, region string, ruleName string) (string, error) {
+	client, err := NewEventBridgeClientE(t, region)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := client.DescribeRule(&eventbridge.DescribeRuleInput{
+		Name: awssdk.String(ruleName),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return awssdk.StringValue(output.EventPattern), nil
+}
+
+// GetEventRuleTargetArns returns the ARNs targeted by an EventBridge rule on the default bus
+func GetEventRuleTargetArns(t testing.TestingT, region string, ruleName string) []string {
+	arns, err := GetEventRuleTargetArnsE(t, region, ruleName)
+	require.NoError(t, err)
+	return arns
+}
+
+// GetEventRuleTargetArnsE returns the ARNs targeted by an EventBridge rule on the default bus
+func GetEventRuleTargetArnsE(t testing.TestingT, region string, ruleName string) ([]string, error) {
+	client, err := NewEventBridgeClientE(t, region)
+	if err != nil {
+		return nil, err
+	}
+
+	var arns []string
+	input := &eventbridge.ListTargetsByRuleInput{Rule: awssdk.String(ruleName)}
+	for {
+		output, err := client.ListTargetsByRule(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, target := range output.Targets {
+			arns = append(arns, awssdk.StringValue(target.Arn))
+		}
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	return arns, nil
+}
@@ -0,0 +1,33 @@
+package helpers
+
+import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/firehose"
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// GetDeliveryStream returns the description of a Firehose delivery stream
+func GetDeliveryStream(t testing.TestingT, region string, streamName string) *firehose.DeliveryStreamDescription {
+	stream, err := GetDeliveryStreamE(t, region, streamName)
+	require.NoError(t, err)
+	return stream
+}
+
+// GetDeliveryStreamE returns the description of a Firehose delivery stream
+func GetDeliveryStreamE(t testing.TestingT, region string, streamName string) (*firehose.DeliveryStreamDescription, error) {
+	sess, err := aws.NewAuthenticatedSession(region)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := firehose.New(sess).DescribeDeliveryStream(&firehose.DescribeDeliveryStreamInput{
+		DeliveryStreamName: awssdk.String(streamName),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output.DeliveryStreamDescription, nil
+}
@@ -0,0 +1,164 @@
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// appRequestContext is the request context of a well-behaved app call; TLS is
+// set so aws:SecureTransport conditions do not mask the policy under test
+var appRequestContext = map[string]string{"aws:SecureTransport": "true"}
+
+// AssertSimulated asserts the IAM policy simulator allows (or does not allow) a principal to perform an action on a resource over TLS
+func AssertSimulated(t testing.TestingT, region string, principalARN string, action string, resourceARN string, expectAllow bool) {
+	decision := SimulatePrincipalAction(t, region, principalARN, action, resourceARN, appRequestContext)
+	if expectAllow {
+		assert.Equal(t, iam.PolicyEvaluationDecisionTypeAllowed, decision, "%s should be allowed %s on %s", principalARN, action, resourceARN)
+	} else {
+		assert.NotEqual(t, iam.PolicyEvaluationDecisionTypeAllowed, decision, "%s should be denied %s on %s", principalARN, action, resourceARN)
+	}
+}
+
+// SimulatePrincipalAction returns the IAM policy simulator decision (allowed, explicitDeny, implicitDeny) for a principal, action and resource
+func SimulatePrincipalAction(t testing.TestingT, region string, principalARN string, action string, resourceARN string, context map[string]string) string {
+	decision, err := SimulatePrincipalActionE(t, region, principalARN, action, resourceARN, context)
+	require.NoError(t, err)
+	return decision
+}
+
+// SimulatePrincipalActionE returns the IAM policy simulator decision (allowed, explicitDeny, implicitDeny) for a principal, action and resource
+func SimulatePrincipalActionE(t testing.TestingT, region string, principalARN string, action string, resourceARN string, context map[string]string) (string, error) {
+	client, err := aws.NewIamClientE(t, region)
+	if err != nil {
+		return "", err
+	}
+
+	// Context keys such as aws:SecureTransport default to absent, failing conditional allows
+	var contextEntries []*iam.ContextEntry
+	for key, value := range context {
+		keyType := iam.ContextKeyTypeEnumString
+		if value == "true" || value == "false" {
+			keyType = iam.ContextKeyTypeEnumBoolean
+		}
+		contextEntries = append(contextEntries, &iam.ContextEntry{
+			ContextKeyName:   awssdk.String(key),
+			ContextKeyType:   awssdk.String(keyType),
+			ContextKeyValues: awssdk.StringSlice([]string{value}),
+		})
+	}
+
+	output, err := client.SimulatePrincipalPolicy(&iam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: awssdk.String(principalARN),
+		ActionNames:     awssdk.StringSlice([]string{action}),
+		ResourceArns:    awssdk.StringSlice([]string{resourceARN}),
+		ContextEntries:  contextEntries,
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(output.EvaluationResults) == 0 {
+		return "", fmt.Errorf("no simulation result for %s on %s", action, resourceARN)
+	}
+
+	return awssdk.StringValue(output.EvaluationResults[0].EvalDecision), nil
+}
+
+// GetAttachedPolicyDocuments returns the default-version document of each managed policy attached to a role, keyed by policy name
+func GetAttachedPolicyDocuments(t testing.TestingT, region string, roleName string) map[string]string {
+	documents, err := GetAttachedPolicyDocumentsE(t, region, roleName)
+	require.NoError(t, err)
+	return documents
+}
+
+// GetAttachedPolicyDocumentsE returns the default-version document of each managed policy attached to a role, keyed by policy name
+func GetAttachedPolicyDocumentsE(t testing.TestingT, region string, roleName string) (map[string]string, error) {
+	client, err := aws.NewIamClientE(t, region)
+	if err != nil {
+		return nil, err
+	}
+
+	var attached []*iam.AttachedPolicy
+	err = client.ListAttachedRolePoliciesPages(&iam.ListAttachedRolePoliciesInput{
+		RoleName: awssdk.String(roleName),
+	}, func(page *iam.ListAttachedRolePoliciesOutput, lastPage bool) bool {
+		attached = append(attached, page.AttachedPolicies...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	documents := map[string]string{}
+	for _, policy := range attached {
+		details, err := client.GetPolicy(&iam.GetPolicyInput{PolicyArn: policy.PolicyArn})
+		if err != nil {
+			return nil, err
+		}
+		version, err := client.GetPolicyVersion(&iam.GetPolicyVersionInput{
+			PolicyArn: policy.PolicyArn,
+			VersionId: details.Policy.DefaultVersionId,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// IAM returns policy documents URL-encoded
+		document, err := url.QueryUnescape(awssdk.StringValue(version.PolicyVersion.Document))
+		if err != nil {
+			return nil, err
+		}
+		documents[awssdk.StringValue(policy.PolicyName)] = document
+	}
+
+	return documents, nil
+}
+
+// PolicyResourceARNs returns the Resource ARNs of a policy document's statements with the given effect, skipping "*"
+func PolicyResourceARNs(t testing.TestingT, document string, effect string) []string {
+	resources, err := PolicyResourceARNsE(document, effect)
+	require.NoError(t, err)
+	return resources
+}
+
+// PolicyResourceARNsE returns the Resource ARNs of a policy document's statements with the given effect, skipping "*"
+func PolicyResourceARNsE(document string, effect string) ([]string, error) {
+	var policy struct {
+		Statement []struct {
+			Effect   string
+			Resource interface{}
+		}
+	}
+	if err := json.Unmarshal([]byte(document), &policy); err != nil {
+		return nil, err
+	}
+
+	var resources []string
+	for _, statement := range policy.Statement {
+		if statement.Effect != effect {
+			continue
+		}
+		// Resource is a string or a list of strings
+		switch value := statement.Resource.(type) {
+		case string:
+			if value != "*" {
+				resources = append(resources, value)
+			}
+		case []interface{}:
+			for _, item := range value {
+				if arn, ok := item.(string); ok && arn != "*" {
+					resources = append(resources, arn)
+				}
+			}
+		}
+	}
+
+	return resources, nil
+}
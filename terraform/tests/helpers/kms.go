@@ -0,0 +1,119 @@
+package helpers
+
+import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// GetKMSKeyState returns the KeyState (Enabled, PendingDeletion, ...) of the given key
+func GetKMSKeyState(t testing.TestingT, region string, keyID string) string {
+	state, err := GetKMSKeyStateE(t, region, keyID)
+	require.NoError(t, err)
+	return state
+}
+
+// GetKMSKeyStateE returns the KeyState (Enabled, PendingDeletion, ...) of the given key
+func GetKMSKeyStateE(t testing.TestingT, region string, keyID string) (string, error) {
+	client, err := aws.NewKmsClientE(t, region)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := client.DescribeKey(&kms.DescribeKeyInput{
+		KeyId: awssdk.String(keyID),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return awssdk.StringValue(output.KeyMetadata.KeyState), nil
+}
+
+// ResolveKMSAlias returns the ID of the key an alias currently points at
+func ResolveKMSAlias(t testing.TestingT, region string, alias string) string {
+	keyID, err := ResolveKMSAliasE(t, region, alias)
+	require.NoError(t, err)
+	return keyID
+}
+
+// ResolveKMSAliasE returns the ID of the key an alias currently points at
+func ResolveKMSAliasE(t testing.TestingT, region string, alias string) (string, error) {
+	client, err := aws.NewKmsClientE(t, region)
+	if err != nil {
+		return "", err
+	}
+
+	// DescribeKey follows the alias to its target key
+	output, err := client.DescribeKey(&kms.DescribeKeyInput{
+		KeyId: awssdk.String(alias),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return awssdk.StringValue(output.KeyMetadata.KeyId), nil
+}
+
+// EnsureKMSKeyEnabled cancels a pending deletion and re-enables the key so a
+// re-apply adopts the existing key instead of replacing it
+func EnsureKMSKeyEnabled(t testing.TestingT, region string, keyID string) {
+	require.NoError(t, EnsureKMSKeyEnabledE(t, region, keyID))
+}
+
+// EnsureKMSKeyEnabledE cancels a pending deletion and re-enables the key so a
+// re-apply adopts the existing key instead of replacing it
+func EnsureKMSKeyEnabledE(t testing.TestingT, region string, keyID string) error {
+	state, err := GetKMSKeyStateE(t, region, keyID)
+	if err != nil {
+		return err
+	}
+
+	client, err := aws.NewKmsClientE(t, region)
+	if err != nil {
+		return err
+	}
+
+	// Cancelling deletion leaves the key Disabled, so it must be re-enabled
+	if state == kms.KeyStatePendingDeletion {
+		if _, err := client.CancelKeyDeletion(&kms.CancelKeyDeletionInput{KeyId: awssdk.String(keyID)}); err != nil {
+			return err
+		}
+		state = kms.KeyStateDisabled
+	}
+
+	if state == kms.KeyStateDisabled {
+		if _, err := client.EnableKey(&kms.EnableKeyInput{KeyId: awssdk.String(keyID)}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetKMSKeyPolicy returns the default key policy document of the given key
+func GetKMSKeyPolicy(t testing.TestingT, region string, keyID string) string {
+	policy, err := GetKMSKeyPolicyE(t, region, keyID)
+	require.NoError(t, err)
+	return policy
+}
+
+// GetKMSKeyPolicyE returns the default key policy document of the given key
+func GetKMSKeyPolicyE(t testing.TestingT, region string, keyID string) (string, error) {
+	client, err := aws.NewKmsClientE(t, region)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := client.GetKeyPolicy(&kms.GetKeyPolicyInput{
+		KeyId:      awssdk.String(keyID),
+		PolicyName: awssdk.String("default"),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return awssdk.StringValue(output.Policy), nil
+}
@@ -0,0 +1,38 @@
+package helpers
+
+import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// GetLambdaEnvironment returns the environment variables configured on a Lambda function
+func GetLambdaEnvironment(t testing.TestingT, region string, functionName string) map[string]string {
+	env, err := GetLambdaEnvironmentE(t, region, functionName)
+	require.NoError(t, err)
+	return env
+}
+
+// GetLambdaEnvironmentE returns the environment variables configured on a Lambda function
+func GetLambdaEnvironmentE(t testing.TestingT, region string, functionName string) (map[string]string, error) {
+	client, err := aws.NewLambdaClientE(t, region)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.GetFunctionConfiguration(&lambda.GetFunctionConfigurationInput{
+		FunctionName: awssdk.String(functionName),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	env := map[string]string{}
+	if output.Environment != nil {
+		env = awssdk.StringValueMap(output.Environment.Variables)
+	}
+
+	return env, nil
+}
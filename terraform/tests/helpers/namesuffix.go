@@ -0,0 +1,76 @@
+package helpers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gruntwork-io/terratest/modules/random"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// nameSuffixRegistry records which test claimed each name_suffix in this test binary
+var nameSuffixRegistry = struct {
+	sync.Mutex
+	owners     map[string]string
+	collisions []string
+}{owners: map[string]string{}}
+
+// UniqueNameSuffix returns a fresh test-<id> name_suffix registered to the calling test
+func UniqueNameSuffix(t testing.TestingT) string {
+	for {
+		suffix := strings.ToLower(fmt.Sprintf("test-%s", random.UniqueId()))
+		if err := RegisterNameSuffixE(t, suffix); err == nil {
+			return suffix
+		}
+	}
+}
+
+// RegisterNameSuffix claims a name_suffix for the calling test and fails the test if another test already holds it
+func RegisterNameSuffix(t testing.TestingT, suffix string) string {
+	err := RegisterNameSuffixE(t, suffix)
+	if err != nil {
+		recordNameSuffixCollision(err)
+	}
+	require.NoError(t, err)
+	return suffix
+}
+
+// RegisterNameSuffixE claims a name_suffix for the calling test and returns an error if another test already holds it
+func RegisterNameSuffixE(t testing.TestingT, suffix string) error {
+	nameSuffixRegistry.Lock()
+	defer nameSuffixRegistry.Unlock()
+
+	testName := t.Name()
+	owner, claimed := nameSuffixRegistry.owners[suffix]
+	if !claimed {
+		nameSuffixRegistry.owners[suffix] = testName
+		return nil
+	}
+
+	// A test may reuse its suffix, and its subtests may share it
+	if owner == testName || strings.HasPrefix(testName, owner+"/") {
+		return nil
+	}
+
+	return fmt.Errorf("name_suffix %q requested by %s is already used by %s", suffix, testName, owner)
+}
+
+// NameSuffixCollisions returns every rejected name_suffix claim recorded by RegisterNameSuffix, sorted
+func NameSuffixCollisions() []string {
+	nameSuffixRegistry.Lock()
+	defer nameSuffixRegistry.Unlock()
+
+	collisions := append([]string(nil), nameSuffixRegistry.collisions...)
+	sort.Strings(collisions)
+	return collisions
+}
+
+func recordNameSuffixCollision(err error) {
+	nameSuffixRegistry.Lock()
+	defer nameSuffixRegistry.Unlock()
+
+	nameSuffixRegistry.collisions = append(nameSuffixRegistry.collisions, err.Error())
+}
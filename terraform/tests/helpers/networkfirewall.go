@@ -0,0 +1,43 @@
+package helpers
+
+import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/networkfirewall"
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// NewNetworkFirewallClientE returns a Network Firewall client for the given region
+func NewNetworkFirewallClientE(t testing.TestingT, region string) (*networkfirewall.NetworkFirewall, error) {
+	sess, err := aws.NewAuthenticatedSession(region)
+	if err != nil {
+		return nil, err
+	}
+
+	return networkfirewall.New(sess), nil
+}
+
+// GetNetworkFirewallRuleGroup returns the Network Firewall rule group with the given ARN
+func GetNetworkFirewallRuleGroup(t testing.TestingT, region string, ruleGroupARN string) *networkfirewall.RuleGroup {
+	ruleGroup, err := GetNetworkFirewallRuleGroupE(t, region, ruleGroupARN)
+	require.NoError(t, err)
+	return ruleGroup
+}
+
+// GetNetworkFirewallRuleGroupE returns the Network Firewall rule group with the given ARN
+func GetNetworkFirewallRuleGroupE(t testing.TestingT, region string, ruleGroupARN string) (*networkfirewall.RuleGroup, error) {
+	client, err := NewNetworkFirewallClientE(t, region)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.DescribeRuleGroup(&networkfirewall.DescribeRuleGroupInput{
+		RuleGroupArn: awssdk.String(ruleGroupARN),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output.RuleGroup, nil
+}
@@ -0,0 +1,68 @@
+package helpers
+
+import (
+	"fmt"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// GetVpcEndpointServiceConfiguration returns the configuration of the VPC endpoint service with the given ID
+func GetVpcEndpointServiceConfiguration(t testing.TestingT, region string, serviceID string) *ec2.ServiceConfiguration {
+	config, err := GetVpcEndpointServiceConfigurationE(t, region, serviceID)
+	require.NoError(t, err)
+	return config
+}
+
+// GetVpcEndpointServiceConfigurationE returns the configuration of the VPC endpoint service with the given ID
+func GetVpcEndpointServiceConfigurationE(t testing.TestingT, region string, serviceID string) (*ec2.ServiceConfiguration, error) {
+	client, err := aws.NewEc2ClientE(t, region)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.DescribeVpcEndpointServiceConfigurations(&ec2.DescribeVpcEndpointServiceConfigurationsInput{
+		ServiceIds: awssdk.StringSlice([]string{serviceID}),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.ServiceConfigurations) == 0 {
+		return nil, fmt.Errorf("VPC endpoint service %s not found", serviceID)
+	}
+
+	return output.ServiceConfigurations[0], nil
+}
+
+// GetVpcEndpointServiceAllowedPrincipals returns the principal ARNs allowed to connect to the VPC endpoint service
+func GetVpcEndpointServiceAllowedPrincipals(t testing.TestingT, region string, serviceID string) []string {
+	principals, err := GetVpcEndpointServiceAllowedPrincipalsE(t, region, serviceID)
+	require.NoError(t, err)
+	return principals
+}
+
+// GetVpcEndpointServiceAllowedPrincipalsE returns the principal ARNs allowed to connect to the VPC endpoint service
+func GetVpcEndpointServiceAllowedPrincipalsE(t testing.TestingT, region string, serviceID string) ([]string, error) {
+	client, err := aws.NewEc2ClientE(t, region)
+	if err != nil {
+		return nil, err
+	}
+
+	var principals []string
+	err = client.DescribeVpcEndpointServicePermissionsPages(&ec2.DescribeVpcEndpointServicePermissionsInput{
+		ServiceId: awssdk.String(serviceID),
+	}, func(page *ec2.DescribeVpcEndpointServicePermissionsOutput, lastPage bool) bool {
+		for _, principal := range page.AllowedPrincipals {
+			principals = append(principals, awssdk.StringValue(principal.Principal))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return principals, nil
+}
@@ -0,0 +1,191 @@
+package helpers
+
+import (
+	"fmt"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// RDSProxyConfig holds the RDS Proxy settings checked by the RDS tests
+type RDSProxyConfig struct {
+	Name                    string
+	Endpoint                string
+	RequireTLS              bool
+	IdleClientTimeout       int64
+	ConnectionBorrowTimeout int64
+}
+
+// NewRDSClientE creates an RDS client for the given region
+func NewRDSClientE(t testing.TestingT, region string) (*rds.RDS, error) {
+	sess, err := aws.NewAuthenticatedSession(region)
+	if err != nil {
+		return nil, err
+	}
+	return rds.New(sess), nil
+}
+
+// GetRDSProxyConfig returns the TLS and connection pool settings of an RDS Proxy
+func GetRDSProxyConfig(t testing.TestingT, region string, proxyName string) RDSProxyConfig {
+	proxyConfig, err := GetRDSProxyConfigE(t, region, proxyName)
+	require.NoError(t, err)
+	return proxyConfig
+}
+
+// GetRDSProxyConfigE returns the TLS and connection pool settings of an RDS Proxy
+func GetRDSProxyConfigE(t testing.TestingT, region string, proxyName string) (RDSProxyConfig, error) {
+	client, err := NewRDSClientE(t, region)
+	if err != nil {
+		return RDSProxyConfig{}, err
+	}
+
+	proxies, err := client.DescribeDBProxies(&rds.DescribeDBProxiesInput{
+		DBProxyName: awssdk.String(proxyName),
+	})
+	if err != nil {
+		return RDSProxyConfig{}, err
+	}
+	if len(proxies.DBProxies) == 0 {
+		return RDSProxyConfig{}, fmt.Errorf("RDS proxy %s not found in %s", proxyName, region)
+	}
+	proxy := proxies.DBProxies[0]
+
+	targetGroups, err := client.DescribeDBProxyTargetGroups(&rds.DescribeDBProxyTargetGroupsInput{
+		DBProxyName:     awssdk.String(proxyName),
+		TargetGroupName: awssdk.String("default"),
+	})
+	if err != nil {
+		return RDSProxyConfig{}, err
+	}
+	if len(targetGroups.TargetGroups) == 0 || targetGroups.TargetGroups[0].ConnectionPoolConfig == nil {
+		return RDSProxyConfig{}, fmt.Errorf("default target group not found for RDS proxy %s", proxyName)
+	}
+
+	return RDSProxyConfig{
+		Name:                    awssdk.StringValue(proxy.DBProxyName),
+		Endpoint:                awssdk.StringValue(proxy.Endpoint),
+		RequireTLS:              awssdk.BoolValue(proxy.RequireTLS),
+		IdleClientTimeout:       awssdk.Int64Value(proxy.IdleClientTimeout),
+		ConnectionBorrowTimeout: awssdk.Int64Value(targetGroups.TargetGroups[0].ConnectionPoolConfig.ConnectionBorrowTimeout),
+	}, nil
+}
+
+// rdsStatusPollInterval is how often WaitForRDSAvailable checks the instance status
+const rdsStatusPollInterval = 30 * time.Second
+
+// WaitForRDSAvailable blocks until the DB instance reports "available", failing the test after timeout
+func WaitForRDSAvailable(t testing.TestingT, region string, dbIdentifier string, timeout time.Duration) {
+	err := WaitForRDSAvailableE(t, region, dbIdentifier, timeout)
+	require.NoError(t, err)
+}
+
+// WaitForRDSAvailableE blocks until the DB instance reports "available", or returns an error after timeout
+func WaitForRDSAvailableE(t testing.TestingT, region string, dbIdentifier string, timeout time.Duration) error {
+	// Apply can return while the instance is still creating, modifying or backing up
+	maxRetries := int(timeout / rdsStatusPollInterval)
+	if maxRetries < 1 {
+		maxRetries = 1
+	}
+
+	_, err := retry.DoWithRetryE(t, fmt.Sprintf("Wait for RDS instance %s to be available", dbIdentifier), maxRetries, rdsStatusPollInterval, func() (string, error) {
+		instance, err := aws.GetRdsInstanceDetailsE(t, dbIdentifier, region)
+		if err != nil {
+			return "", err
+		}
+		status := awssdk.StringValue(instance.DBInstanceStatus)
+		if status != "available" {
+			return "", fmt.Errorf("DB instance %s is %s", dbIdentifier, status)
+		}
+		return status, nil
+	})
+	return err
+}
+
+// GetRDSParameterGroup returns the name of the parameter group the live DB instance references
+func GetRDSParameterGroup(t testing.TestingT, region string, dbIdentifier string) string {
+	parameterGroup, err := GetRDSParameterGroupE(t, region, dbIdentifier)
+	require.NoError(t, err)
+	return parameterGroup
+}
+
+// GetRDSParameterGroupE returns the name of the parameter group the live DB instance references
+func GetRDSParameterGroupE(t testing.TestingT, region string, dbIdentifier string) (string, error) {
+	instance, err := aws.GetRdsInstanceDetailsE(t, dbIdentifier, region)
+	if err != nil {
+		return "", err
+	}
+	if len(instance.DBParameterGroups) == 0 {
+		return "", fmt.Errorf("DB instance %s has no parameter group in %s", dbIdentifier, region)
+	}
+
+	return awssdk.StringValue(instance.DBParameterGroups[0].DBParameterGroupName), nil
+}
+
+// GetRDSMultiAZ reports whether the live DB instance has a Multi-AZ standby
+func GetRDSMultiAZ(t testing.TestingT, region string, dbIdentifier string) bool {
+	multiAZ, err := GetRDSMultiAZE(t, region, dbIdentifier)
+	require.NoError(t, err)
+	return multiAZ
+}
+
+// GetRDSMultiAZE reports whether the live DB instance has a Multi-AZ standby
+func GetRDSMultiAZE(t testing.TestingT, region string, dbIdentifier string) (bool, error) {
+	instance, err := aws.GetRdsInstanceDetailsE(t, dbIdentifier, region)
+	if err != nil {
+		return false, err
+	}
+
+	return awssdk.BoolValue(instance.MultiAZ), nil
+}
+
+// GetRDSMaxAllocatedStorage returns the storage autoscaling ceiling in GB set on the live DB instance
+func GetRDSMaxAllocatedStorage(t testing.TestingT, region string, dbIdentifier string) int {
+	maxAllocatedStorage, err := GetRDSMaxAllocatedStorageE(t, region, dbIdentifier)
+	require.NoError(t, err)
+	return maxAllocatedStorage
+}
+
+// GetRDSMaxAllocatedStorageE returns the storage autoscaling ceiling in GB set on the live DB instance
+func GetRDSMaxAllocatedStorageE(t testing.TestingT, region string, dbIdentifier string) (int, error) {
+	instance, err := aws.GetRdsInstanceDetailsE(t, dbIdentifier, region)
+	if err != nil {
+		return 0, err
+	}
+	if instance.MaxAllocatedStorage == nil {
+		return 0, fmt.Errorf("DB instance %s has storage autoscaling disabled in %s", dbIdentifier, region)
+	}
+
+	return int(awssdk.Int64Value(instance.MaxAllocatedStorage)), nil
+}
+
+// GetAuroraCluster returns the description of an Aurora DB cluster
+func GetAuroraCluster(t testing.TestingT, region string, clusterIdentifier string) *rds.DBCluster {
+	cluster, err := GetAuroraClusterE(t, region, clusterIdentifier)
+	require.NoError(t, err)
+	return cluster
+}
+
+// GetAuroraClusterE returns the description of an Aurora DB cluster
+func GetAuroraClusterE(t testing.TestingT, region string, clusterIdentifier string) (*rds.DBCluster, error) {
+	client, err := NewRDSClientE(t, region)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.DescribeDBClusters(&rds.DescribeDBClustersInput{
+		DBClusterIdentifier: awssdk.String(clusterIdentifier),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.DBClusters) == 0 {
+		return nil, fmt.Errorf("DB cluster %s not found in %s", clusterIdentifier, region)
+	}
+
+	return output.DBClusters[0], nil
+}
@@ -0,0 +1,15 @@
+package helpers
+
+import (
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/hipaa-compliant-stack/terraform/tests/pkg/stackoutputs"
+)
+
+// StackOutputs is the typed view of every root stack output
+type StackOutputs = stackoutputs.Outputs
+
+// ParseStackOutputs unmarshals terraform output -json for the root stack into StackOutputs, returning an error if any root output lacks a field or vice versa
+func ParseStackOutputs(t testing.TestingT, options *terraform.Options) (StackOutputs, error) {
+	return stackoutputs.LoadE(t, options)
+}
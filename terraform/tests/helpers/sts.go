@@ -0,0 +1,47 @@
+package helpers
+
+import (
+	"fmt"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// AssumeRoleSession returns a session holding credentials of roleARN, assumed with the given external ID
+func AssumeRoleSession(t testing.TestingT, region string, roleARN string, externalID string) *session.Session {
+	sess, err := AssumeRoleSessionE(t, region, roleARN, externalID)
+	require.NoError(t, err)
+	return sess
+}
+
+// AssumeRoleSessionE returns a session holding credentials of roleARN, assumed with the given external ID
+func AssumeRoleSessionE(t testing.TestingT, region string, roleARN string, externalID string) (*session.Session, error) {
+	baseSession, err := aws.NewAuthenticatedSession(region)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials := stscreds.NewCredentials(baseSession, roleARN, func(provider *stscreds.AssumeRoleProvider) {
+		provider.RoleSessionName = "terratest"
+		if externalID != "" {
+			provider.ExternalID = awssdk.String(externalID)
+		}
+	})
+
+	// A role created moments ago may not be assumable until IAM propagates it
+	_, err = retry.DoWithRetryE(t, fmt.Sprintf("Assume %s", roleARN), 10, 5*time.Second, func() (string, error) {
+		_, err := credentials.Get()
+		return "", err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return session.NewSession(awssdk.NewConfig().WithRegion(region).WithCredentials(credentials))
+}
@@ -0,0 +1,159 @@
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// GetResourceTags returns the tags on any taggable resource identified by ARN
+func GetResourceTags(t testing.TestingT, region string, resourceArn string) map[string]string {
+	tags, err := GetResourceTagsE(t, region, resourceArn)
+	require.NoError(t, err)
+	return tags
+}
+
+// GetResourceTagsE returns the tags on any taggable resource identified by ARN
+func GetResourceTagsE(t testing.TestingT, region string, resourceArn string) (map[string]string, error) {
+	sess, err := aws.NewAuthenticatedSession(region)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := resourcegroupstaggingapi.New(sess).GetResources(&resourcegroupstaggingapi.GetResourcesInput{
+		ResourceARNList: awssdk.StringSlice([]string{resourceArn}),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.ResourceTagMappingList) == 0 {
+		return nil, fmt.Errorf("no tags found for %s", resourceArn)
+	}
+
+	tags := map[string]string{}
+	for _, tag := range output.ResourceTagMappingList[0].Tags {
+		tags[awssdk.StringValue(tag.Key)] = awssdk.StringValue(tag.Value)
+	}
+	return tags, nil
+}
+
+// AssertRequiredTags fails the test if any of the required tag keys is missing or empty on the resource
+func AssertRequiredTags(t testing.TestingT, region string, resourceArn string, requiredKeys ...string) {
+	tags := GetResourceTags(t, region, resourceArn)
+	for _, key := range requiredKeys {
+		require.NotEmpty(t, tags[key], "Resource %s is missing required tag %s", resourceArn, key)
+	}
+}
+
+// TagPolicy is an AWS Organizations tag policy: for each tag key, the values resources may carry
+type TagPolicy struct {
+	Tags map[string]TagPolicyRule `json:"tags"`
+}
+
+// TagPolicyRule is one key of a tag policy in the Organizations @@assign syntax
+type TagPolicyRule struct {
+	TagKey struct {
+		Assign string `json:"@@assign"`
+	} `json:"tag_key"`
+	TagValue struct {
+		Assign []string `json:"@@assign"`
+	} `json:"tag_value"`
+}
+
+// LoadTagPolicy reads a tag policy JSON document from disk
+func LoadTagPolicy(t testing.TestingT, path string) TagPolicy {
+	policy, err := LoadTagPolicyE(t, path)
+	require.NoError(t, err)
+	return policy
+}
+
+// LoadTagPolicyE reads a tag policy JSON document from disk
+func LoadTagPolicyE(t testing.TestingT, path string) (TagPolicy, error) {
+	var policy TagPolicy
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return policy, err
+	}
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return policy, fmt.Errorf("parsing tag policy %s: %w", path, err)
+	}
+	if len(policy.Tags) == 0 {
+		return policy, fmt.Errorf("tag policy %s defines no tags", path)
+	}
+	return policy, nil
+}
+
+// TagPolicyViolations returns one message per tag whose value the policy does not allow. As with
+// Organizations tag policies, keys match case-insensitively, absent keys are not violations, and an
+// allowed value ending in * matches any value with that prefix.
+func TagPolicyViolations(policy TagPolicy, tags map[string]string) []string {
+	var violations []string
+	for _, rule := range policy.Tags {
+		for key, value := range tags {
+			if !strings.EqualFold(key, rule.TagKey.Assign) {
+				continue
+			}
+			if key != rule.TagKey.Assign {
+				violations = append(violations, fmt.Sprintf("tag key %s should be spelled %s", key, rule.TagKey.Assign))
+			}
+			if len(rule.TagValue.Assign) > 0 && !tagValueAllowed(rule.TagValue.Assign, value) {
+				violations = append(violations, fmt.Sprintf("%s=%s is not one of %v", key, value, rule.TagValue.Assign))
+			}
+		}
+	}
+	sort.Strings(violations)
+	return violations
+}
+
+func tagValueAllowed(allowed []string, value string) bool {
+	for _, candidate := range allowed {
+		if candidate == value || (strings.HasSuffix(candidate, "*") && strings.HasPrefix(value, strings.TrimSuffix(candidate, "*"))) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTaggedResources returns the tags of every resource carrying the given tag, keyed by ARN
+func GetTaggedResources(t testing.TestingT, region string, key string, value string) map[string]map[string]string {
+	resources, err := GetTaggedResourcesE(t, region, key, value)
+	require.NoError(t, err)
+	return resources
+}
+
+// GetTaggedResourcesE returns the tags of every resource carrying the given tag, keyed by ARN
+func GetTaggedResourcesE(t testing.TestingT, region string, key string, value string) (map[string]map[string]string, error) {
+	sess, err := aws.NewAuthenticatedSession(region)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := map[string]map[string]string{}
+	err = resourcegroupstaggingapi.New(sess).GetResourcesPages(&resourcegroupstaggingapi.GetResourcesInput{
+		TagFilters: []*resourcegroupstaggingapi.TagFilter{{
+			Key:    awssdk.String(key),
+			Values: awssdk.StringSlice([]string{value}),
+		}},
+	}, func(page *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
+		for _, mapping := range page.ResourceTagMappingList {
+			tags := map[string]string{}
+			for _, tag := range mapping.Tags {
+				tags[awssdk.StringValue(tag.Key)] = awssdk.StringValue(tag.Value)
+			}
+			resources[awssdk.StringValue(mapping.ResourceARN)] = tags
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
@@ -0,0 +1,110 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// wafResource holds the scope, name and ID encoded in a WAFv2 ARN
+type wafResource struct {
+	scope string
+	name  string
+	id    string
+}
+
+// parseWAFARN splits a WAFv2 ARN (arn:aws:wafv2:<region>:<account>:<scope>/<type>/<name>/<id>) into its lookup fields
+func parseWAFARN(arn string) (wafResource, error) {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 {
+		return wafResource{}, fmt.Errorf("%s is not a WAFv2 ARN", arn)
+	}
+	resource := strings.Split(parts[5], "/")
+	if len(resource) != 4 {
+		return wafResource{}, fmt.Errorf("%s is not a WAFv2 ARN", arn)
+	}
+
+	scope := wafv2.ScopeRegional
+	if resource[0] == "global" {
+		scope = wafv2.ScopeCloudfront
+	}
+
+	return wafResource{scope: scope, name: resource[2], id: resource[3]}, nil
+}
+
+// NewWAFv2ClientE returns a WAFv2 client for the given region
+func NewWAFv2ClientE(t testing.TestingT, region string) (*wafv2.WAFV2, error) {
+	sess, err := aws.NewAuthenticatedSession(region)
+	if err != nil {
+		return nil, err
+	}
+
+	return wafv2.New(sess), nil
+}
+
+// GetWAFIPSet returns the WAFv2 IP set with the given ARN
+func GetWAFIPSet(t testing.TestingT, region string, ipSetARN string) *wafv2.IPSet {
+	ipSet, err := GetWAFIPSetE(t, region, ipSetARN)
+	require.NoError(t, err)
+	return ipSet
+}
+
+// GetWAFIPSetE returns the WAFv2 IP set with the given ARN
+func GetWAFIPSetE(t testing.TestingT, region string, ipSetARN string) (*wafv2.IPSet, error) {
+	resource, err := parseWAFARN(ipSetARN)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewWAFv2ClientE(t, region)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.GetIPSet(&wafv2.GetIPSetInput{
+		Id:    awssdk.String(resource.id),
+		Name:  awssdk.String(resource.name),
+		Scope: awssdk.String(resource.scope),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output.IPSet, nil
+}
+
+// GetWAFWebACL returns the WAFv2 web ACL with the given ARN
+func GetWAFWebACL(t testing.TestingT, region string, webACLARN string) *wafv2.WebACL {
+	webACL, err := GetWAFWebACLE(t, region, webACLARN)
+	require.NoError(t, err)
+	return webACL
+}
+
+// GetWAFWebACLE returns the WAFv2 web ACL with the given ARN
+func GetWAFWebACLE(t testing.TestingT, region string, webACLARN string) (*wafv2.WebACL, error) {
+	resource, err := parseWAFARN(webACLARN)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewWAFv2ClientE(t, region)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.GetWebACL(&wafv2.GetWebACLInput{
+		Id:    awssdk.String(resource.id),
+		Name:  awssdk.String(resource.name),
+		Scope: awssdk.String(resource.scope),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output.WebACL, nil
+}
@@ -0,0 +1,30 @@
+package helpers
+
+import (
+	"github.com/aws/aws-sdk-go/service/xray"
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// GetXRayEncryptionConfig returns the X-Ray encryption configuration of the account in a region
+func GetXRayEncryptionConfig(t testing.TestingT, region string) *xray.EncryptionConfig {
+	config, err := GetXRayEncryptionConfigE(t, region)
+	require.NoError(t, err)
+	return config
+}
+
+// GetXRayEncryptionConfigE returns the X-Ray encryption configuration of the account in a region
+func GetXRayEncryptionConfigE(t testing.TestingT, region string) (*xray.EncryptionConfig, error) {
+	sess, err := aws.NewAuthenticatedSession(region)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := xray.New(sess).GetEncryptionConfig(&xray.GetEncryptionConfigInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	return output.EncryptionConfig, nil
+}
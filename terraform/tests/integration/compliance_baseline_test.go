@@ -0,0 +1,156 @@
+package test
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hipaa-compliant-stack/terraform/tests/helpers"
+	"github.com/hipaa-compliant-stack/terraform/tests/pkg/stackoutputs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ==============================================================================
+// Compliance Baseline Diff
+// ==============================================================================
+// Serializes the security-relevant settings of a deployed stack into
+// normalized JSON and diffs it against the approved posture in
+// testdata/baseline.json. Values are keyed by role, never by generated
+// resource names, so the baseline is stable across runs. After an intended
+// posture change, regenerate the baseline and review its diff:
+//
+//	go test ./integration -run TestComplianceBaselineDiff -update-baseline
+
+var updateBaseline = flag.Bool("update-baseline", false, "rewrite testdata/baseline.json from the deployed stack")
+
+const baselinePath = "testdata/baseline.json"
+
+// TestComplianceBaselineDiff verifies the deployed security posture matches the committed baseline
+func TestComplianceBaselineDiff(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping compliance baseline test in short mode")
+	}
+
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../",
+		Vars: map[string]interface{}{
+			"aws_region":            awsRegion,
+			"environment":           "dev",
+			"name_suffix":           nameSuffix,
+			"enable_nat_gateway":    false,
+			"rds_instance_class":    "db.t3.micro",
+			"rds_allocated_storage": 20,
+			"allow_destroy":         true,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+	outputs := stackoutputs.Load(t, terraformOptions)
+
+	actual, err := json.MarshalIndent(collectSecurityPosture(t, awsRegion, outputs), "", "  ")
+	require.NoError(t, err)
+	actual = append(actual, '\n')
+
+	if *updateBaseline {
+		require.NoError(t, os.MkdirAll(filepath.Dir(baselinePath), 0755))
+		require.NoError(t, os.WriteFile(baselinePath, actual, 0644))
+		t.Logf("Updated %s; review the diff before committing", baselinePath)
+		return
+	}
+
+	expected, err := os.ReadFile(baselinePath)
+	require.NoError(t, err, "Baseline missing; generate it with -update-baseline")
+	assert.JSONEq(t, string(expected), string(actual),
+		"Security posture drifted from %s; if intended, rerun with -update-baseline and commit the reviewed diff", baselinePath)
+}
+
+// collectSecurityPosture returns the encryption, public access, rotation and logging settings of the stack keyed by role
+func collectSecurityPosture(t *testing.T, region string, outputs stackoutputs.Outputs) map[string]interface{} {
+	s3Client := aws.NewS3Client(t, region)
+
+	buckets := map[string]interface{}{}
+	for role, bucket := range map[string]string{
+		"documents":  outputs.S3DocumentsBucket,
+		"backups":    outputs.S3BackupsBucket,
+		"audit_logs": outputs.S3AuditLogsBucket,
+	} {
+		encryption, err := s3Client.GetBucketEncryption(&s3.GetBucketEncryptionInput{Bucket: awssdk.String(bucket)})
+		require.NoError(t, err)
+		rule := encryption.ServerSideEncryptionConfiguration.Rules[0]
+
+		versioning, err := s3Client.GetBucketVersioning(&s3.GetBucketVersioningInput{Bucket: awssdk.String(bucket)})
+		require.NoError(t, err)
+
+		publicAccess, err := s3Client.GetPublicAccessBlock(&s3.GetPublicAccessBlockInput{Bucket: awssdk.String(bucket)})
+		require.NoError(t, err)
+		block := publicAccess.PublicAccessBlockConfiguration
+
+		logging, err := s3Client.GetBucketLogging(&s3.GetBucketLoggingInput{Bucket: awssdk.String(bucket)})
+		require.NoError(t, err)
+
+		buckets[role] = map[string]interface{}{
+			"sse_algorithm":           awssdk.StringValue(rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm),
+			"bucket_key_enabled":      awssdk.BoolValue(rule.BucketKeyEnabled),
+			"versioning":              awssdk.StringValue(versioning.Status),
+			"block_public_acls":       awssdk.BoolValue(block.BlockPublicAcls),
+			"block_public_policy":     awssdk.BoolValue(block.BlockPublicPolicy),
+			"ignore_public_acls":      awssdk.BoolValue(block.IgnorePublicAcls),
+			"restrict_public_buckets": awssdk.BoolValue(block.RestrictPublicBuckets),
+			"access_logging":          logging.LoggingEnabled != nil,
+		}
+	}
+
+	rotation, err := aws.NewKmsClient(t, region).GetKeyRotationStatus(&kms.GetKeyRotationStatusInput{
+		KeyId: awssdk.String(outputs.KMSMasterKeyID),
+	})
+	require.NoError(t, err)
+
+	arnParts := strings.Split(outputs.RDSARN, ":")
+	helpers.WaitForRDSAvailable(t, region, arnParts[len(arnParts)-1], 20*time.Minute)
+	database, err := aws.GetRdsInstanceDetailsE(t, arnParts[len(arnParts)-1], region)
+	require.NoError(t, err)
+
+	trail := helpers.GetCloudTrailConfig(t, region, outputs.CloudTrailName)
+	recorder := helpers.GetConfigurationRecorderStatus(t, region, outputs.ConfigRecorderName)
+
+	return map[string]interface{}{
+		"s3": buckets,
+		"kms": map[string]interface{}{
+			"rotation_enabled": awssdk.BoolValue(rotation.KeyRotationEnabled),
+			"key_state":        helpers.GetKMSKeyState(t, region, outputs.KMSMasterKeyID),
+		},
+		"rds": map[string]interface{}{
+			"storage_encrypted":   awssdk.BoolValue(database.StorageEncrypted),
+			"publicly_accessible": awssdk.BoolValue(database.PubliclyAccessible),
+			"iam_auth_enabled":    awssdk.BoolValue(database.IAMDatabaseAuthenticationEnabled),
+			"automated_backups":   awssdk.Int64Value(database.BackupRetentionPeriod) > 0,
+		},
+		"cloudtrail": map[string]interface{}{
+			"kms_encrypted":       trail.KMSKeyID != "",
+			"log_file_validation": trail.LogFileValidationEnabled,
+			"multi_region":        trail.IsMultiRegionTrail,
+		},
+		"config": map[string]interface{}{
+			"recording": awssdk.BoolValue(recorder.Recording),
+		},
+	}
+}
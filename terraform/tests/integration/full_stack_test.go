@@ -1,14 +1,15 @@
 package test
 
 import (
-	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/gruntwork-io/terratest/modules/aws"
-	"github.com/gruntwork-io/terratest/modules/random"
 	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hipaa-compliant-stack/terraform/tests/helpers"
+	"github.com/hipaa-compliant-stack/terraform/tests/pkg/stackoutputs"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -38,9 +39,8 @@ func TestFullStackDeployment(t *testing.T) {
 
 	awsRegion := "us-east-1"
 	expectedAccountID := aws.GetAccountId(t)
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("integ-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../",
@@ -59,6 +59,7 @@ func TestFullStackDeployment(t *testing.T) {
 			"rds_multi_az":            false,
 			"rds_enable_read_replica": false,
 			"rds_deletion_protection": false,
+			"allow_destroy":           true,
 			"rds_backup_retention_days": 7,
 
 			// S3 configuration
@@ -89,27 +90,20 @@ func TestFullStackDeployment(t *testing.T) {
 	// Deploy the full stack
 	t.Log("Deploying full infrastructure stack... this will take 15-20 minutes")
 	terraform.InitAndApply(t, terraformOptions)
+	outputs := stackoutputs.Load(t, terraformOptions)
 
 	// ===== VPC Validation =====
 	t.Run("VPC Resources", func(t *testing.T) {
-		vpcID := terraform.Output(t, terraformOptions, "vpc_id")
-		assert.NotEmpty(t, vpcID)
-
-		privateSubnetIDs := terraform.OutputList(t, terraformOptions, "private_subnet_ids")
-		assert.Len(t, privateSubnetIDs, 3, "Expected 3 private subnets")
-
-		publicSubnetIDs := terraform.OutputList(t, terraformOptions, "public_subnet_ids")
-		assert.Len(t, publicSubnetIDs, 3, "Expected 3 public subnets")
+		assert.NotEmpty(t, outputs.VPCID)
+		assert.Len(t, outputs.PrivateSubnetIDs, 3, "Expected 3 private subnets")
+		assert.Len(t, outputs.PublicSubnetIDs, 3, "Expected 3 public subnets")
 	})
 
 	// ===== KMS Validation =====
 	t.Run("KMS Encryption", func(t *testing.T) {
-		kmsKeyID := terraform.Output(t, terraformOptions, "kms_master_key_id")
-		kmsKeyARN := terraform.Output(t, terraformOptions, "kms_master_key_arn")
-
-		assert.NotEmpty(t, kmsKeyID)
-		assert.NotEmpty(t, kmsKeyARN)
-		assert.Contains(t, kmsKeyARN, "arn:aws:kms")
+		assert.NotEmpty(t, outputs.KMSMasterKeyID)
+		assert.NotEmpty(t, outputs.KMSMasterKeyARN)
+		assert.Contains(t, outputs.KMSMasterKeyARN, "arn:aws:kms")
 
 		// Verify key rotation is enabled
 		// Note: GetKMSKeyMetadata not available in Terratest aws module
@@ -120,14 +114,10 @@ func TestFullStackDeployment(t *testing.T) {
 
 	// ===== S3 Validation =====
 	t.Run("S3 Buckets", func(t *testing.T) {
-		documentsBucket := terraform.Output(t, terraformOptions, "s3_bucket_documents")
-		backupsBucket := terraform.Output(t, terraformOptions, "s3_bucket_backups")
-		auditLogsBucket := terraform.Output(t, terraformOptions, "s3_bucket_audit_logs")
-
 		// Verify bucket names
-		assert.NotEmpty(t, documentsBucket)
-		assert.NotEmpty(t, backupsBucket)
-		assert.NotEmpty(t, auditLogsBucket)
+		assert.NotEmpty(t, outputs.S3DocumentsBucket)
+		assert.NotEmpty(t, outputs.S3BackupsBucket)
+		assert.NotEmpty(t, outputs.S3AuditLogsBucket)
 
 		// Note: S3 bucket property verification functions not available in Terratest aws module
 		// Would require direct AWS SDK calls - see unit tests for detailed bucket validation
@@ -138,18 +128,17 @@ func TestFullStackDeployment(t *testing.T) {
 
 	// ===== RDS Validation =====
 	t.Run("RDS Database", func(t *testing.T) {
-		rdsEndpoint := terraform.Output(t, terraformOptions, "rds_endpoint")
-		rdsDBName := terraform.Output(t, terraformOptions, "rds_db_name")
-		rdsARN := terraform.Output(t, terraformOptions, "rds_arn")
-
-		assert.NotEmpty(t, rdsEndpoint)
-		assert.NotEmpty(t, rdsDBName)
-		assert.NotEmpty(t, rdsARN)
-		assert.Contains(t, rdsARN, "arn:aws:rds")
+		assert.NotEmpty(t, outputs.RDSEndpoint)
+		assert.NotEmpty(t, outputs.RDSDBName)
+		assert.NotEmpty(t, outputs.RDSARN)
+		assert.Contains(t, outputs.RDSARN, "arn:aws:rds")
 
 		// Extract DB instance identifier from endpoint
 		// Format: identifier.randomstring.region.rds.amazonaws.com:5432
-		assert.Contains(t, rdsEndpoint, ".rds.amazonaws.com:5432")
+		assert.Contains(t, outputs.RDSEndpoint, ".rds.amazonaws.com:5432")
+
+		arnParts := strings.Split(outputs.RDSARN, ":")
+		helpers.WaitForRDSAvailable(t, awsRegion, arnParts[len(arnParts)-1], 20*time.Minute)
 	})
 
 	// ===== Security Groups Validation =====
@@ -165,38 +154,27 @@ func TestFullStackDeployment(t *testing.T) {
 
 	// ===== IAM Validation =====
 	t.Run("IAM Roles", func(t *testing.T) {
-		appIAMRoleARN := terraform.Output(t, terraformOptions, "app_iam_role_arn")
-		appIAMRoleName := terraform.Output(t, terraformOptions, "app_iam_role_name")
-
-		assert.NotEmpty(t, appIAMRoleARN)
-		assert.NotEmpty(t, appIAMRoleName)
-		assert.Contains(t, appIAMRoleARN, "arn:aws:iam")
-		assert.Contains(t, appIAMRoleName, "hipaa-app-backend")
+		assert.NotEmpty(t, outputs.AppIAMRoleARN)
+		assert.NotEmpty(t, outputs.AppIAMRoleName)
+		assert.Contains(t, outputs.AppIAMRoleARN, "arn:aws:iam")
+		assert.Contains(t, outputs.AppIAMRoleName, "hipaa-app-backend")
 	})
 
 	// ===== AWS Config Validation =====
 	t.Run("AWS Config", func(t *testing.T) {
-		configRecorderName := terraform.Output(t, terraformOptions, "config_recorder_name")
-		configSNSTopicARN := terraform.Output(t, terraformOptions, "config_sns_topic_arn")
-
-		assert.NotEmpty(t, configRecorderName)
-		assert.NotEmpty(t, configSNSTopicARN)
-		assert.Contains(t, configRecorderName, nameSuffix)
-		assert.Contains(t, configSNSTopicARN, "arn:aws:sns")
+		assert.NotEmpty(t, outputs.ConfigRecorderName)
+		assert.NotEmpty(t, outputs.ConfigSNSTopicARN)
+		assert.Contains(t, outputs.ConfigRecorderName, nameSuffix)
+		assert.Contains(t, outputs.ConfigSNSTopicARN, "arn:aws:sns")
 	})
 
 	// ===== Integration Points Validation =====
 	t.Run("Cross-Module Integration", func(t *testing.T) {
-		// Verify VPC ID is used in multiple modules
-		vpcID := terraform.Output(t, terraformOptions, "vpc_id")
-
 		// Verify KMS key is used for RDS and S3 encryption
-		kmsKeyARN := terraform.Output(t, terraformOptions, "kms_master_key_arn")
-		assert.NotEmpty(t, kmsKeyARN)
+		assert.NotEmpty(t, outputs.KMSMasterKeyARN)
 
 		// Verify S3 bucket ARNs are used in IAM policies
-		documentsBucketARN := terraform.Output(t, terraformOptions, "s3_bucket_documents_arn")
-		assert.Contains(t, documentsBucketARN, "arn:aws:s3:::")
+		assert.Contains(t, outputs.S3DocumentsBucketARN, "arn:aws:s3:::")
 
 		// Verify security groups reference each other correctly
 		rdsSecurityGroupID := terraform.Output(t, terraformOptions, "rds_security_group_id")
@@ -204,9 +182,9 @@ func TestFullStackDeployment(t *testing.T) {
 		assert.NotEqual(t, rdsSecurityGroupID, appSecurityGroupID)
 
 		t.Logf("Successfully validated cross-module integration:")
-		t.Logf("  - VPC ID: %s", vpcID)
-		t.Logf("  - KMS Key ARN: %s", kmsKeyARN)
-		t.Logf("  - Documents Bucket ARN: %s", documentsBucketARN)
+		t.Logf("  - VPC ID: %s", outputs.VPCID)
+		t.Logf("  - KMS Key ARN: %s", outputs.KMSMasterKeyARN)
+		t.Logf("  - Documents Bucket ARN: %s", outputs.S3DocumentsBucketARN)
 	})
 
 	// ===== Outputs JSON Validation =====
@@ -231,5 +209,64 @@ func TestFullStackDeployment(t *testing.T) {
 		}
 	})
 
+	// ===== Typed Outputs Validation =====
+	t.Run("Typed Outputs", func(t *testing.T) {
+		typed, err := helpers.ParseStackOutputs(t, terraformOptions)
+		require.NoError(t, err, "Every root output should map to a StackOutputs field and back")
+
+		// Outputs that are empty because this test leaves their feature disabled
+		emptyByConfiguration := map[string]string{
+			"aurora_cluster_endpoint":                  "rds_engine_type is postgres",
+			"aurora_reader_endpoint":                   "rds_engine_type is postgres",
+			"rds_reader_endpoint":                      "rds_enable_read_replica is false",
+			"rds_reporting_endpoint":                   "no reporting replica",
+			"rds_proxy_endpoint":                       "enable_rds_proxy is false",
+			"rds_proxy_reader_endpoint":                "enable_rds_proxy is false",
+			"rds_snapshot_copy_configuration":          "snapshot_copy_account_id is unset",
+			"rds_schedule_expressions":                 "enable_rds_scheduling is false",
+			"data_masking_status":                      "enable_data_masking is false",
+			"data_masking_isolation_security_group_id": "enable_data_masking is false",
+			"canary_bucket_name":                       "create_canary_bucket is false",
+			"quarantine_bucket_arn":                    "create_quarantine_bucket is false",
+			"s3_bucket_documents_replica":              "replica_region is unset",
+			"kms_replica_key_arn":                      "replica_region is unset",
+			"network_firewall_rule_group_arn":          "enable_network_firewall is false",
+			"nat_gateway_ids":                          "enable_nat_gateway is false",
+			"nat_gateway_eips":                         "enable_nat_gateway is false",
+			"waf_ip_set_arn":                           "enable_waf is false",
+			"privatelink_endpoint_service_name":        "enable_privatelink is false",
+			"config_aggregator_arn":                    "enable_config_aggregator is false",
+			"central_alarm_topic_arn":                  "central_alarm_region is unset",
+			"siem_firehose_arn":                        "enable_siem_forwarding is false",
+			"xray_encryption_key_arn":                  "enable_xray_tracing is false",
+			"railway_env_file":                         "write_env_file is false",
+			"missing_cost_tags":                        "lists absent cost tags, so empty is the healthy value",
+		}
+
+		value := reflect.ValueOf(typed)
+		fields := map[string]bool{}
+		for i := 0; i < value.NumField(); i++ {
+			name := value.Type().Field(i).Tag.Get("json")
+			fields[name] = true
+			if _, ok := emptyByConfiguration[name]; ok {
+				continue
+			}
+
+			field := value.Field(i)
+			switch field.Kind() {
+			case reflect.Bool:
+				// false is a legitimate value; presence is enforced by ParseStackOutputs
+			case reflect.Slice, reflect.Map:
+				assert.NotZero(t, field.Len(), "Output %s should not be empty after apply", name)
+			default:
+				assert.False(t, field.IsZero(), "Output %s should be populated after apply", name)
+			}
+		}
+
+		for name := range emptyByConfiguration {
+			assert.True(t, fields[name], "Exempted output %s is not a StackOutputs field", name)
+		}
+	})
+
 	t.Log("Full stack integration test completed successfully!")
 }
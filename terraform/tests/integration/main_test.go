@@ -0,0 +1,31 @@
+package test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hipaa-compliant-stack/terraform/tests/helpers"
+)
+
+// ==============================================================================
+// Test Binary Setup
+// ==============================================================================
+// Integration tests deploy the full stack in parallel under name_suffix values
+// from helpers.UniqueNameSuffix. TestMain fails the run if any test was refused
+// a suffix another test already held, as in the unit package.
+// ==============================================================================
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+
+	if collisions := helpers.NameSuffixCollisions(); len(collisions) > 0 {
+		fmt.Fprintf(os.Stderr, "name_suffix collisions between tests:\n  %s\n", strings.Join(collisions, "\n  "))
+		if code == 0 {
+			code = 1
+		}
+	}
+
+	os.Exit(code)
+}
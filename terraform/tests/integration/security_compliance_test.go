@@ -1,14 +1,26 @@
 package test
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"strings"
 	"testing"
+	"time"
 
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/configservice"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/gruntwork-io/terratest/modules/aws"
-	"github.com/gruntwork-io/terratest/modules/random"
+	"github.com/gruntwork-io/terratest/modules/retry"
 	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hipaa-compliant-stack/terraform/tests/helpers"
+	"github.com/hipaa-compliant-stack/terraform/tests/pkg/stackoutputs"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // ==============================================================================
@@ -28,14 +40,14 @@ func TestHIPAAEncryptionCompliance(t *testing.T) {
 
 	awsRegion := "us-east-1"
 	expectedAccountID := aws.GetAccountId(t)
-	uniqueID := random.UniqueId()
-	environment := fmt.Sprintf("sec-%s", uniqueID)
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../",
 		Vars: map[string]interface{}{
 			"aws_region":              awsRegion,
-			"environment":             environment,
+			"environment":             "dev",
+			"name_suffix":             nameSuffix,
 			"aws_account_id":          expectedAccountID,
 			"enable_nat_gateway":      false,
 			"enable_vpc_endpoints":    true,
@@ -50,13 +62,14 @@ func TestHIPAAEncryptionCompliance(t *testing.T) {
 
 	defer terraform.Destroy(t, terraformOptions)
 	terraform.InitAndApply(t, terraformOptions)
+	outputs := stackoutputs.Load(t, terraformOptions)
 
 	t.Run("S3 Encryption at Rest", func(t *testing.T) {
 		// Verify all S3 buckets use SSE-KMS encryption
 		buckets := []string{
-			terraform.Output(t, terraformOptions, "s3_bucket_documents"),
-			terraform.Output(t, terraformOptions, "s3_bucket_backups"),
-			terraform.Output(t, terraformOptions, "s3_bucket_audit_logs"),
+			outputs.S3DocumentsBucket,
+			outputs.S3BackupsBucket,
+			outputs.S3AuditLogsBucket,
 		}
 
 		for _, bucket := range buckets {
@@ -70,24 +83,22 @@ func TestHIPAAEncryptionCompliance(t *testing.T) {
 
 	t.Run("RDS Encryption at Rest", func(t *testing.T) {
 		// Verify RDS instance has encryption enabled
-		rdsARN := terraform.Output(t, terraformOptions, "rds_arn")
-		assert.NotEmpty(t, rdsARN)
+		assert.NotEmpty(t, outputs.RDSARN)
 
 		// Parse DB instance identifier from ARN
 		// Format: arn:aws:rds:region:account:db:identifier
-		parts := strings.Split(rdsARN, ":")
+		parts := strings.Split(outputs.RDSARN, ":")
 		assert.GreaterOrEqual(t, len(parts), 6, "Invalid RDS ARN format")
 
-		t.Logf("RDS ARN validated: %s", rdsARN)
+		t.Logf("RDS ARN validated: %s", outputs.RDSARN)
 	})
 
 	t.Run("KMS Key Rotation", func(t *testing.T) {
 		// Verify KMS key exists and has proper configuration
-		kmsKeyARN := terraform.Output(t, terraformOptions, "kms_master_key_arn")
-		assert.NotEmpty(t, kmsKeyARN)
-		assert.Contains(t, kmsKeyARN, "arn:aws:kms")
+		assert.NotEmpty(t, outputs.KMSMasterKeyARN)
+		assert.Contains(t, outputs.KMSMasterKeyARN, "arn:aws:kms")
 
-		t.Logf("KMS master key configured: %s", kmsKeyARN)
+		t.Logf("KMS master key configured: %s", outputs.KMSMasterKeyARN)
 	})
 }
 
@@ -101,14 +112,14 @@ func TestNetworkIsolation(t *testing.T) {
 
 	awsRegion := "us-east-1"
 	expectedAccountID := aws.GetAccountId(t)
-	uniqueID := random.UniqueId()
-	environment := fmt.Sprintf("net-%s", uniqueID)
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../",
 		Vars: map[string]interface{}{
 			"aws_region":         awsRegion,
-			"environment":        environment,
+			"environment":        "dev",
+			"name_suffix":        nameSuffix,
 			"aws_account_id":     expectedAccountID,
 			"enable_nat_gateway": false,
 			"rds_instance_class": "db.t3.micro",
@@ -170,14 +181,14 @@ func TestVPCEndpointConnectivity(t *testing.T) {
 
 	awsRegion := "us-east-1"
 	expectedAccountID := aws.GetAccountId(t)
-	uniqueID := random.UniqueId()
-	environment := fmt.Sprintf("vpc-%s", uniqueID)
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../",
 		Vars: map[string]interface{}{
 			"aws_region":           awsRegion,
-			"environment":          environment,
+			"environment":          "dev",
+			"name_suffix":          nameSuffix,
 			"aws_account_id":       expectedAccountID,
 			"enable_nat_gateway":   false,
 			"enable_vpc_endpoints": true,
@@ -216,14 +227,14 @@ func TestIAMLeastPrivilege(t *testing.T) {
 
 	awsRegion := "us-east-1"
 	expectedAccountID := aws.GetAccountId(t)
-	uniqueID := random.UniqueId()
-	environment := fmt.Sprintf("iam-%s", uniqueID)
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../",
 		Vars: map[string]interface{}{
 			"aws_region":         awsRegion,
-			"environment":        environment,
+			"environment":        "dev",
+			"name_suffix":        nameSuffix,
 			"aws_account_id":     expectedAccountID,
 			"enable_nat_gateway": false,
 		},
@@ -246,8 +257,8 @@ func TestIAMLeastPrivilege(t *testing.T) {
 		documentsBucketARN := terraform.Output(t, terraformOptions, "s3_bucket_documents_arn")
 		backupsBucketARN := terraform.Output(t, terraformOptions, "s3_bucket_backups_arn")
 
-		assert.Contains(t, documentsBucketARN, environment)
-		assert.Contains(t, backupsBucketARN, environment)
+		assert.Contains(t, documentsBucketARN, nameSuffix)
+		assert.Contains(t, backupsBucketARN, nameSuffix)
 	})
 
 	t.Run("KMS Key Scoped Access", func(t *testing.T) {
@@ -258,6 +269,167 @@ func TestIAMLeastPrivilege(t *testing.T) {
 	})
 }
 
+// TestIAMPoliciesReferenceLiveARNs verifies the app role's S3 and KMS policies name exactly the buckets and key the stack created
+func TestIAMPoliciesReferenceLiveARNs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping IAM policy wiring test in short mode")
+	}
+
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../",
+		Vars: map[string]interface{}{
+			"aws_region":         awsRegion,
+			"environment":        "dev",
+			"name_suffix":        nameSuffix,
+			"enable_nat_gateway": false,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+	outputs := stackoutputs.Load(t, terraformOptions)
+
+	documents := helpers.GetAttachedPolicyDocuments(t, awsRegion, outputs.AppIAMRoleName)
+
+	// Policies are attached by name; find each by its suffix
+	findPolicy := func(t *testing.T, suffix string) string {
+		for name, document := range documents {
+			if strings.HasSuffix(name, suffix) {
+				return document
+			}
+		}
+		require.Failf(t, "policy not attached", "No policy ending in %s on %s", suffix, outputs.AppIAMRoleName)
+		return ""
+	}
+
+	t.Run("S3 Policy Buckets", func(t *testing.T) {
+		resources := helpers.PolicyResourceARNs(t, findPolicy(t, "-s3-access-policy"), "Allow")
+		require.NotEmpty(t, resources)
+
+		// Object ARNs carry a key path after the bucket; compare the bucket part
+		buckets := map[string]bool{}
+		for _, resource := range resources {
+			buckets[strings.SplitN(resource, "/", 2)[0]] = true
+		}
+
+		expected := map[string]bool{
+			outputs.S3DocumentsBucketARN: true,
+			outputs.S3BackupsBucketARN:   true,
+			outputs.S3AuditLogsBucketARN: true,
+		}
+		assert.Equal(t, expected, buckets, "S3 policy should name exactly the stack's buckets")
+	})
+
+	t.Run("KMS Policy Key", func(t *testing.T) {
+		var keys []string
+		for _, resource := range helpers.PolicyResourceARNs(t, findPolicy(t, "-kms-access-policy"), "Allow") {
+			// Tenant keys are granted by pattern and scoped by tag conditions
+			if !strings.HasSuffix(resource, ":key/*") {
+				keys = append(keys, resource)
+			}
+		}
+
+		assert.Equal(t, []string{outputs.KMSMasterKeyARN}, keys, "KMS policy should name exactly the master key")
+	})
+}
+
+// TestAppRoleLiveBoundaries assumes the app role with its external ID and checks allowed and denied calls against live AWS
+func TestAppRoleLiveBoundaries(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping live IAM boundary test in short mode")
+	}
+
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	// The root module keeps the IAM module's default external ID
+	externalID := "railway-hipaa-app"
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../",
+		Vars: map[string]interface{}{
+			"aws_region":         awsRegion,
+			"environment":        "dev",
+			"name_suffix":        nameSuffix,
+			"enable_nat_gateway": false,
+			"rds_instance_class": "db.t3.micro",
+			"allow_destroy":      true, // Test object is left in the documents bucket
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+	outputs := stackoutputs.Load(t, terraformOptions)
+
+	_, err := helpers.AssumeRoleSessionE(t, awsRegion, outputs.AppIAMRoleARN, "wrong-external-id")
+	require.Error(t, err, "The app role must not be assumable without its external ID")
+
+	appSession := helpers.AssumeRoleSession(t, awsRegion, outputs.AppIAMRoleARN, externalID)
+	appS3 := s3.New(appSession)
+
+	t.Run("Allowed GetObject On Documents", func(t *testing.T) {
+		key := "least-privilege-check/document.txt"
+		content := fmt.Sprintf("least privilege check %s", nameSuffix)
+
+		_, err := aws.NewS3Client(t, awsRegion).PutObject(&s3.PutObjectInput{
+			Bucket:               awssdk.String(outputs.S3DocumentsBucket),
+			Key:                  awssdk.String(key),
+			Body:                 strings.NewReader(content),
+			ServerSideEncryption: awssdk.String(s3.ServerSideEncryptionAwsKms),
+			SSEKMSKeyId:          awssdk.String(outputs.KMSMasterKeyARN),
+		})
+		require.NoError(t, err)
+
+		object, err := appS3.GetObject(&s3.GetObjectInput{
+			Bucket: awssdk.String(outputs.S3DocumentsBucket),
+			Key:    awssdk.String(key),
+		})
+		require.NoError(t, err, "The app role should read documents")
+		defer object.Body.Close()
+
+		body, err := io.ReadAll(object.Body)
+		require.NoError(t, err)
+		assert.Equal(t, content, string(body))
+	})
+
+	t.Run("Denied DeleteBucket On Audit Logs", func(t *testing.T) {
+		_, err := appS3.DeleteBucket(&s3.DeleteBucketInput{
+			Bucket: awssdk.String(outputs.S3AuditLogsBucket),
+		})
+		assertAccessDenied(t, err, "s3:DeleteBucket on the audit bucket")
+	})
+
+	t.Run("Denied ScheduleKeyDeletion", func(t *testing.T) {
+		_, err := kms.New(appSession).ScheduleKeyDeletion(&kms.ScheduleKeyDeletionInput{
+			KeyId:               awssdk.String(outputs.KMSMasterKeyARN),
+			PendingWindowInDays: awssdk.Int64(7),
+		})
+		assertAccessDenied(t, err, "kms:ScheduleKeyDeletion on the master key")
+	})
+}
+
+// assertAccessDenied asserts err is an AWS access-denied error (S3 AccessDenied, KMS AccessDeniedException, ...)
+func assertAccessDenied(t *testing.T, err error, call string) {
+	require.Error(t, err, "%s should be denied", call)
+
+	var awsErr awserr.Error
+	require.True(t, errors.As(err, &awsErr), "%s failed with a non-AWS error: %v", call, err)
+	assert.True(t, strings.HasPrefix(awsErr.Code(), "AccessDenied"), "%s should fail with AccessDenied, got %s", call, awsErr.Code())
+}
+
 // TestAuditLogging verifies audit logging is configured across all services
 func TestAuditLogging(t *testing.T) {
 	if testing.Short() {
@@ -268,14 +440,14 @@ func TestAuditLogging(t *testing.T) {
 
 	awsRegion := "us-east-1"
 	expectedAccountID := aws.GetAccountId(t)
-	uniqueID := random.UniqueId()
-	environment := fmt.Sprintf("audit-%s", uniqueID)
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../",
 		Vars: map[string]interface{}{
 			"aws_region":         awsRegion,
-			"environment":        environment,
+			"environment":        "dev",
+			"name_suffix":        nameSuffix,
 			"aws_account_id":     expectedAccountID,
 			"enable_nat_gateway": false,
 		},
@@ -313,6 +485,56 @@ func TestAuditLogging(t *testing.T) {
 		configRecorderName := terraform.Output(t, terraformOptions, "config_recorder_name")
 		assert.NotEmpty(t, configRecorderName)
 		assert.Contains(t, configRecorderName, "config-recorder")
+
+		// A recorder that exists but was never started collects no compliance data
+		status := helpers.GetConfigurationRecorderStatus(t, awsRegion, configRecorderName)
+		assert.True(t, awssdk.BoolValue(status.Recording), "Config recorder should be recording")
+
+		// The first recording run reports PENDING until it has delivered
+		retry.DoWithRetry(t, "Config recorder last status", 20, 15*time.Second, func() (string, error) {
+			status := helpers.GetConfigurationRecorderStatus(t, awsRegion, configRecorderName)
+			lastStatus := awssdk.StringValue(status.LastStatus)
+			if lastStatus != "SUCCESS" {
+				return "", fmt.Errorf("recorder last status is %s (%s)", lastStatus, awssdk.StringValue(status.LastErrorMessage))
+			}
+			return lastStatus, nil
+		})
+	})
+
+	t.Run("Config Rules Evaluating", func(t *testing.T) {
+		configRules := terraform.OutputMap(t, terraformOptions, "config_rules")
+		require.NotEmpty(t, configRules)
+
+		var ruleNames []string
+		for _, ruleName := range configRules {
+			ruleNames = append(ruleNames, ruleName)
+		}
+
+		// A rule stuck in ERROR or never leaving INSUFFICIENT_DATA reports nothing,
+		// which COMPLIANT-only checks cannot tell apart from a clean account
+		retry.DoWithRetry(t, "Config rules evaluated without errors", 40, 30*time.Second, func() (string, error) {
+			statuses := helpers.GetConfigRuleEvaluationStatuses(t, awsRegion, ruleNames)
+			complianceTypes := helpers.GetConfigRuleComplianceTypes(t, awsRegion, ruleNames)
+
+			var pending []string
+			for _, ruleName := range ruleNames {
+				status, ok := statuses[ruleName]
+				switch {
+				case !ok:
+					pending = append(pending, fmt.Sprintf("%s has no evaluation status", ruleName))
+				case status.LastFailedEvaluationTime != nil && (status.LastSuccessfulEvaluationTime == nil || status.LastFailedEvaluationTime.After(*status.LastSuccessfulEvaluationTime)):
+					pending = append(pending, fmt.Sprintf("%s last failed with %s: %s", ruleName, awssdk.StringValue(status.LastErrorCode), awssdk.StringValue(status.LastErrorMessage)))
+				case status.LastSuccessfulEvaluationTime == nil:
+					pending = append(pending, fmt.Sprintf("%s has not completed an evaluation", ruleName))
+				case complianceTypes[ruleName] == configservice.ComplianceTypeInsufficientData:
+					pending = append(pending, fmt.Sprintf("%s is INSUFFICIENT_DATA", ruleName))
+				}
+			}
+			if len(pending) > 0 {
+				return "", fmt.Errorf("%s", strings.Join(pending, "; "))
+			}
+			return "all rules evaluated", nil
+		})
 	})
 
 	t.Run("Config SNS Topic for Alerts", func(t *testing.T) {
@@ -332,18 +554,19 @@ func TestBackupAndRecovery(t *testing.T) {
 
 	awsRegion := "us-east-1"
 	expectedAccountID := aws.GetAccountId(t)
-	uniqueID := random.UniqueId()
-	environment := fmt.Sprintf("bak-%s", uniqueID)
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../",
 		Vars: map[string]interface{}{
 			"aws_region":                awsRegion,
-			"environment":               environment,
+			"environment":               "dev",
+			"name_suffix":               nameSuffix,
 			"aws_account_id":            expectedAccountID,
 			"enable_nat_gateway":        false,
 			"rds_instance_class":        "db.t3.micro",
 			"rds_backup_retention_days": 7,
+			"allow_destroy":             true, // Recovery check leaves object versions behind
 		},
 		EnvVars: map[string]string{
 			"AWS_DEFAULT_REGION": awsRegion,
@@ -368,6 +591,56 @@ func TestBackupAndRecovery(t *testing.T) {
 		}
 	})
 
+	t.Run("Deleted Object Recoverable", func(t *testing.T) {
+		// Exercise versioning end to end: a delete only adds a marker, and the prior version restores intact
+		documentsBucket := terraform.Output(t, terraformOptions, "s3_bucket_documents")
+		key := "recovery-check/document.txt"
+		content := fmt.Sprintf("recovery check %s", nameSuffix)
+
+		s3Client := aws.NewS3Client(t, awsRegion)
+
+		put, err := s3Client.PutObject(&s3.PutObjectInput{
+			Bucket: awssdk.String(documentsBucket),
+			Key:    awssdk.String(key),
+			Body:   strings.NewReader(content),
+		})
+		require.NoError(t, err)
+		originalVersion := awssdk.StringValue(put.VersionId)
+		require.NotEmpty(t, originalVersion, "Versioned bucket should return a version ID")
+
+		deleted, err := s3Client.DeleteObject(&s3.DeleteObjectInput{
+			Bucket: awssdk.String(documentsBucket),
+			Key:    awssdk.String(key),
+		})
+		require.NoError(t, err)
+		assert.True(t, awssdk.BoolValue(deleted.DeleteMarker), "Delete should create a delete marker")
+
+		_, err = s3Client.GetObject(&s3.GetObjectInput{
+			Bucket: awssdk.String(documentsBucket),
+			Key:    awssdk.String(key),
+		})
+		require.Error(t, err, "Deleted object should no longer be current")
+
+		// Restore by copying the prior version over the delete marker
+		_, err = s3Client.CopyObject(&s3.CopyObjectInput{
+			Bucket:     awssdk.String(documentsBucket),
+			Key:        awssdk.String(key),
+			CopySource: awssdk.String(fmt.Sprintf("%s/%s?versionId=%s", documentsBucket, key, originalVersion)),
+		})
+		require.NoError(t, err, "Prior version should be restorable")
+
+		restored, err := s3Client.GetObject(&s3.GetObjectInput{
+			Bucket: awssdk.String(documentsBucket),
+			Key:    awssdk.String(key),
+		})
+		require.NoError(t, err)
+		defer restored.Body.Close()
+
+		restoredContent, err := io.ReadAll(restored.Body)
+		require.NoError(t, err)
+		assert.Equal(t, content, string(restoredContent), "Restored object should match the original content")
+	})
+
 	t.Run("RDS Automated Backups", func(t *testing.T) {
 		// Verify RDS instance exists (backup configuration is part of instance)
 		rdsEndpoint := terraform.Output(t, terraformOptions, "rds_endpoint")
@@ -386,3 +659,178 @@ func TestBackupAndRecovery(t *testing.T) {
 			encryption.ServerSideEncryptionConfiguration.Rules[0].ApplyServerSideEncryptionByDefault.SSEAlgorithm)
 	})
 }
+
+// TestSeparateBackupKeys verifies use_separate_bucket_keys moves the backups bucket and RDS onto a backup key distinct from the documents key
+func TestSeparateBackupKeys(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping backup key test in short mode")
+	}
+
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../",
+		Vars: map[string]interface{}{
+			"aws_region":               awsRegion,
+			"environment":              "dev",
+			"name_suffix":              nameSuffix,
+			"enable_nat_gateway":       false,
+			"rds_instance_class":       "db.t3.micro",
+			"use_separate_bucket_keys": true,
+			"allow_destroy":            true,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+	outputs := stackoutputs.Load(t, terraformOptions)
+
+	require.NotEmpty(t, outputs.BackupKMSKeyARN)
+	assert.NotEqual(t, outputs.KMSMasterKeyARN, outputs.BackupKMSKeyARN, "Backups must not share the documents key")
+
+	t.Run("Bucket Keys", func(t *testing.T) {
+		bucketKeys := map[string]string{
+			outputs.S3DocumentsBucket: outputs.KMSMasterKeyARN,
+			outputs.S3BackupsBucket:   outputs.BackupKMSKeyARN,
+		}
+
+		for bucket, expectedKey := range bucketKeys {
+			encryption := aws.GetS3BucketEncryption(t, awsRegion, bucket)
+			require.NotNil(t, encryption)
+			rule := encryption.ServerSideEncryptionConfiguration.Rules[0].ApplyServerSideEncryptionByDefault
+			assert.Equal(t, "aws:kms", awssdk.StringValue(rule.SSEAlgorithm))
+			assert.Equal(t, expectedKey, awssdk.StringValue(rule.KMSMasterKeyID), "Bucket %s uses the wrong key", bucket)
+		}
+	})
+
+	t.Run("RDS Storage Key", func(t *testing.T) {
+		// Automated backups and snapshots are encrypted with the instance's storage key
+		arnParts := strings.Split(outputs.RDSARN, ":")
+		helpers.WaitForRDSAvailable(t, awsRegion, arnParts[len(arnParts)-1], 20*time.Minute)
+		database, err := aws.GetRdsInstanceDetailsE(t, arnParts[len(arnParts)-1], awsRegion)
+		require.NoError(t, err)
+
+		assert.True(t, awssdk.BoolValue(database.StorageEncrypted))
+		assert.Equal(t, outputs.BackupKMSKeyARN, awssdk.StringValue(database.KmsKeyId), "RDS should be encrypted with the backup key")
+	})
+}
+
+// TestSeparateLogKey verifies CloudTrail, its log group and Config delivery use the log key rather than the data key when create_log_key is set
+func TestSeparateLogKey(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping log key test in short mode")
+	}
+
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../",
+		Vars: map[string]interface{}{
+			"aws_region":                        awsRegion,
+			"environment":                       "dev",
+			"name_suffix":                       nameSuffix,
+			"enable_nat_gateway":                false,
+			"rds_instance_class":                "db.t3.micro",
+			"create_log_key":                    true,
+			"enable_cloudtrail_cloudwatch_logs": true,
+			"allow_destroy":                     true,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+	outputs := stackoutputs.Load(t, terraformOptions)
+
+	require.NotEmpty(t, outputs.LogKMSKeyARN)
+	assert.NotEqual(t, outputs.KMSMasterKeyARN, outputs.LogKMSKeyARN, "Logs must not share the data key")
+
+	trail := helpers.GetCloudTrailConfig(t, awsRegion, outputs.CloudTrailName)
+
+	t.Run("CloudTrail", func(t *testing.T) {
+		assert.Equal(t, outputs.LogKMSKeyARN, trail.KMSKeyID, "Trail should encrypt log files with the log key")
+	})
+
+	t.Run("Log Group", func(t *testing.T) {
+		// The trail reports its group as arn:...:log-group:<name>:*
+		require.NotEmpty(t, trail.CloudWatchLogsGroupArn)
+		logGroupName := strings.TrimSuffix(strings.SplitN(trail.CloudWatchLogsGroupArn, ":log-group:", 2)[1], ":*")
+
+		logGroup := helpers.GetLogGroup(t, awsRegion, logGroupName)
+		assert.Equal(t, outputs.LogKMSKeyARN, awssdk.StringValue(logGroup.KmsKeyId), "Log group should be encrypted with the log key")
+	})
+
+	t.Run("Config Delivery", func(t *testing.T) {
+		channelName := strings.TrimSuffix(outputs.ConfigRecorderName, "-config-recorder") + "-config-delivery-channel"
+		channel := helpers.GetConfigDeliveryChannel(t, awsRegion, channelName)
+		assert.Equal(t, outputs.LogKMSKeyARN, awssdk.StringValue(channel.S3KmsKeyArn), "Config should encrypt deliveries with the log key")
+	})
+}
+
+// TestPHIDataFlow verifies the phi_data_flow output reports the app-to-RDS path over private networking
+func TestPHIDataFlow(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping PHI data flow test in short mode")
+	}
+
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../",
+		Vars: map[string]interface{}{
+			"aws_region":           awsRegion,
+			"environment":          "dev",
+			"name_suffix":          nameSuffix,
+			"enable_nat_gateway":   false,
+			"enable_vpc_endpoints": true,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	var flow struct {
+		Paths []map[string]interface{} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(terraform.Output(t, terraformOptions, "phi_data_flow")), &flow))
+
+	paths := map[string]map[string]interface{}{}
+	for _, path := range flow.Paths {
+		paths[path["name"].(string)] = path
+	}
+
+	t.Run("App to RDS", func(t *testing.T) {
+		rdsPath, ok := paths["app-to-rds"]
+		require.True(t, ok, "Data flow should include the app-to-rds path")
+		assert.Equal(t, "private-subnet", rdsPath["network_path"], "RDS path must not traverse the internet")
+		assert.NotEqual(t, "internet", rdsPath["network_path"])
+		assert.NotEmpty(t, rdsPath["db_subnet_group"], "RDS path should name the DB subnet group")
+		assert.NotEmpty(t, rdsPath["subnet_ids"], "RDS path should list the private subnets")
+		assert.NotEmpty(t, rdsPath["target_security_group_id"], "RDS path should be gated by the RDS security group")
+		assert.EqualValues(t, 5432, rdsPath["port"])
+	})
+
+	t.Run("App to S3 Documents", func(t *testing.T) {
+		s3Path, ok := paths["app-to-s3-documents"]
+		require.True(t, ok, "Data flow should include the app-to-s3-documents path")
+		assert.Equal(t, "vpc-endpoint", s3Path["network_path"])
+		assert.NotEmpty(t, s3Path["vpc_endpoint_id"], "S3 path should reference the gateway endpoint")
+	})
+}
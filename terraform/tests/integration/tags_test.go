@@ -0,0 +1,135 @@
+package test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hipaa-compliant-stack/terraform/tests/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+// ==============================================================================
+// Tagging Integration Tests
+// ==============================================================================
+// These tests verify cost allocation and governance tags propagate to the
+// billable resources across the full stack. The tag policy test reads an AWS
+// Organizations tag policy from TEST_TAG_POLICY_FILE, defaulting to
+// testdata/tag_policy.json.
+// ==============================================================================
+
+const defaultTagPolicyPath = "testdata/tag_policy.json"
+
+// TestCostAllocationTags verifies CostCenter and Environment tags reach RDS, S3, NAT gateways, and the KMS key
+func TestCostAllocationTags(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping cost allocation tag test in short mode")
+	}
+
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	accountID := aws.GetAccountId(t)
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../",
+		Vars: map[string]interface{}{
+			"aws_region":         awsRegion,
+			"environment":        "dev",
+			"name_suffix":        nameSuffix,
+			"cost_center":        "Engineering",
+			"enable_nat_gateway": true,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	assert.Empty(t, terraform.OutputList(t, terraformOptions, "missing_cost_tags"), "No cost allocation tags should be missing")
+
+	billableResources := map[string]string{
+		"RDS":         terraform.Output(t, terraformOptions, "rds_arn"),
+		"KMS":         terraform.Output(t, terraformOptions, "kms_master_key_arn"),
+		"S3Documents": fmt.Sprintf("arn:aws:s3:::%s", terraform.Output(t, terraformOptions, "s3_bucket_documents")),
+		"S3Backups":   fmt.Sprintf("arn:aws:s3:::%s", terraform.Output(t, terraformOptions, "s3_bucket_backups")),
+		"S3AuditLogs": fmt.Sprintf("arn:aws:s3:::%s", terraform.Output(t, terraformOptions, "s3_bucket_audit_logs")),
+	}
+	for _, natGatewayID := range terraform.OutputList(t, terraformOptions, "nat_gateway_ids") {
+		billableResources["NAT-"+natGatewayID] = fmt.Sprintf("arn:aws:ec2:%s:%s:natgateway/%s", awsRegion, accountID, natGatewayID)
+	}
+
+	for name, resourceArn := range billableResources {
+		t.Run(name, func(t *testing.T) {
+			tags := helpers.GetResourceTags(t, awsRegion, resourceArn)
+			assert.Equal(t, "Engineering", tags["CostCenter"], "%s should carry the CostCenter tag", name)
+			assert.Equal(t, "dev", tags["Environment"], "%s should carry the Environment tag", name)
+		})
+	}
+}
+
+// TestTagPolicyCompliance verifies every tagged resource in the stack uses only the tag values the organization's tag policy allows
+func TestTagPolicyCompliance(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping tag policy compliance test in short mode")
+	}
+
+	t.Parallel()
+
+	policyPath := os.Getenv("TEST_TAG_POLICY_FILE")
+	if policyPath == "" {
+		policyPath = defaultTagPolicyPath
+	}
+	policy := helpers.LoadTagPolicy(t, policyPath)
+
+	// A policy that lets PHI be labelled public would pass any stack, so reject it before deploying
+	assert.NotEmpty(t, helpers.TagPolicyViolations(policy, map[string]string{"DataClassification": "public"}),
+		"Tag policy %s must not allow DataClassification=public", policyPath)
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../",
+		Vars: map[string]interface{}{
+			"aws_region":  awsRegion,
+			"environment": "dev",
+			"name_suffix": nameSuffix,
+			"tags": map[string]string{
+				"DataClassification": "phi",
+				"TestRun":            nameSuffix,
+			},
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	// The TestRun tag comes from common_tags, so it finds every resource the stack tags
+	resources := helpers.GetTaggedResources(t, awsRegion, "TestRun", nameSuffix)
+	assert.NotEmpty(t, resources, "Stack resources should be discoverable by their TestRun tag")
+
+	for resourceArn, tags := range resources {
+		assert.Empty(t, helpers.TagPolicyViolations(policy, tags), "%s violates tag policy %s", resourceArn, policyPath)
+	}
+
+	// PHI stores must carry a classification, not just avoid disallowed ones
+	phiResources := map[string]string{
+		"RDS":         terraform.Output(t, terraformOptions, "rds_arn"),
+		"S3Documents": fmt.Sprintf("arn:aws:s3:::%s", terraform.Output(t, terraformOptions, "s3_bucket_documents")),
+		"S3Backups":   fmt.Sprintf("arn:aws:s3:::%s", terraform.Output(t, terraformOptions, "s3_bucket_backups")),
+	}
+	for name, resourceArn := range phiResources {
+		t.Run(name, func(t *testing.T) {
+			helpers.AssertRequiredTags(t, awsRegion, resourceArn, "Environment", "DataClassification")
+		})
+	}
+}
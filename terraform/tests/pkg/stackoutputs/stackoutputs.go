@@ -0,0 +1,201 @@
+// Package stackoutputs loads the root stack's Terraform outputs into a typed
+// struct so tests reference fields instead of stringly-typed output keys.
+package stackoutputs
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// Outputs mirrors the root module outputs; json tags are the output names.
+// Every root output has a field, so adding, renaming or removing an output
+// without updating this struct fails LoadE.
+type Outputs struct {
+	// Database
+	RDSEndpoint                         string                  `json:"rds_endpoint"`
+	AuroraClusterEndpoint               string                  `json:"aurora_cluster_endpoint"`
+	AuroraReaderEndpoint                string                  `json:"aurora_reader_endpoint"`
+	RDSReaderEndpoint                   string                  `json:"rds_reader_endpoint"`
+	RDSReportingEndpoint                string                  `json:"rds_reporting_endpoint"`
+	RDSProxyEndpoint                    string                  `json:"rds_proxy_endpoint"`
+	RDSProxyReaderEndpoint              string                  `json:"rds_proxy_reader_endpoint"`
+	RDSProxyRequireTLS                  bool                    `json:"rds_proxy_require_tls"`
+	RDSDBName                           string                  `json:"rds_db_name"`
+	RDSUsername                         string                  `json:"rds_username"`
+	RDSCARotationNeeded                 bool                    `json:"rds_ca_rotation_needed"`
+	RDSStrongPasswordEnforced           bool                    `json:"rds_strong_password_enforced"`
+	RDSARN                              string                  `json:"rds_arn"`
+	RDSSizingRecommendation             RDSSizingRecommendation `json:"rds_sizing_recommendation"`
+	RDSSnapshotCopyConfiguration        map[string]string       `json:"rds_snapshot_copy_configuration"`
+	RDSScheduleExpressions              map[string]string       `json:"rds_schedule_expressions"`
+	DataMaskingStatus                   string                  `json:"data_masking_status"`
+	DataMaskingIsolationSecurityGroupID string                  `json:"data_masking_isolation_security_group_id"`
+
+	// S3 storage
+	S3DocumentsBucket          string          `json:"s3_bucket_documents"`
+	S3BackupsBucket            string          `json:"s3_bucket_backups"`
+	S3AuditLogsBucket          string          `json:"s3_bucket_audit_logs"`
+	S3DocumentsBucketARN       string          `json:"s3_bucket_documents_arn"`
+	S3BackupsBucketARN         string          `json:"s3_bucket_backups_arn"`
+	S3AuditLogsBucketARN       string          `json:"s3_bucket_audit_logs_arn"`
+	CanaryBucketName           string          `json:"canary_bucket_name"`
+	QuarantineBucketARN        string          `json:"quarantine_bucket_arn"`
+	S3DocumentsReplicaBucket   string          `json:"s3_bucket_documents_replica"`
+	S3EventBridgeNotifications map[string]bool `json:"s3_eventbridge_notifications"`
+
+	// KMS encryption
+	KMSMasterKeyID   string `json:"kms_master_key_id"`
+	KMSMasterKeyARN  string `json:"kms_master_key_arn"`
+	BackupKMSKeyARN  string `json:"backup_kms_key_arn"`
+	LogKMSKeyARN     string `json:"log_kms_key_arn"`
+	KMSReplicaKeyARN string `json:"kms_replica_key_arn"`
+
+	// CloudTrail
+	CloudTrailName string `json:"cloudtrail_name"`
+	CloudTrailARN  string `json:"cloudtrail_arn"`
+
+	// PHI data flow evidence (JSON document)
+	PHIDataFlow string `json:"phi_data_flow"`
+
+	// VPC networking
+	VPCID                            string        `json:"vpc_id"`
+	VPCEndpointS3                    string        `json:"vpc_endpoint_s3"`
+	VPCEndpointRDS                   string        `json:"vpc_endpoint_rds"`
+	VPCEndpointBedrock               string        `json:"vpc_endpoint_bedrock"`
+	VPCEndpoints                     []VPCEndpoint `json:"vpc_endpoints"`
+	DefaultVPCPresent                bool          `json:"default_vpc_present"`
+	NetworkFirewallRuleGroupARN      string        `json:"network_firewall_rule_group_arn"`
+	NATGatewayIDs                    []string      `json:"nat_gateway_ids"`
+	NATGatewayEIPs                   []string      `json:"nat_gateway_eips"`
+	PrivateSubnetsHaveInternetEgress bool          `json:"private_subnets_have_internet_egress"`
+	WAFIPSetARN                      string        `json:"waf_ip_set_arn"`
+	PrivateLinkServiceName           string        `json:"privatelink_endpoint_service_name"`
+	PrivateSubnetIDs                 []string      `json:"private_subnet_ids"`
+	PublicSubnetIDs                  []string      `json:"public_subnet_ids"`
+
+	// IAM
+	AppIAMRoleARN  string `json:"app_iam_role_arn"`
+	AppIAMRoleName string `json:"app_iam_role_name"`
+
+	// AWS Config
+	ConfigRecorderName   string            `json:"config_recorder_name"`
+	ConfigRules          map[string]string `json:"config_rules"`
+	ConfigSnapshotBucket string            `json:"config_snapshot_bucket"`
+	ConfigSNSTopicARN    string            `json:"config_sns_topic_arn"`
+	ConfigAggregatorARN  string            `json:"config_aggregator_arn"`
+
+	// Monitoring
+	AlarmTopicARN        string            `json:"alarm_topic_arn"`
+	CentralAlarmTopicARN string            `json:"central_alarm_topic_arn"`
+	CertExpiryAlarmARNs  map[string]string `json:"cert_expiry_alarm_arns"`
+	DashboardName        string            `json:"dashboard_name"`
+	SIEMFirehoseARN      string            `json:"siem_firehose_arn"`
+	XRayEncryptionKeyARN string            `json:"xray_encryption_key_arn"`
+
+	// Railway integration
+	RailwayEnv     string `json:"railway_env"`
+	RailwayEnvFile string `json:"railway_env_file"`
+
+	// Environment metadata
+	AWSRegion          string   `json:"aws_region"`
+	AWSAccountID       string   `json:"aws_account_id"`
+	Environment        string   `json:"environment"`
+	MissingCostTags    []string `json:"missing_cost_tags"`
+	TerraformWorkspace string   `json:"terraform_workspace"`
+}
+
+// VPCEndpoint mirrors one entry of the vpc_endpoints output list
+type VPCEndpoint struct {
+	Name              string `json:"name"`
+	ID                string `json:"id"`
+	ServiceName       string `json:"service_name"`
+	Type              string `json:"type"`
+	PrivateDNSEnabled bool   `json:"private_dns_enabled"`
+}
+
+// RDSSizingRecommendation mirrors the rds_sizing_recommendation output object
+type RDSSizingRecommendation struct {
+	InstanceClass       string   `json:"instance_class"`
+	AllocatedStorage    int      `json:"allocated_storage"`
+	MaxAllocatedStorage int      `json:"max_allocated_storage"`
+	Undersized          bool     `json:"undersized"`
+	Warnings            []string `json:"warnings"`
+}
+
+// Load returns the applied stack's outputs, failing the test if Outputs and the root outputs differ
+func Load(t testing.TestingT, options *terraform.Options) Outputs {
+	outputs, err := LoadE(t, options)
+	require.NoError(t, err)
+	return outputs
+}
+
+// LoadE returns the applied stack's outputs, or an error if Outputs and the root outputs differ
+func LoadE(t testing.TestingT, options *terraform.Options) (Outputs, error) {
+	rawJSON, err := terraform.OutputJsonE(t, options, "")
+	if err != nil {
+		return Outputs{}, err
+	}
+
+	// terraform output -json wraps each value as {"sensitive": ..., "type": ..., "value": ...}
+	var wrapped map[string]struct {
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal([]byte(rawJSON), &wrapped); err != nil {
+		return Outputs{}, fmt.Errorf("parsing terraform output -json: %w", err)
+	}
+
+	values := map[string]json.RawMessage{}
+	for name, output := range wrapped {
+		values[name] = output.Value
+	}
+
+	// A renamed or removed output would otherwise leave its field silently empty,
+	// and a new output would be invisible to Go consumers
+	missing, unmapped := compareOutputs(values)
+	if len(missing) > 0 {
+		return Outputs{}, fmt.Errorf("terraform outputs not found for stackoutputs fields: %s", strings.Join(missing, ", "))
+	}
+	if len(unmapped) > 0 {
+		return Outputs{}, fmt.Errorf("terraform outputs without a stackoutputs field: %s", strings.Join(unmapped, ", "))
+	}
+
+	flattened, err := json.Marshal(values)
+	if err != nil {
+		return Outputs{}, err
+	}
+
+	var outputs Outputs
+	if err := json.Unmarshal(flattened, &outputs); err != nil {
+		return Outputs{}, fmt.Errorf("decoding terraform outputs into stackoutputs.Outputs: %w", err)
+	}
+
+	return outputs, nil
+}
+
+// compareOutputs returns the json tags of Outputs fields absent from values and the output names in values with no field
+func compareOutputs(values map[string]json.RawMessage) (missing []string, unmapped []string) {
+	fields := map[string]bool{}
+	outputsType := reflect.TypeOf(Outputs{})
+	for i := 0; i < outputsType.NumField(); i++ {
+		name := outputsType.Field(i).Tag.Get("json")
+		fields[name] = true
+		if _, ok := values[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	for name := range values {
+		if !fields[name] {
+			unmapped = append(unmapped, name)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(unmapped)
+	return missing, unmapped
+}
@@ -0,0 +1,77 @@
+package test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ==============================================================================
+// Account Isolation Tests
+// ==============================================================================
+// Plans the root module with assume_role_arn so each environment can target
+// its own account. The assume-role test needs a deploy role in another
+// account, passed as TEST_ASSUME_ROLE_ARN, and is skipped without one.
+
+// TestAssumeRoleTargetsAccount verifies resources are planned under the assumed role's account
+func TestAssumeRoleTargetsAccount(t *testing.T) {
+	roleARN := os.Getenv("TEST_ASSUME_ROLE_ARN")
+	if roleARN == "" {
+		t.Skip("TEST_ASSUME_ROLE_ARN not set; skipping cross-account assume-role test")
+	}
+
+	t.Parallel()
+
+	targetAccountID := strings.Split(roleARN, ":")[4]
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: copyRootWithLocalBackend(t),
+		Vars: map[string]interface{}{
+			"environment":     "dev",
+			"aws_account_id":  targetAccountID,
+			"assume_role_arn": roleARN,
+		},
+		PlanFilePath: filepath.Join(t.TempDir(), "assume-role.tfplan"),
+		NoColor:      true,
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, terraformOptions)
+
+	// aws_account_id is read from caller identity, so it reflects the assumed role
+	accountOutput, ok := plan.RawPlan.OutputChanges["aws_account_id"]
+	require.True(t, ok, "Plan should include the aws_account_id output")
+	assert.Equal(t, targetAccountID, accountOutput.After, "Caller identity should resolve to the assumed account")
+
+	masterKey, ok := plan.ResourcePlannedValuesMap["module.kms.aws_kms_key.master"]
+	require.True(t, ok, "Plan should include the KMS master key")
+	assert.Contains(t, masterKey.AttributeValues["policy"], fmt.Sprintf("arn:aws:iam::%s:root", targetAccountID),
+		"Key policy should grant the assumed account's root")
+}
+
+// TestAccountIDMismatchFailsPlan verifies the plan fails when credentials resolve to a different account than aws_account_id
+func TestAccountIDMismatchFailsPlan(t *testing.T) {
+	t.Parallel()
+
+	otherAccountID := "000000000000"
+	require.NotEqual(t, otherAccountID, aws.GetAccountId(t))
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: copyRootWithLocalBackend(t),
+		Vars: map[string]interface{}{
+			"environment":    "dev",
+			"aws_account_id": otherAccountID,
+		},
+		NoColor: true,
+	}
+
+	_, err := terraform.InitAndPlanE(t, terraformOptions)
+	require.Error(t, err, "Plan should fail against the wrong account")
+	assert.Contains(t, err.Error(), "expected aws_account_id "+otherAccountID)
+}
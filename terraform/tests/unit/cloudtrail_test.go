@@ -0,0 +1,113 @@
+package test
+
+import (
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hipaa-compliant-stack/terraform/tests/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ==============================================================================
+// CloudTrail Module Tests
+// ==============================================================================
+// These tests verify the audit trail is encrypted, tamper-evident, and covers
+// all regions
+// ==============================================================================
+
+// TestCloudTrailEncryptedAndValidated verifies the trail uses the master key, log file validation, and multi-region capture
+func TestCloudTrailEncryptedAndValidated(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../fixtures/cloudtrail",
+		Vars: map[string]interface{}{
+			"aws_region":  awsRegion,
+			"name_suffix": nameSuffix,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	trailName := terraform.Output(t, terraformOptions, "cloudtrail_name")
+	masterKeyArn := terraform.Output(t, terraformOptions, "kms_master_key_arn")
+	auditBucket := terraform.Output(t, terraformOptions, "s3_bucket_audit_logs")
+
+	trailConfig := helpers.GetCloudTrailConfig(t, awsRegion, trailName)
+
+	assert.Equal(t, masterKeyArn, trailConfig.KMSKeyID, "Trail should be encrypted with the KMS master key")
+	assert.True(t, trailConfig.LogFileValidationEnabled, "Log file validation should be enabled")
+	assert.True(t, trailConfig.IsMultiRegionTrail, "Trail should capture all regions")
+	assert.Equal(t, auditBucket, trailConfig.S3BucketName, "Trail should deliver to the audit logs bucket")
+}
+
+// TestCloudTrailPHIDataEvents verifies S3 object-level data events are recorded for the documents and backups buckets
+func TestCloudTrailPHIDataEvents(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../fixtures/cloudtrail",
+		Vars: map[string]interface{}{
+			"aws_region":  awsRegion,
+			"name_suffix": nameSuffix,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	trailName := terraform.Output(t, terraformOptions, "cloudtrail_name")
+	documentsBucketArn := terraform.Output(t, terraformOptions, "s3_bucket_documents_arn")
+	backupsBucketArn := terraform.Output(t, terraformOptions, "s3_bucket_backups_arn")
+
+	dataEventResources := helpers.GetCloudTrailS3DataEventResources(t, awsRegion, trailName)
+
+	assert.Contains(t, dataEventResources, documentsBucketArn+"/", "Documents bucket object access should be logged")
+	assert.Contains(t, dataEventResources, backupsBucketArn+"/", "Backups bucket object access should be logged")
+}
+
+// TestCloudTrailLogGroupRetention verifies the trail's CloudWatch Logs group has the configured finite retention and KMS encryption
+func TestCloudTrailLogGroupRetention(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	retentionDays := 90
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../fixtures/cloudtrail",
+		Vars: map[string]interface{}{
+			"aws_region":                    awsRegion,
+			"name_suffix":                   nameSuffix,
+			"enable_cloudwatch_logs":        true,
+			"cloudtrail_log_retention_days": retentionDays,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	logGroupName := terraform.Output(t, terraformOptions, "cloudwatch_log_group_name")
+	require.NotEmpty(t, logGroupName, "CloudTrail log group should be created")
+
+	logGroup := helpers.GetLogGroup(t, awsRegion, logGroupName)
+	require.NotNil(t, logGroup.RetentionInDays, "Log group retention must be finite")
+	assert.Equal(t, int64(retentionDays), awssdk.Int64Value(logGroup.RetentionInDays))
+	assert.Equal(t, terraform.Output(t, terraformOptions, "kms_master_key_arn"), awssdk.StringValue(logGroup.KmsKeyId),
+		"Log group should be encrypted with the KMS master key")
+
+	trailConfig := helpers.GetCloudTrailConfig(t, awsRegion, terraform.Output(t, terraformOptions, "cloudtrail_name"))
+	assert.Equal(t, awssdk.StringValue(logGroup.Arn), trailConfig.CloudWatchLogsGroupArn, "Trail should deliver to the log group")
+}
@@ -1,22 +1,25 @@
 package test
 
 import (
+	"encoding/json"
 	"fmt"
-	"strings"
+	"path/filepath"
 	"testing"
 
-	"github.com/gruntwork-io/terratest/modules/random"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/gruntwork-io/terratest/modules/aws"
 	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hipaa-compliant-stack/terraform/tests/helpers"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestConfigModuleBasicDeployment tests basic Config module deployment
 func TestConfigModuleBasicDeployment(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/config",
@@ -45,9 +48,8 @@ func TestConfigModuleBasicDeployment(t *testing.T) {
 func TestConfigModuleRecorderCreation(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/config",
@@ -72,9 +74,8 @@ func TestConfigModuleRecorderCreation(t *testing.T) {
 func TestConfigModuleSNSTopicCreation(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/config",
@@ -95,13 +96,12 @@ func TestConfigModuleSNSTopicCreation(t *testing.T) {
 	assert.Contains(t, snsTopicArn, fmt.Sprintf("%s-%s-config-alerts", environment, nameSuffix))
 }
 
-// TestConfigModuleRulesDeployment verifies all 6 HIPAA Config rules deployed
+// TestConfigModuleRulesDeployment verifies all 8 HIPAA Config rules deployed
 func TestConfigModuleRulesDeployment(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/config",
@@ -115,11 +115,11 @@ func TestConfigModuleRulesDeployment(t *testing.T) {
 	defer terraform.Destroy(t, terraformOptions)
 	terraform.InitAndApply(t, terraformOptions)
 
-	// Verify Config rules output contains all 6 expected rules
+	// Verify Config rules output contains all 8 expected rules
 	configRules := terraform.OutputMap(t, terraformOptions, "config_rules")
 
 	assert.NotEmpty(t, configRules)
-	assert.Len(t, configRules, 6, "Should have exactly 6 Config rules")
+	assert.Len(t, configRules, 8, "Should have exactly 8 Config rules")
 
 	// Verify each rule name
 	assert.Contains(t, configRules, "s3_encryption")
@@ -128,20 +128,22 @@ func TestConfigModuleRulesDeployment(t *testing.T) {
 	assert.Contains(t, configRules, "iam_no_admin_access")
 	assert.Contains(t, configRules, "cloudtrail_enabled")
 	assert.Contains(t, configRules, "vpc_sg_authorized")
+	assert.Contains(t, configRules, "s3_public_access")
+	assert.Contains(t, configRules, "log_group_encrypted")
 
 	// Verify rule names contain environment-nameSuffix prefix
 	expectedPrefix := fmt.Sprintf("%s-%s-", environment, nameSuffix)
 	assert.Contains(t, configRules["s3_encryption"], expectedPrefix)
 	assert.Contains(t, configRules["rds_encryption"], expectedPrefix)
+	assert.Contains(t, configRules["log_group_encrypted"], expectedPrefix)
 }
 
 // TestConfigModuleDeliveryChannel verifies delivery channel created
 func TestConfigModuleDeliveryChannel(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/config",
@@ -165,9 +167,8 @@ func TestConfigModuleDeliveryChannel(t *testing.T) {
 func TestConfigModuleWithEmailSubscription(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 	testEmail := "security-test@example.com"
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
@@ -196,9 +197,8 @@ func TestConfigModuleWithEmailSubscription(t *testing.T) {
 func TestConfigModuleEnvironmentValidation(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
 	environment := "invalid-env"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/config",
@@ -219,9 +219,8 @@ func TestConfigModuleEnvironmentValidation(t *testing.T) {
 func TestConfigModuleTagsPropagation(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	testTags := map[string]string{
 		"Project":    "HIPAA-Test",
@@ -246,3 +245,339 @@ func TestConfigModuleTagsPropagation(t *testing.T) {
 	recorderName := terraform.Output(t, terraformOptions, "config_recorder_name")
 	assert.NotEmpty(t, recorderName)
 }
+
+// TestConfigModuleAutoRemediation verifies SSM remediation is attached to the S3 encryption and public access rules
+func TestConfigModuleAutoRemediation(t *testing.T) {
+	t.Parallel()
+
+	environment := "dev"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+	awsRegion := "us-east-1"
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/config",
+		Vars: map[string]interface{}{
+			"environment":             environment,
+			"name_suffix":             nameSuffix,
+			"s3_bucket_audit_logs":    "test-audit-logs-bucket-77777",
+			"enable_auto_remediation": true,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	remediationIDs := terraform.OutputMap(t, terraformOptions, "remediation_configuration_ids")
+	assert.Len(t, remediationIDs, 3, "Should have a remediation configuration per remediable rule")
+
+	configRules := terraform.OutputMap(t, terraformOptions, "config_rules")
+	expectedTargets := map[string]string{
+		configRules["s3_encryption"]:    "AWS-EnableS3BucketEncryption",
+		configRules["s3_public_access"]: "AWSConfigRemediation-ConfigureS3BucketPublicAccessBlock",
+	}
+
+	ruleNames := []string{configRules["s3_encryption"], configRules["s3_public_access"]}
+	remediations := helpers.GetRemediationConfigurations(t, awsRegion, ruleNames)
+
+	for ruleName, targetID := range expectedTargets {
+		remediation, ok := remediations[ruleName]
+		require.True(t, ok, "Rule %s should have a remediation configuration", ruleName)
+		assert.Equal(t, "SSM_DOCUMENT", *remediation.TargetType)
+		assert.Equal(t, targetID, *remediation.TargetId)
+		assert.True(t, *remediation.Automatic, "Remediation for %s should run automatically", ruleName)
+	}
+}
+
+// TestConfigModuleRemediationTargets verifies the S3 encryption and RDS public access rules remediate through SSM with the module's role
+func TestConfigModuleRemediationTargets(t *testing.T) {
+	t.Parallel()
+
+	nameSuffix := helpers.UniqueNameSuffix(t)
+	awsRegion := "us-east-1"
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/config",
+		Vars: map[string]interface{}{
+			"environment":             "dev",
+			"name_suffix":             nameSuffix,
+			"s3_bucket_audit_logs":    "test-audit-logs-bucket-78787",
+			"enable_auto_remediation": true,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	configRules := terraform.OutputMap(t, terraformOptions, "config_rules")
+	remediationRoleARN := terraform.Output(t, terraformOptions, "remediation_role_arn")
+	require.NotEmpty(t, remediationRoleARN)
+
+	expected := map[string]struct {
+		targetID      string
+		resourceParam string
+	}{
+		configRules["s3_encryption"]:     {targetID: "AWS-EnableS3BucketEncryption", resourceParam: "BucketName"},
+		configRules["rds_public_access"]: {targetID: "AWSConfigRemediation-DisablePublicAccessToRDSInstance", resourceParam: "DbiResourceId"},
+	}
+
+	ruleNames := []string{configRules["s3_encryption"], configRules["rds_public_access"]}
+	remediations := helpers.GetRemediationConfigurations(t, awsRegion, ruleNames)
+
+	for ruleName, want := range expected {
+		remediation, ok := remediations[ruleName]
+		require.True(t, ok, "Rule %s should have a remediation configuration", ruleName)
+		assert.Equal(t, "SSM_DOCUMENT", awssdk.StringValue(remediation.TargetType))
+		assert.Equal(t, want.targetID, awssdk.StringValue(remediation.TargetId))
+		assert.True(t, awssdk.BoolValue(remediation.Automatic), "Remediation for %s should run automatically", ruleName)
+
+		assumeRole, ok := remediation.Parameters["AutomationAssumeRole"]
+		require.True(t, ok, "Remediation for %s should pass AutomationAssumeRole", ruleName)
+		require.NotNil(t, assumeRole.StaticValue)
+		assert.Equal(t, []string{remediationRoleARN}, awssdk.StringValueSlice(assumeRole.StaticValue.Values),
+			"Remediation for %s should run as the module's remediation role", ruleName)
+
+		resource, ok := remediation.Parameters[want.resourceParam]
+		require.True(t, ok, "Remediation for %s should pass the non-compliant resource as %s", ruleName, want.resourceParam)
+		require.NotNil(t, resource.ResourceValue)
+		assert.Equal(t, "RESOURCE_ID", awssdk.StringValue(resource.ResourceValue.Value))
+	}
+}
+
+// TestConfigSnapshotBucketOverride verifies the delivery channel and recorder role target config_snapshot_bucket when set and the audit bucket otherwise
+func TestConfigSnapshotBucketOverride(t *testing.T) {
+	t.Parallel()
+
+	auditBucket := "test-audit-logs-bucket-89898"
+	snapshotBucket := "test-compliance-config-89898"
+
+	testCases := []struct {
+		name           string
+		snapshotBucket string
+		expectedBucket string
+	}{
+		{name: "default", snapshotBucket: "", expectedBucket: auditBucket},
+		{name: "override", snapshotBucket: snapshotBucket, expectedBucket: snapshotBucket},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			terraformOptions := &terraform.Options{
+				TerraformDir: "../../modules/config",
+				Vars: map[string]interface{}{
+					"environment":            "dev",
+					"name_suffix":            helpers.UniqueNameSuffix(t),
+					"s3_bucket_audit_logs":   auditBucket,
+					"config_snapshot_bucket": tc.snapshotBucket,
+				},
+				PlanFilePath: filepath.Join(t.TempDir(), "snapshot-bucket.tfplan"),
+				NoColor:      true,
+			}
+
+			plan := terraform.InitAndPlanAndShowWithStruct(t, terraformOptions)
+
+			channel, ok := plan.ResourcePlannedValuesMap["aws_config_delivery_channel.main"]
+			require.True(t, ok, "Delivery channel should be planned")
+			assert.Equal(t, tc.expectedBucket, channel.AttributeValues["s3_bucket_name"])
+
+			outputChange, ok := plan.RawPlan.OutputChanges["config_snapshot_bucket"]
+			require.True(t, ok, "Plan should include config_snapshot_bucket")
+			assert.Equal(t, tc.expectedBucket, outputChange.After)
+
+			// The recorder role may only write to the bucket Config delivers to
+			rolePolicy, ok := plan.ResourcePlannedValuesMap["aws_iam_role_policy.config_s3_policy"]
+			require.True(t, ok, "Recorder S3 policy should be planned")
+			policyJSON, ok := rolePolicy.AttributeValues["policy"].(string)
+			require.True(t, ok, "Recorder S3 policy should be known at plan time")
+			assert.Contains(t, policyJSON, fmt.Sprintf("arn:aws:s3:::%s/*", tc.expectedBucket))
+			if tc.snapshotBucket != "" {
+				assert.NotContains(t, policyJSON, auditBucket, "Recorder role should not write to the audit bucket once overridden")
+			}
+		})
+	}
+}
+
+// TestConfigRuleEvaluationModes verifies encryption rules are change-triggered while CloudTrail is checked periodically
+func TestConfigRuleEvaluationModes(t *testing.T) {
+	t.Parallel()
+
+	nameSuffix := helpers.UniqueNameSuffix(t)
+	awsRegion := "us-east-1"
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/config",
+		Vars: map[string]interface{}{
+			"environment":          "dev",
+			"name_suffix":          nameSuffix,
+			"s3_bucket_audit_logs": "test-audit-logs-bucket-88888",
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	evaluationModes := terraform.OutputMap(t, terraformOptions, "config_rule_evaluation_modes")
+	assert.Equal(t, "configuration_change", evaluationModes["s3_encryption"])
+	assert.Equal(t, "periodic", evaluationModes["cloudtrail_enabled"])
+	assert.Equal(t, "periodic", evaluationModes["log_group_encrypted"], "CLOUDWATCH_LOG_GROUP_ENCRYPTED only supports periodic evaluation")
+
+	configRules := terraform.OutputMap(t, terraformOptions, "config_rules")
+
+	// Change-triggered rules have no execution schedule
+	s3EncryptionRule := helpers.GetConfigRule(t, awsRegion, configRules["s3_encryption"])
+	assert.Nil(t, s3EncryptionRule.MaximumExecutionFrequency, "s3_encryption should evaluate on configuration change")
+
+	cloudTrailRule := helpers.GetConfigRule(t, awsRegion, configRules["cloudtrail_enabled"])
+	require.NotNil(t, cloudTrailRule.MaximumExecutionFrequency, "cloudtrail_enabled should evaluate periodically")
+	assert.Equal(t, "TwentyFour_Hours", *cloudTrailRule.MaximumExecutionFrequency)
+}
+
+// TestConfigModuleAggregator verifies the aggregator is created and sources the configured accounts and regions
+func TestConfigModuleAggregator(t *testing.T) {
+	t.Parallel()
+
+	nameSuffix := helpers.UniqueNameSuffix(t)
+	awsRegion := "us-east-1"
+	sourceAccountID := aws.GetAccountId(t)
+	sourceRegions := []string{"us-east-1", "us-west-2"}
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/config",
+		Vars: map[string]interface{}{
+			"environment":              "dev",
+			"name_suffix":              nameSuffix,
+			"s3_bucket_audit_logs":     "test-audit-logs-bucket-99999",
+			"enable_config_aggregator": true,
+			"aggregator_account_ids":   []string{sourceAccountID},
+			"aggregator_regions":       sourceRegions,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	aggregatorARN := terraform.Output(t, terraformOptions, "config_aggregator_arn")
+	require.NotEmpty(t, aggregatorARN)
+
+	aggregator := helpers.GetConfigurationAggregator(t, awsRegion, aggregatorARN)
+	require.Len(t, aggregator.AccountAggregationSources, 1)
+
+	source := aggregator.AccountAggregationSources[0]
+	assert.ElementsMatch(t, []string{sourceAccountID}, awssdk.StringValueSlice(source.AccountIds))
+	assert.ElementsMatch(t, sourceRegions, awssdk.StringValueSlice(source.AwsRegions))
+	assert.False(t, awssdk.BoolValue(source.AllAwsRegions), "Aggregator should be limited to the configured regions")
+}
+
+// TestConfigModuleAggregateAuthorization verifies the source account authorizes the configured central aggregator account and regions
+func TestConfigModuleAggregateAuthorization(t *testing.T) {
+	t.Parallel()
+
+	nameSuffix := helpers.UniqueNameSuffix(t)
+	awsRegion := "us-east-1"
+	centralAccountID := aws.GetAccountId(t)
+	centralRegions := []string{"us-east-1", "us-west-2"}
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/config",
+		Vars: map[string]interface{}{
+			"environment":                      "dev",
+			"name_suffix":                      nameSuffix,
+			"s3_bucket_audit_logs":             "test-audit-logs-bucket-99999",
+			"aggregator_authorized_account_id": centralAccountID,
+			"aggregator_authorized_regions":    centralRegions,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	authorizationARNs := terraform.OutputMap(t, terraformOptions, "config_aggregate_authorization_arns")
+	assert.Len(t, authorizationARNs, len(centralRegions), "One authorization per central region")
+
+	authorizations := helpers.GetAggregateAuthorizations(t, awsRegion)
+	require.Contains(t, authorizations, centralAccountID, "Central account should be authorized to aggregate")
+	for _, region := range centralRegions {
+		assert.Contains(t, authorizations[centralAccountID], region, "Central aggregator in %s should be authorized", region)
+	}
+}
+
+// TestConfigServicePrincipalsByPartition verifies trust policies use the partition's service principals
+func TestConfigServicePrincipalsByPartition(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		partition string
+		dnsSuffix string
+	}{
+		{partition: "aws", dnsSuffix: "amazonaws.com"},
+		{partition: "aws-us-gov", dnsSuffix: "amazonaws.com"},
+		{partition: "aws-cn", dnsSuffix: "amazonaws.com.cn"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.partition, func(t *testing.T) {
+			t.Parallel()
+
+			terraformOptions := &terraform.Options{
+				TerraformDir: "../../modules/config",
+				Vars: map[string]interface{}{
+					"environment":             "dev",
+					"s3_bucket_audit_logs":    "test-audit-logs-bucket-99999",
+					"enable_auto_remediation": true,
+					"partition":               tc.partition,
+					"dns_suffix":              tc.dnsSuffix,
+				},
+				EnvVars: map[string]string{
+					"AWS_DEFAULT_REGION": "us-east-1",
+				},
+				PlanFilePath: filepath.Join(t.TempDir(), "partition.tfplan"),
+				NoColor:      true,
+			}
+
+			plan := terraform.InitAndPlanAndShowWithStruct(t, terraformOptions)
+
+			outputChange, ok := plan.RawPlan.OutputChanges["service_principals"]
+			require.True(t, ok, "Plan should include service_principals")
+			principals, ok := outputChange.After.(map[string]interface{})
+			require.True(t, ok, "service_principals should be known at plan time")
+			assert.Equal(t, "config."+tc.dnsSuffix, principals["config"])
+			assert.Equal(t, "ssm."+tc.dnsSuffix, principals["ssm"])
+
+			role, ok := plan.ResourcePlannedValuesMap["aws_iam_role.config"]
+			require.True(t, ok, "Plan should include the Config recorder role")
+
+			var trustPolicy struct {
+				Statement []struct {
+					Principal struct {
+						Service string
+					}
+				}
+			}
+			require.NoError(t, json.Unmarshal([]byte(role.AttributeValues["assume_role_policy"].(string)), &trustPolicy))
+			require.NotEmpty(t, trustPolicy.Statement)
+			assert.Equal(t, principals["config"], trustPolicy.Statement[0].Principal.Service, "Recorder role should trust the partition's Config principal")
+
+			attachment, ok := plan.ResourcePlannedValuesMap["aws_iam_role_policy_attachment.config_managed_policy"]
+			require.True(t, ok, "Plan should include the managed Config policy attachment")
+			assert.Equal(t, "arn:"+tc.partition+":iam::aws:policy/service-role/ConfigRole", attachment.AttributeValues["policy_arn"], "Managed policy ARN should use the partition")
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hipaa-compliant-stack/terraform/tests/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDashboardWidgets verifies the deployed dashboard JSON parses and graphs the RDS, S3 and GuardDuty metrics
+func TestDashboardWidgets(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+	documentsBucket := fmt.Sprintf("test-documents-%s", nameSuffix)
+
+	// The dashboard references resources by name only, so none need to exist
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/dashboard",
+		Vars: map[string]interface{}{
+			"environment":             "dev",
+			"name_suffix":             nameSuffix,
+			"rds_instance_identifier": fmt.Sprintf("dev-hipaa-db-%s", nameSuffix),
+			"s3_bucket_names": map[string]string{
+				"documents": documentsBucket,
+			},
+			"documents_bucket_name": documentsBucket,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	dashboardName := terraform.Output(t, terraformOptions, "dashboard_name")
+	require.Contains(t, dashboardName, nameSuffix)
+
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+	dashboard, err := cloudwatch.New(sess).GetDashboard(&cloudwatch.GetDashboardInput{
+		DashboardName: awssdk.String(dashboardName),
+	})
+	require.NoError(t, err)
+
+	var body struct {
+		Widgets []struct {
+			Properties struct {
+				Metrics [][]interface{} `json:"metrics"`
+			} `json:"properties"`
+		} `json:"widgets"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(awssdk.StringValue(dashboard.DashboardBody)), &body), "Dashboard body should be valid JSON")
+
+	// Namespace/metric pairs graphed across all widgets
+	graphed := map[string]bool{}
+	for _, widget := range body.Widgets {
+		for _, metric := range widget.Properties.Metrics {
+			require.GreaterOrEqual(t, len(metric), 2)
+			graphed[fmt.Sprintf("%s/%s", metric[0], metric[1])] = true
+		}
+	}
+
+	for _, expected := range []string{
+		"AWS/RDS/CPUUtilization",
+		"AWS/RDS/DatabaseConnections",
+		"AWS/RDS/FreeStorageSpace",
+		"AWS/S3/BucketSizeBytes",
+		"AWS/S3/4xxErrors",
+		"AWS/S3/5xxErrors",
+		"AWS/Events/MatchedEvents",
+	} {
+		assert.True(t, graphed[expected], "Dashboard should graph %s", expected)
+	}
+}
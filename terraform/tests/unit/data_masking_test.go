@@ -0,0 +1,159 @@
+package test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ==============================================================================
+// Data Masking Tests
+// ==============================================================================
+// Plans the data_masking module with placeholder network IDs. The masking
+// Lambda must be triggered by restores into staging and must be the only
+// thing able to reach a restore until it attaches the exposed groups itself.
+// ==============================================================================
+
+// dataMaskingVars returns module inputs with placeholder IDs for plan-only tests
+func dataMaskingVars(environment string) map[string]interface{} {
+	return map[string]interface{}{
+		"environment":                environment,
+		"vpc_id":                     "vpc-test123",
+		"subnet_ids":                 []string{"subnet-test1", "subnet-test2"},
+		"exposed_security_group_ids": []string{"sg-exposed123"},
+		"master_username_parameter":  "/" + environment + "-hipaa-db/master-username",
+		"master_password_parameter":  "/" + environment + "-hipaa-db/master-password",
+		"kms_key_arn":                "arn:aws:kms:us-east-1:123456789012:key/00000000-0000-0000-0000-000000000000",
+		"driver_layer_arns":          []string{"arn:aws:lambda:us-east-1:123456789012:layer:pg8000:1"},
+		"masking_rules": []map[string]string{
+			{"table": "patients", "column": "full_name", "strategy": "redact"},
+			{"table": "patients", "column": "email", "strategy": "email"},
+			{"table": "clinical.encounters", "column": "date_of_birth", "strategy": "date_year"},
+		},
+	}
+}
+
+// TestDataMaskingRunsBeforeExposure verifies the masking Lambda is wired to staging restore events and restores stay isolated until it exposes them
+func TestDataMaskingRunsBeforeExposure(t *testing.T) {
+	t.Parallel()
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../../modules/data_masking",
+		Vars:         dataMaskingVars("staging"),
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": "us-east-1",
+		},
+		PlanFilePath: filepath.Join(t.TempDir(), "data-masking.tfplan"),
+		NoColor:      true,
+	}
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, terraformOptions)
+
+	t.Run("RestoreEventRule", func(t *testing.T) {
+		rule, ok := plan.ResourcePlannedValuesMap["aws_cloudwatch_event_rule.restored"]
+		require.True(t, ok, "Plan should include the restore event rule")
+
+		var pattern struct {
+			Source     []string `json:"source"`
+			DetailType []string `json:"detail-type"`
+			Detail     struct {
+				EventID          []string            `json:"EventID"`
+				SourceIdentifier []map[string]string `json:"SourceIdentifier"`
+			} `json:"detail"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(rule.AttributeValues["event_pattern"].(string)), &pattern))
+
+		assert.Equal(t, []string{"aws.rds"}, pattern.Source)
+		assert.Equal(t, []string{"RDS DB Instance Event"}, pattern.DetailType)
+		// Snapshot and point-in-time restores start isolation; the password reset starts masking
+		assert.Subset(t, pattern.Detail.EventID, []string{"RDS-EVENT-0043", "RDS-EVENT-0019", "RDS-EVENT-0016"})
+		assert.Equal(t, []map[string]string{{"prefix": "staging-hipaa-db-restore"}}, pattern.Detail.SourceIdentifier,
+			"Only restores into staging should trigger masking")
+	})
+
+	t.Run("LambdaIsTheTarget", func(t *testing.T) {
+		references := map[string][]string{}
+		for _, resource := range plan.RawPlan.Config.RootModule.Resources {
+			for name, expression := range resource.Expressions {
+				references[resource.Address+"."+name] = expression.References
+			}
+		}
+
+		assert.Contains(t, references["aws_cloudwatch_event_target.masking.rule"], "aws_cloudwatch_event_rule.restored")
+		assert.Contains(t, references["aws_cloudwatch_event_target.masking.arn"], "aws_lambda_function.masking")
+		assert.Contains(t, references["aws_lambda_permission.masking.source_arn"], "aws_cloudwatch_event_rule.restored")
+		assert.Contains(t, references["aws_lambda_permission.masking.function_name"], "aws_lambda_function.masking")
+	})
+
+	t.Run("RestoresIsolatedUntilMasked", func(t *testing.T) {
+		// The isolation group admits PostgreSQL from the masking Lambda and nothing else
+		var isolationIngress []string
+		for _, resource := range plan.RawPlan.Config.RootModule.Resources {
+			if resource.Type != "aws_vpc_security_group_ingress_rule" {
+				continue
+			}
+			target := resource.Expressions["security_group_id"]
+			require.NotNil(t, target, "%s should set security_group_id", resource.Address)
+			if !containsReference(target.References, "aws_security_group.isolation") {
+				continue
+			}
+			isolationIngress = append(isolationIngress, resource.Address)
+
+			source := resource.Expressions["referenced_security_group_id"]
+			require.NotNil(t, source, "%s should admit a security group, not a CIDR", resource.Address)
+			assert.True(t, containsReference(source.References, "aws_security_group.lambda"), "%s should admit only the masking Lambda", resource.Address)
+			assert.Nil(t, resource.Expressions["cidr_ipv4"], "%s must not admit a CIDR", resource.Address)
+		}
+		assert.Equal(t, []string{"aws_vpc_security_group_ingress_rule.isolation_from_lambda"}, isolationIngress)
+
+		// The app-facing groups reach restores only through the Lambda, after masking
+		function, ok := plan.ResourcePlannedValuesMap["aws_lambda_function.masking"]
+		require.True(t, ok, "Plan should include the masking Lambda")
+		environment := function.AttributeValues["environment"].([]interface{})[0].(map[string]interface{})["variables"].(map[string]interface{})
+		assert.Equal(t, "sg-exposed123", environment["EXPOSED_SECURITY_GROUP_IDS"])
+		assert.Equal(t, "staging-hipaa-db-restore", environment["RESTORE_PREFIX"])
+		assert.Equal(t, "RDS-EVENT-0043,RDS-EVENT-0019", environment["RESTORE_EVENT_IDS"])
+		assert.Equal(t, "RDS-EVENT-0016", environment["RESET_EVENT_IDS"])
+		assert.JSONEq(t, `[
+			{"table": "patients", "column": "full_name", "strategy": "redact"},
+			{"table": "patients", "column": "email", "strategy": "email"},
+			{"table": "clinical.encounters", "column": "date_of_birth", "strategy": "date_year"}
+		]`, environment["MASKING_RULES"].(string))
+	})
+
+	t.Run("StatusOutput", func(t *testing.T) {
+		outputChange, ok := plan.RawPlan.OutputChanges["masking_job_status"]
+		require.True(t, ok, "Plan should include masking_job_status")
+		assert.Equal(t, "never_run", outputChange.After)
+	})
+}
+
+// TestDataMaskingRejectsProduction verifies the module refuses to run against production
+func TestDataMaskingRejectsProduction(t *testing.T) {
+	t.Parallel()
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../../modules/data_masking",
+		Vars:         dataMaskingVars("production"),
+		NoColor:      true,
+	}
+
+	_, err := terraform.InitAndPlanE(t, terraformOptions)
+	require.Error(t, err, "Plan should fail in production")
+	assert.Contains(t, err.Error(), "Data masking runs in dev or staging only")
+}
+
+// containsReference reports whether any reference names the given resource
+func containsReference(references []string, resource string) bool {
+	for _, reference := range references {
+		if reference == resource || strings.HasPrefix(reference, resource+".") || strings.HasPrefix(reference, resource+"[") {
+			return true
+		}
+	}
+	return false
+}
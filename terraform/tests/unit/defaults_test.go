@@ -0,0 +1,70 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	test_structure "github.com/gruntwork-io/terratest/modules/test-structure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ==============================================================================
+// Default Variable Tests
+// ==============================================================================
+// Plans the root module with only its required variables so a first-time
+// deployment on defaults is known to be valid and secure by default. Nothing
+// is applied; the S3 backend is swapped for local state in a temp copy.
+
+// TestDefaultVariablesValid verifies the root plans with only required variables and keeps encryption and versioning on
+func TestDefaultVariablesValid(t *testing.T) {
+	t.Parallel()
+
+	rootDir := copyRootWithLocalBackend(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: rootDir,
+		Vars: map[string]interface{}{
+			"environment": "dev",
+		},
+		PlanFilePath: filepath.Join(t.TempDir(), "defaults.tfplan"),
+		NoColor:      true,
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, terraformOptions)
+
+	masterKey, ok := plan.ResourcePlannedValuesMap["module.kms.aws_kms_key.master"]
+	require.True(t, ok, "Plan should include the KMS master key")
+	assert.Equal(t, true, masterKey.AttributeValues["enable_key_rotation"], "KMS key rotation should be on by default")
+
+	database, ok := plan.ResourcePlannedValuesMap["module.rds.aws_db_instance.main[0]"]
+	require.True(t, ok, "Plan should include the RDS instance")
+	assert.Equal(t, true, database.AttributeValues["storage_encrypted"], "RDS storage should be encrypted by default")
+	assert.Equal(t, false, database.AttributeValues["publicly_accessible"], "RDS should not be publicly accessible by default")
+
+	for _, bucket := range []string{"documents", "backups", "audit_logs"} {
+		encryption, ok := plan.ResourcePlannedValuesMap["module.s3.aws_s3_bucket_server_side_encryption_configuration."+bucket]
+		require.True(t, ok, "Plan should include SSE configuration for the %s bucket", bucket)
+		rules, _ := encryption.AttributeValues["rule"].([]interface{})
+		require.Len(t, rules, 1)
+		defaults, _ := rules[0].(map[string]interface{})["apply_server_side_encryption_by_default"].([]interface{})
+		require.Len(t, defaults, 1)
+		assert.Equal(t, "aws:kms", defaults[0].(map[string]interface{})["sse_algorithm"], "%s bucket should default to SSE-KMS", bucket)
+
+		versioning, ok := plan.ResourcePlannedValuesMap["module.s3.aws_s3_bucket_versioning."+bucket]
+		require.True(t, ok, "Plan should include versioning for the %s bucket", bucket)
+		configuration, _ := versioning.AttributeValues["versioning_configuration"].([]interface{})
+		require.Len(t, configuration, 1)
+		assert.Equal(t, "Enabled", configuration[0].(map[string]interface{})["status"], "%s bucket should be versioned by default", bucket)
+	}
+}
+
+// copyRootWithLocalBackend copies the root module to a temp dir with the S3 backend swapped for local state
+func copyRootWithLocalBackend(t *testing.T) string {
+	rootDir := test_structure.CopyTerraformFolderToTemp(t, "../..", ".")
+	backendOverride := []byte("terraform {\n  backend \"local\" {}\n}\n")
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "backend_override.tf"), backendOverride, 0644))
+	return rootDir
+}
@@ -1,23 +1,25 @@
 package test
 
 import (
+	"encoding/json"
 	"fmt"
-	"strings"
+	"path/filepath"
+	"strconv"
 	"testing"
 
 	"github.com/gruntwork-io/terratest/modules/aws"
-	"github.com/gruntwork-io/terratest/modules/random"
 	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hipaa-compliant-stack/terraform/tests/helpers"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestIAMModuleRoleCreation verifies that the backend application IAM role is created
 func TestIAMModuleRoleCreation(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/iam",
@@ -50,9 +52,8 @@ func TestIAMModuleRoleCreation(t *testing.T) {
 func TestIAMModulePoliciesCreated(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/iam",
@@ -84,9 +85,8 @@ func TestIAMModulePoliciesCreated(t *testing.T) {
 func TestIAMModuleRDSMonitoringRoleConditional(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/iam",
@@ -113,9 +113,8 @@ func TestIAMModuleRDSMonitoringRoleConditional(t *testing.T) {
 func TestIAMModuleRDSMonitoringRoleDisabled(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/iam",
@@ -142,9 +141,8 @@ func TestIAMModuleRDSMonitoringRoleDisabled(t *testing.T) {
 func TestIAMModuleEnvironmentTagging(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	customTags := map[string]string{
 		"Project":    "HIPAA-Compliant",
@@ -177,9 +175,8 @@ func TestIAMModuleEnvironmentTagging(t *testing.T) {
 func TestIAMModuleAllOutputs(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/iam",
@@ -218,9 +215,8 @@ func TestIAMModuleAllOutputs(t *testing.T) {
 func TestIAMModuleWithMinimalInputs(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/iam",
@@ -249,9 +245,8 @@ func TestIAMModuleMultipleEnvironments(t *testing.T) {
 
 	for _, env := range environments {
 		t.Run(env, func(t *testing.T) {
-			uniqueID := random.UniqueId()
 			environment := "dev"
-			nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+			nameSuffix := helpers.UniqueNameSuffix(t)
 
 			terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 				TerraformDir: "../../modules/iam",
@@ -274,3 +269,391 @@ func TestIAMModuleMultipleEnvironments(t *testing.T) {
 		})
 	}
 }
+
+// TestIAMModuleS3PolicyConditions verifies the S3 policy requires TLS and scopes listing to allowed prefixes
+func TestIAMModuleS3PolicyConditions(t *testing.T) {
+	t.Parallel()
+
+	environment := "dev"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/iam",
+		Vars: map[string]interface{}{
+			"environment":              environment,
+			"name_suffix":              nameSuffix,
+			"s3_bucket_documents_arn":  "arn:aws:s3:::conditions-docs-bucket",
+			"s3_bucket_backups_arn":    "arn:aws:s3:::conditions-backups-bucket",
+			"s3_bucket_audit_logs_arn": "arn:aws:s3:::conditions-audit-bucket",
+			"kms_master_key_arn":       fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/conditions-key-id", aws.GetAccountId(t)),
+			"require_secure_transport": true,
+			"s3_allowed_prefixes":      []string{"tenants/"},
+		},
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	policyJSON := terraform.Output(t, terraformOptions, "s3_policy_document")
+
+	var policy struct {
+		Statement []struct {
+			Sid       string
+			Condition map[string]map[string]interface{}
+		}
+	}
+	require.NoError(t, json.Unmarshal([]byte(policyJSON), &policy), "S3 policy should be valid JSON")
+	require.NotEmpty(t, policy.Statement, "S3 policy should contain statements")
+
+	for _, statement := range policy.Statement {
+		assert.Contains(t, statement.Condition["Bool"], "aws:SecureTransport", "Statement %s should require aws:SecureTransport", statement.Sid)
+	}
+
+	var listDocumentsCondition map[string]map[string]interface{}
+	for _, statement := range policy.Statement {
+		if statement.Sid == "ListDocumentsBucket" {
+			listDocumentsCondition = statement.Condition
+		}
+	}
+	require.NotNil(t, listDocumentsCondition, "ListDocumentsBucket statement should be present")
+	assert.Contains(t, listDocumentsCondition["StringLike"], "s3:prefix", "Listing should be scoped by s3:prefix")
+	assert.Equal(t, []interface{}{"tenants/*"}, listDocumentsCondition["StringLike"]["s3:prefix"])
+}
+
+// TestIAMModuleAccountPasswordPolicy verifies the managed password policy meets length and reuse minimums.
+// The policy is an account-wide singleton, so the test only plans it: applying would overwrite the
+// test account's real policy and race other runs.
+func TestIAMModuleAccountPasswordPolicy(t *testing.T) {
+	t.Parallel()
+
+	environment := "dev"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../../modules/iam",
+		Vars: map[string]interface{}{
+			"environment":                    environment,
+			"name_suffix":                    nameSuffix,
+			"s3_bucket_documents_arn":        "arn:aws:s3:::password-docs-bucket",
+			"s3_bucket_backups_arn":          "arn:aws:s3:::password-backups-bucket",
+			"s3_bucket_audit_logs_arn":       "arn:aws:s3:::password-audit-bucket",
+			"kms_master_key_arn":             fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/password-key-id", aws.GetAccountId(t)),
+			"manage_account_password_policy": true,
+		},
+		PlanFilePath: filepath.Join(t.TempDir(), "password-policy.tfplan"),
+		NoColor:      true,
+	}
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, terraformOptions)
+
+	policy, ok := plan.ResourcePlannedValuesMap["aws_iam_account_password_policy.strict[0]"]
+	require.True(t, ok, "Password policy should be planned when managed")
+
+	assert.GreaterOrEqual(t, policy.AttributeValues["minimum_password_length"], float64(14), "Minimum password length should be at least 14")
+	assert.Equal(t, float64(24), policy.AttributeValues["password_reuse_prevention"], "Password reuse prevention should be 24")
+	assert.Equal(t, true, policy.AttributeValues["require_symbols"], "Password policy should require symbols")
+}
+
+// TestIAMModulePrivilegedRolesRequireMFA verifies the auditor and admin trust policy carries the MFA condition only when require_mfa is set
+func TestIAMModulePrivilegedRolesRequireMFA(t *testing.T) {
+	t.Parallel()
+
+	for _, requireMFA := range []bool{true, false} {
+		requireMFA := requireMFA
+		t.Run(fmt.Sprintf("require-mfa-%t", requireMFA), func(t *testing.T) {
+			t.Parallel()
+
+			nameSuffix := helpers.UniqueNameSuffix(t)
+
+			terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+				TerraformDir: "../../modules/iam",
+				Vars: map[string]interface{}{
+					"environment":              "dev",
+					"name_suffix":              nameSuffix,
+					"s3_bucket_documents_arn":  "arn:aws:s3:::mfa-docs-bucket",
+					"s3_bucket_backups_arn":    "arn:aws:s3:::mfa-backups-bucket",
+					"s3_bucket_audit_logs_arn": "arn:aws:s3:::mfa-audit-bucket",
+					"kms_master_key_arn":       fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/mfa-key-id", aws.GetAccountId(t)),
+					"create_auditor_role":      true,
+					"create_admin_role":        true,
+					"require_mfa":              requireMFA,
+				},
+			})
+
+			defer terraform.Destroy(t, terraformOptions)
+			terraform.InitAndApply(t, terraformOptions)
+
+			assert.NotEmpty(t, terraform.Output(t, terraformOptions, "auditor_role_arn"))
+			assert.NotEmpty(t, terraform.Output(t, terraformOptions, "admin_role_arn"))
+			assert.Equal(t, strconv.FormatBool(requireMFA), terraform.Output(t, terraformOptions, "privileged_roles_require_mfa"))
+
+			var trustPolicy struct {
+				Statement []struct {
+					Action    string
+					Condition map[string]map[string]interface{}
+				}
+			}
+			trustPolicyJSON := terraform.Output(t, terraformOptions, "privileged_role_trust_policy")
+			require.NoError(t, json.Unmarshal([]byte(trustPolicyJSON), &trustPolicy), "Trust policy should be valid JSON")
+			require.Len(t, trustPolicy.Statement, 1)
+
+			statement := trustPolicy.Statement[0]
+			assert.Equal(t, "sts:AssumeRole", statement.Action)
+			if requireMFA {
+				assert.Equal(t, []interface{}{"true"}, statement.Condition["Bool"]["aws:MultiFactorAuthPresent"], "Trust policy should require MFA")
+			} else {
+				assert.NotContains(t, statement.Condition["Bool"], "aws:MultiFactorAuthPresent")
+			}
+		})
+	}
+}
+
+// TestIAMModuleAppDeniedAuditLogReads verifies the app role is explicitly denied reading audit log objects while it can still append them
+func TestIAMModuleAppDeniedAuditLogReads(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+	auditBucketARN := "arn:aws:s3:::separation-audit-bucket"
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/iam",
+		Vars: map[string]interface{}{
+			"environment":              "dev",
+			"name_suffix":              nameSuffix,
+			"s3_bucket_documents_arn":  "arn:aws:s3:::separation-docs-bucket",
+			"s3_bucket_backups_arn":    "arn:aws:s3:::separation-backups-bucket",
+			"s3_bucket_audit_logs_arn": auditBucketARN,
+			"kms_master_key_arn":       fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/separation-key-id", aws.GetAccountId(t)),
+		},
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Static check: an unconditional explicit deny covers every audit log object
+	var policy struct {
+		Statement []struct {
+			Sid       string
+			Effect    string
+			Action    []string
+			Resource  []string
+			Condition map[string]interface{}
+		}
+	}
+	policyJSON := terraform.Output(t, terraformOptions, "audit_logs_protection_policy_document")
+	require.NoError(t, json.Unmarshal([]byte(policyJSON), &policy), "Audit log protection policy should be valid JSON")
+
+	var denyFound bool
+	for _, statement := range policy.Statement {
+		if statement.Effect != "Deny" {
+			continue
+		}
+		denyFound = true
+		assert.Contains(t, statement.Action, "s3:GetObject", "Statement %s should deny reading audit logs", statement.Sid)
+		assert.Contains(t, statement.Resource, auditBucketARN+"/*", "Statement %s should cover every audit log object", statement.Sid)
+		assert.Empty(t, statement.Condition, "Statement %s should deny unconditionally", statement.Sid)
+	}
+	assert.True(t, denyFound, "Audit log protection policy should contain an explicit deny")
+
+	// Live check: the simulator evaluates every policy attached to the role
+	roleARN := terraform.Output(t, terraformOptions, "app_iam_role_arn")
+	tlsContext := map[string]string{"aws:SecureTransport": "true"}
+
+	readDecision := helpers.SimulatePrincipalAction(t, awsRegion, roleARN, "s3:GetObject", auditBucketARN+"/application-logs/app.log", tlsContext)
+	assert.Equal(t, "explicitDeny", readDecision, "App role must not read its own audit logs")
+
+	appendDecision := helpers.SimulatePrincipalAction(t, awsRegion, roleARN, "s3:PutObject", auditBucketARN+"/application-logs/app.log", tlsContext)
+	assert.Equal(t, "allowed", appendDecision, "App role should still append application logs")
+}
+
+// TestIAMModuleDeniesUnapprovedKMSKeys verifies the app role cannot encrypt under any key other than the master and secondary keys
+func TestIAMModuleDeniesUnapprovedKMSKeys(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+	accountID := aws.GetAccountId(t)
+
+	masterKeyARN := fmt.Sprintf("arn:aws:kms:%s:%s:key/approved-master-key-id", awsRegion, accountID)
+	secondaryKeyARN := fmt.Sprintf("arn:aws:kms:us-west-2:%s:key/approved-replica-key-id", accountID)
+	otherKeyARN := fmt.Sprintf("arn:aws:kms:%s:%s:key/unapproved-key-id", awsRegion, accountID)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/iam",
+		Vars: map[string]interface{}{
+			"environment":              "dev",
+			"name_suffix":              nameSuffix,
+			"s3_bucket_documents_arn":  "arn:aws:s3:::approved-keys-docs-bucket",
+			"s3_bucket_backups_arn":    "arn:aws:s3:::approved-keys-backups-bucket",
+			"s3_bucket_audit_logs_arn": "arn:aws:s3:::approved-keys-audit-bucket",
+			"kms_master_key_arn":       masterKeyARN,
+			"deny_unapproved_kms_keys": true,
+			"secondary_kms_key_arns":   []string{secondaryKeyARN},
+		},
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Static check: one explicit deny whose NotResource is exactly the approved keys
+	var policy struct {
+		Statement []struct {
+			Sid         string
+			Effect      string
+			Action      interface{}
+			NotResource []string
+		}
+	}
+	policyJSON := terraform.Output(t, terraformOptions, "kms_policy_document")
+	require.NoError(t, json.Unmarshal([]byte(policyJSON), &policy), "KMS policy should be valid JSON")
+
+	var denyFound bool
+	for _, statement := range policy.Statement {
+		if statement.Sid != "DenyUnapprovedKeyEncryption" {
+			continue
+		}
+		denyFound = true
+		assert.Equal(t, "Deny", statement.Effect)
+		assert.ElementsMatch(t, []string{masterKeyARN, secondaryKeyARN}, statement.NotResource, "Only the master and secondary keys should be exempt")
+	}
+	assert.True(t, denyFound, "KMS policy should contain the unapproved key deny")
+
+	// Live check: the deny must win over the tenant key grant for any other key
+	roleARN := terraform.Output(t, terraformOptions, "app_iam_role_arn")
+	tlsContext := map[string]string{"aws:SecureTransport": "true"}
+
+	for _, action := range []string{"kms:GenerateDataKey", "kms:Encrypt"} {
+		decision := helpers.SimulatePrincipalAction(t, awsRegion, roleARN, action, otherKeyARN, tlsContext)
+		assert.Equal(t, "explicitDeny", decision, "App role must not %s under an unapproved key", action)
+	}
+
+	helpers.AssertSimulated(t, awsRegion, roleARN, "kms:GenerateDataKey", masterKeyARN, true)
+	helpers.AssertSimulated(t, awsRegion, roleARN, "kms:Encrypt", masterKeyARN, true)
+	assert.NotEqual(t, "explicitDeny", helpers.SimulatePrincipalAction(t, awsRegion, roleARN, "kms:Encrypt", secondaryKeyARN, tlsContext),
+		"The secondary key should not be caught by the deny")
+}
+
+// TestIAMModuleEffectivePermissions verifies the app role's effective permissions across all attached policies with the IAM policy simulator
+func TestIAMModuleEffectivePermissions(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+	accountID := aws.GetAccountId(t)
+
+	documentsBucketARN := "arn:aws:s3:::simulated-docs-bucket"
+	auditBucketARN := "arn:aws:s3:::simulated-audit-bucket"
+	masterKeyARN := fmt.Sprintf("arn:aws:kms:%s:%s:key/simulated-key-id", awsRegion, accountID)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/iam",
+		Vars: map[string]interface{}{
+			"environment":              "dev",
+			"name_suffix":              nameSuffix,
+			"s3_bucket_documents_arn":  documentsBucketARN,
+			"s3_bucket_backups_arn":    "arn:aws:s3:::simulated-backups-bucket",
+			"s3_bucket_audit_logs_arn": auditBucketARN,
+			"kms_master_key_arn":       masterKeyARN,
+		},
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	roleARN := terraform.Output(t, terraformOptions, "app_iam_role_arn")
+
+	testCases := []struct {
+		name        string
+		action      string
+		resource    string
+		expectAllow bool
+	}{
+		{name: "ReadTenantDocument", action: "s3:GetObject", resource: documentsBucketARN + "/tenants/tenant-1/record.pdf", expectAllow: true},
+		{name: "ReadDocumentOutsideTenants", action: "s3:GetObject", resource: documentsBucketARN + "/admin/export.csv", expectAllow: false},
+		{name: "ReadAuditLogs", action: "s3:GetObject", resource: auditBucketARN + "/application-logs/app.log", expectAllow: false},
+		{name: "DecryptWithMasterKey", action: "kms:Decrypt", resource: masterKeyARN, expectAllow: true},
+		{name: "ScheduleMasterKeyDeletion", action: "kms:ScheduleKeyDeletion", resource: masterKeyARN, expectAllow: false},
+		{name: "InvokeApprovedModel", action: "bedrock:InvokeModel", resource: fmt.Sprintf("arn:aws:bedrock:%s::foundation-model/anthropic.claude-3-sonnet-20240229-v1:0", awsRegion), expectAllow: true},
+		{name: "InvokeUnapprovedModel", action: "bedrock:InvokeModel", resource: fmt.Sprintf("arn:aws:bedrock:%s::foundation-model/amazon.titan-text-express-v1", awsRegion), expectAllow: false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			helpers.AssertSimulated(t, awsRegion, roleARN, tc.action, tc.resource, tc.expectAllow)
+		})
+	}
+}
+
+// TestIAMModulePresignedURLPolicy verifies pre-signed document GETs are granted only over TLS and within the expiry cap
+func TestIAMModulePresignedURLPolicy(t *testing.T) {
+	t.Parallel()
+
+	documentsBucketARN := "arn:aws:s3:::presigned-docs-bucket"
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../../modules/iam",
+		Vars: map[string]interface{}{
+			"environment":                      "dev",
+			"name_suffix":                      helpers.UniqueNameSuffix(t),
+			"s3_bucket_documents_arn":          documentsBucketARN,
+			"s3_bucket_backups_arn":            "arn:aws:s3:::presigned-backups-bucket",
+			"s3_bucket_audit_logs_arn":         "arn:aws:s3:::presigned-audit-bucket",
+			"kms_master_key_arn":               fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/presigned-key-id", aws.GetAccountId(t)),
+			"require_secure_transport":         false,
+			"enable_presigned_urls":            true,
+			"presigned_url_max_expiry_seconds": 120,
+		},
+		PlanFilePath: filepath.Join(t.TempDir(), "presigned.tfplan"),
+		NoColor:      true,
+	}
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, terraformOptions)
+
+	outputChange, ok := plan.RawPlan.OutputChanges["presigned_url_policy_document"]
+	require.True(t, ok, "Plan should include presigned_url_policy_document")
+	policyJSON, ok := outputChange.After.(string)
+	require.True(t, ok, "presigned_url_policy_document should be known at plan time")
+
+	var policy struct {
+		Statement []struct {
+			Sid       string
+			Effect    string
+			Action    []string
+			Resource  []string
+			Condition map[string]map[string]interface{}
+		}
+	}
+	require.NoError(t, json.Unmarshal([]byte(policyJSON), &policy), "Pre-signed URL policy should be valid JSON")
+
+	var allowFound, expiryDenyFound, tlsDenyFound bool
+	for _, statement := range policy.Statement {
+		assert.Equal(t, []string{"s3:GetObject"}, statement.Action, "Statement %s should only cover GetObject", statement.Sid)
+		assert.Equal(t, "REST-QUERY-STRING", statement.Condition["StringEquals"]["s3:authType"], "Statement %s should only apply to pre-signed requests", statement.Sid)
+
+		switch {
+		case statement.Effect == "Allow":
+			allowFound = true
+			assert.Equal(t, []string{documentsBucketARN + "/tenants/*"}, statement.Resource)
+			// TLS is required here even though require_secure_transport is off
+			assert.Equal(t, []interface{}{"true"}, statement.Condition["Bool"]["aws:SecureTransport"], "Pre-signed GETs must require TLS")
+			assert.EqualValues(t, 120000, statement.Condition["NumericLessThanEquals"]["s3:signatureAge"], "Signature age should be capped at the max expiry")
+		case statement.Condition["NumericGreaterThan"] != nil:
+			expiryDenyFound = true
+			assert.Equal(t, "Deny", statement.Effect)
+			assert.Equal(t, []string{documentsBucketARN + "/*"}, statement.Resource)
+			assert.EqualValues(t, 120000, statement.Condition["NumericGreaterThan"]["s3:signatureAge"])
+		default:
+			tlsDenyFound = true
+			assert.Equal(t, "Deny", statement.Effect)
+			assert.Equal(t, []interface{}{"false"}, statement.Condition["Bool"]["aws:SecureTransport"])
+		}
+	}
+	assert.True(t, allowFound, "Policy should allow pre-signed document GETs")
+	assert.True(t, expiryDenyFound, "Policy should deny pre-signed URLs older than the cap")
+	assert.True(t, tlsDenyFound, "Policy should deny pre-signed URLs over plain HTTP")
+
+	_, attached := plan.ResourcePlannedValuesMap["aws_iam_role_policy_attachment.presigned_url_access[0]"]
+	assert.True(t, attached, "Pre-signed URL policy should be attached to the app role")
+}
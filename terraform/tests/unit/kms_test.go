@@ -3,12 +3,14 @@ package test
 import (
 	"encoding/json"
 	"fmt"
-	"strings"
+	"path/filepath"
 	"testing"
 
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kms"
 	"github.com/gruntwork-io/terratest/modules/aws"
-	"github.com/gruntwork-io/terratest/modules/random"
 	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hipaa-compliant-stack/terraform/tests/helpers"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -16,14 +18,13 @@ import (
 // TestKMSKeyCreation verifies that the KMS master key is created successfully
 func TestKMSKeyCreation(t *testing.T) {
 	t.Parallel()
-	uniqueID := random.UniqueId()
 	awsAccountID := aws.GetAccountId(t) // Dynamically get AWS account ID
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/kms",
 		Vars: map[string]interface{}{
 			"environment":         "dev",
-			"name_suffix":         strings.ToLower(fmt.Sprintf("test-%s", uniqueID)),
+			"name_suffix":         helpers.UniqueNameSuffix(t),
 			"aws_account_id":      awsAccountID,
 			"enable_key_rotation": true,
 			"tags": map[string]string{
@@ -50,13 +51,12 @@ func TestKMSKeyCreation(t *testing.T) {
 // TestKMSKeyRotationEnabled verifies that automatic key rotation is enabled
 func TestKMSKeyRotationEnabled(t *testing.T) {
 	t.Parallel()
-	uniqueID := random.UniqueId()
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/kms",
 		Vars: map[string]interface{}{
 			"environment":         "dev",
-			"name_suffix":         strings.ToLower(fmt.Sprintf("test-%s", uniqueID)),
+			"name_suffix":         helpers.UniqueNameSuffix(t),
 			"aws_account_id":      aws.GetAccountId(t),
 			"enable_key_rotation": true,
 			"tags": map[string]string{
@@ -80,13 +80,12 @@ func TestKMSKeyRotationEnabled(t *testing.T) {
 // TestKMSKeyRotationDisabled verifies that key rotation can be disabled
 func TestKMSKeyRotationDisabled(t *testing.T) {
 	t.Parallel()
-	uniqueID := random.UniqueId()
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/kms",
 		Vars: map[string]interface{}{
 			"environment":         "dev",
-			"name_suffix":         strings.ToLower(fmt.Sprintf("test-%s", uniqueID)),
+			"name_suffix":         helpers.UniqueNameSuffix(t),
 			"aws_account_id":      aws.GetAccountId(t),
 			"enable_key_rotation": false,
 			"tags": map[string]string{
@@ -107,10 +106,9 @@ func TestKMSKeyRotationDisabled(t *testing.T) {
 // TestKMSKeyAlias verifies that KMS key alias is created correctly
 func TestKMSKeyAlias(t *testing.T) {
 	t.Parallel()
-	uniqueID := random.UniqueId()
 
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/kms",
@@ -133,13 +131,44 @@ func TestKMSKeyAlias(t *testing.T) {
 	// Verify alias output is non-empty
 	alias := terraform.Output(t, terraformOptions, "kms_key_alias")
 	assert.NotEmpty(t, alias, "KMS key alias should not be empty")
-	assert.Equal(t, "alias/hipaa-master-"+environment, alias, "Alias should match expected format")
+	assert.Equal(t, "alias/hipaa-master-"+environment+"-"+nameSuffix, alias, "Alias should match expected format")
+}
+
+// TestKMSAliasResolvesToKey verifies the alias resolves in AWS to the key this module created, not a stale one
+func TestKMSAliasResolvesToKey(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	environment := "dev"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/kms",
+		Vars: map[string]interface{}{
+			"environment":    environment,
+			"name_suffix":    nameSuffix,
+			"aws_account_id": aws.GetAccountId(t),
+			"allow_destroy":  true,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	alias := terraform.Output(t, terraformOptions, "kms_key_alias")
+	require.Equal(t, fmt.Sprintf("alias/hipaa-master-%s-%s", environment, nameSuffix), alias)
+
+	keyID := terraform.Output(t, terraformOptions, "kms_master_key_id")
+	assert.Equal(t, keyID, helpers.ResolveKMSAlias(t, awsRegion, alias), "Alias should point at the module's master key")
 }
 
 // TestKMSKeyPolicy verifies that the key policy is correctly configured
 func TestKMSKeyPolicy(t *testing.T) {
 	t.Parallel()
-	uniqueID := random.UniqueId()
 
 	accountID := aws.GetAccountId(t)
 
@@ -147,7 +176,7 @@ func TestKMSKeyPolicy(t *testing.T) {
 		TerraformDir: "../../modules/kms",
 		Vars: map[string]interface{}{
 			"environment":         "dev",
-			"name_suffix":         strings.ToLower(fmt.Sprintf("test-%s", uniqueID)),
+			"name_suffix":         helpers.UniqueNameSuffix(t),
 			"aws_account_id":      accountID,
 			"enable_key_rotation": true,
 			"tags": map[string]string{
@@ -168,6 +197,125 @@ func TestKMSKeyPolicy(t *testing.T) {
 	assert.Contains(t, keyARN, accountID, "Key ARN should contain the AWS account ID")
 }
 
+// TestKMSBackupServiceAccess verifies the AWS Backup grant is present only when backups are enabled
+func TestKMSBackupServiceAccess(t *testing.T) {
+	t.Parallel()
+
+	accountID := aws.GetAccountId(t)
+
+	testCases := []struct {
+		name          string
+		enableBackups bool
+	}{
+		{"BackupsEnabled", true},
+		{"BackupsDisabled", false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			nameSuffix := helpers.UniqueNameSuffix(t)
+
+			terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+				TerraformDir: "../../modules/kms",
+				Vars: map[string]interface{}{
+					"environment":                  "dev",
+					"name_suffix":                  nameSuffix,
+					"aws_account_id":               accountID,
+					"enable_backup_service_access": tc.enableBackups,
+					"tags": map[string]string{
+						"TestName": "TestKMSBackupServiceAccess",
+					},
+				},
+				NoColor: true,
+			})
+
+			defer terraform.Destroy(t, terraformOptions)
+
+			terraform.InitAndApply(t, terraformOptions)
+
+			policy := parseJSONOutput(t, terraform.Output(t, terraformOptions, "kms_key_policy"))
+			statements, ok := policy["Statement"].([]interface{})
+			require.True(t, ok, "Key policy should contain a Statement list")
+
+			var backupStatement map[string]interface{}
+			for _, raw := range statements {
+				statement := raw.(map[string]interface{})
+				principal, _ := statement["Principal"].(map[string]interface{})
+				if principal["Service"] == "backup.amazonaws.com" {
+					backupStatement = statement
+				}
+			}
+
+			if !tc.enableBackups {
+				assert.Nil(t, backupStatement, "Key policy should not grant backup.amazonaws.com when backups are disabled")
+				return
+			}
+
+			require.NotNil(t, backupStatement, "Key policy should grant backup.amazonaws.com when backups are enabled")
+			assert.ElementsMatch(t, []interface{}{
+				"kms:Decrypt",
+				"kms:Encrypt",
+				"kms:GenerateDataKey*",
+				"kms:ReEncrypt*",
+				"kms:DescribeKey",
+				"kms:CreateGrant",
+			}, backupStatement["Action"], "AWS Backup should receive only the actions needed to copy snapshots")
+
+			condition, ok := backupStatement["Condition"].(map[string]interface{})
+			require.True(t, ok, "AWS Backup statement should be conditioned")
+			sourceArn := condition["ArnLike"].(map[string]interface{})["aws:SourceArn"]
+			assert.Equal(t, fmt.Sprintf("arn:aws:backup:*:%s:backup-vault:hipaa-backup-vault-dev-%s", accountID, nameSuffix), sourceArn,
+				"AWS Backup access should be scoped to the backup vault")
+		})
+	}
+}
+
+// TestKMSKeyEnabledAcrossReapply verifies a key left pending deletion is recovered and stays Enabled after re-apply
+func TestKMSKeyEnabledAcrossReapply(t *testing.T) {
+	t.Parallel()
+	awsRegion := "us-east-1"
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/kms",
+		Vars: map[string]interface{}{
+			"environment":    "dev",
+			"name_suffix":    helpers.UniqueNameSuffix(t),
+			"aws_account_id": aws.GetAccountId(t),
+			"tags": map[string]string{
+				"TestName": "TestKMSKeyEnabledAcrossReapply",
+			},
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+
+	terraform.InitAndApply(t, terraformOptions)
+
+	keyID := terraform.Output(t, terraformOptions, "kms_master_key_id")
+	assert.Equal(t, kms.KeyStateEnabled, helpers.GetKMSKeyState(t, awsRegion, keyID), "Key should be Enabled after apply")
+
+	// Simulate a half-finished destroy that scheduled the key for deletion
+	kmsClient := aws.NewKmsClient(t, awsRegion)
+	_, err := kmsClient.ScheduleKeyDeletion(&kms.ScheduleKeyDeletionInput{
+		KeyId:               awssdk.String(keyID),
+		PendingWindowInDays: awssdk.Int64(7),
+	})
+	require.NoError(t, err)
+	require.Equal(t, kms.KeyStatePendingDeletion, helpers.GetKMSKeyState(t, awsRegion, keyID))
+
+	helpers.EnsureKMSKeyEnabled(t, awsRegion, keyID)
+	terraform.Apply(t, terraformOptions)
+
+	assert.Equal(t, keyID, terraform.Output(t, terraformOptions, "kms_master_key_id"), "Re-apply should keep the recovered key rather than replace it")
+	assert.Equal(t, kms.KeyStateEnabled, helpers.GetKMSKeyState(t, awsRegion, keyID), "Key should be Enabled after re-apply")
+}
+
 // TestKMSMultipleEnvironments verifies that different environments can be deployed
 func TestKMSMultipleEnvironments(t *testing.T) {
 	t.Parallel()
@@ -178,13 +326,14 @@ func TestKMSMultipleEnvironments(t *testing.T) {
 		env := env // Capture range variable
 		t.Run(env, func(t *testing.T) {
 			t.Parallel()
-			uniqueID := random.UniqueId()
+
+			nameSuffix := helpers.UniqueNameSuffix(t)
 
 			terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 				TerraformDir: "../../modules/kms",
 				Vars: map[string]interface{}{
 					"environment":         env,
-					"name_suffix":         strings.ToLower(fmt.Sprintf("test-%s", uniqueID)),
+					"name_suffix":         nameSuffix,
 					"aws_account_id":      aws.GetAccountId(t),
 					"enable_key_rotation": true,
 					"tags": map[string]string{
@@ -200,7 +349,7 @@ func TestKMSMultipleEnvironments(t *testing.T) {
 			terraform.InitAndApply(t, terraformOptions)
 
 			alias := terraform.Output(t, terraformOptions, "kms_key_alias")
-			assert.Equal(t, "alias/hipaa-master-"+env, alias, "Alias should match environment")
+			assert.Equal(t, "alias/hipaa-master-"+env+"-"+nameSuffix, alias, "Alias should match environment")
 		})
 	}
 }
@@ -208,7 +357,6 @@ func TestKMSMultipleEnvironments(t *testing.T) {
 // TestKMSKeyTags verifies that custom tags are applied correctly
 func TestKMSKeyTags(t *testing.T) {
 	t.Parallel()
-	uniqueID := random.UniqueId()
 
 	customTags := map[string]string{
 		"Project":    "HIPAA Compliant Stack",
@@ -220,7 +368,7 @@ func TestKMSKeyTags(t *testing.T) {
 		TerraformDir: "../../modules/kms",
 		Vars: map[string]interface{}{
 			"environment":         "dev",
-			"name_suffix":         strings.ToLower(fmt.Sprintf("test-%s", uniqueID)),
+			"name_suffix":         helpers.UniqueNameSuffix(t),
 			"aws_account_id":      aws.GetAccountId(t),
 			"enable_key_rotation": true,
 			"tags":                customTags,
@@ -260,6 +408,242 @@ func TestKMSInvalidEnvironment(t *testing.T) {
 	assert.Contains(t, err.Error(), "Environment must be dev, staging, or production")
 }
 
+// TestKMSKeyAdministratorsAndUsers verifies key administrators cannot use the key, key users cannot manage it and the account root no longer delegates key use to IAM
+func TestKMSKeyAdministratorsAndUsers(t *testing.T) {
+	t.Parallel()
+
+	accountID := aws.GetAccountId(t)
+	adminArn := fmt.Sprintf("arn:aws:iam::%s:role/key-admin", accountID)
+	userArn := fmt.Sprintf("arn:aws:iam::%s:role/key-user", accountID)
+	rootArn := fmt.Sprintf("arn:aws:iam::%s:root", accountID)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/kms",
+		Vars: map[string]interface{}{
+			"environment":            "dev",
+			"name_suffix":            helpers.UniqueNameSuffix(t),
+			"aws_account_id":         accountID,
+			"key_administrator_arns": []string{adminArn},
+			"key_user_arns":          []string{userArn},
+		},
+		PlanFilePath: filepath.Join(t.TempDir(), "key-roles.tfplan"),
+		NoColor:      true,
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, terraformOptions)
+
+	masterKey, ok := plan.ResourcePlannedValuesMap["aws_kms_key.master"]
+	require.True(t, ok, "Plan should include the master key")
+	keyPolicy, ok := masterKey.AttributeValues["policy"].(string)
+	require.True(t, ok, "Key policy should be known at plan time")
+
+	statements, ok := parseJSONOutput(t, keyPolicy)["Statement"].([]interface{})
+	require.True(t, ok, "Key policy should contain a Statement list")
+
+	// Actions granted to each principal, across all statements naming it
+	grantedActions := map[string][]string{}
+	for _, raw := range statements {
+		statement := raw.(map[string]interface{})
+		principal, _ := statement["Principal"].(map[string]interface{})
+		var principals []interface{}
+		switch value := principal["AWS"].(type) {
+		case string:
+			principals = []interface{}{value}
+		case []interface{}:
+			principals = value
+		}
+
+		var actions []interface{}
+		switch value := statement["Action"].(type) {
+		case string:
+			actions = []interface{}{value}
+		case []interface{}:
+			actions = value
+		}
+
+		for _, p := range principals {
+			for _, action := range actions {
+				grantedActions[p.(string)] = append(grantedActions[p.(string)], action.(string))
+			}
+		}
+	}
+
+	require.NotEmpty(t, grantedActions[adminArn], "Key administrators should be granted in the key policy")
+	require.NotEmpty(t, grantedActions[userArn], "Key users should be granted in the key policy")
+
+	for _, action := range grantedActions[adminArn] {
+		assert.NotContains(t, []string{"kms:*", "kms:Decrypt", "kms:Encrypt", "kms:ReEncrypt*", "kms:GenerateDataKey*"}, action,
+			"Key administrators should not be able to use the key")
+	}
+	assert.Contains(t, grantedActions[adminArn], "kms:ScheduleKeyDeletion", "Key administrators should be able to schedule deletion")
+
+	for _, action := range grantedActions[userArn] {
+		assert.NotContains(t, []string{"kms:*", "kms:ScheduleKeyDeletion", "kms:PutKeyPolicy", "kms:Put*", "kms:Delete*", "kms:Disable*"}, action,
+			"Key users should not be able to manage or delete the key")
+	}
+	assert.Contains(t, grantedActions[userArn], "kms:Decrypt", "Key users should be able to decrypt")
+
+	// Any IAM principal in the account could otherwise use the key through the root statement
+	for _, action := range grantedActions[rootArn] {
+		assert.NotContains(t, []string{"kms:*", "kms:Decrypt", "kms:Encrypt", "kms:ReEncrypt*", "kms:GenerateDataKey*"}, action,
+			"The account root should only delegate key management once key users are named")
+	}
+	assert.Contains(t, grantedActions[rootArn], "kms:Put*", "The account root should keep key policy management to avoid lockout")
+}
+
+// TestKMSRotationStatus verifies the rotation status output and tags reflect enable_key_rotation
+func TestKMSRotationStatus(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		rotation         bool
+		expectedSchedule string
+	}{
+		{name: "Enabled", rotation: true, expectedSchedule: "Every 365 days"},
+		{name: "Disabled", rotation: false, expectedSchedule: "Disabled"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			terraformOptions := &terraform.Options{
+				TerraformDir: "../../modules/kms",
+				Vars: map[string]interface{}{
+					"environment":         "dev",
+					"aws_account_id":      aws.GetAccountId(t),
+					"enable_key_rotation": tc.rotation,
+				},
+				PlanFilePath: filepath.Join(t.TempDir(), "rotation.tfplan"),
+				NoColor:      true,
+			}
+
+			plan := terraform.InitAndPlanAndShowWithStruct(t, terraformOptions)
+
+			outputChange, ok := plan.RawPlan.OutputChanges["kms_rotation_status"]
+			require.True(t, ok, "Plan should include kms_rotation_status")
+			status, ok := outputChange.After.(map[string]interface{})
+			require.True(t, ok, "kms_rotation_status should be known at plan time")
+			assert.Equal(t, tc.rotation, status["enabled"])
+			assert.Equal(t, tc.expectedSchedule, status["schedule"])
+
+			masterKey, ok := plan.ResourcePlannedValuesMap["aws_kms_key.master"]
+			require.True(t, ok, "Plan should include the master key")
+			tags, _ := masterKey.AttributeValues["tags"].(map[string]interface{})
+			assert.Equal(t, fmt.Sprintf("%t", tc.rotation), tags["RotationEnabled"])
+			assert.Equal(t, tc.expectedSchedule, tags["RotationSchedule"])
+		})
+	}
+}
+
+// TestKMSReplicaKeyPolicy verifies the replica key policy lets DR-region services and the app role use the key
+func TestKMSReplicaKeyPolicy(t *testing.T) {
+	t.Parallel()
+
+	replicaRegion := "us-west-2"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../fixtures/kms_replica",
+		Vars: map[string]interface{}{
+			"name_suffix":    nameSuffix,
+			"replica_region": replicaRegion,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	replicaKeyArn := terraform.Output(t, terraformOptions, "kms_replica_key_arn")
+	require.Contains(t, replicaKeyArn, replicaRegion, "Replica key should live in the replica region")
+	appRoleArn := terraform.Output(t, terraformOptions, "app_role_arn")
+
+	policy := helpers.GetKMSKeyPolicy(t, replicaRegion, replicaKeyArn)
+	statements, ok := parseJSONOutput(t, policy)["Statement"].([]interface{})
+	require.True(t, ok, "Replica key policy should contain a Statement list")
+
+	// Actions granted to each service or AWS principal, across all statements naming it
+	grantedActions := map[string][]string{}
+	for _, raw := range statements {
+		statement := raw.(map[string]interface{})
+		principal, _ := statement["Principal"].(map[string]interface{})
+		var principals []interface{}
+		for _, key := range []string{"Service", "AWS"} {
+			switch value := principal[key].(type) {
+			case string:
+				principals = append(principals, value)
+			case []interface{}:
+				principals = append(principals, value...)
+			}
+		}
+
+		var actions []interface{}
+		switch value := statement["Action"].(type) {
+		case string:
+			actions = []interface{}{value}
+		case []interface{}:
+			actions = value
+		}
+
+		for _, p := range principals {
+			for _, a := range actions {
+				grantedActions[p.(string)] = append(grantedActions[p.(string)], a.(string))
+			}
+		}
+	}
+
+	assert.Contains(t, grantedActions["s3.amazonaws.com"], "kms:Decrypt", "S3 in the DR region should decrypt replicated objects")
+	assert.Contains(t, grantedActions["s3.amazonaws.com"], "kms:GenerateDataKey", "S3 in the DR region should encrypt replicated objects")
+	assert.Contains(t, grantedActions["rds.amazonaws.com"], "kms:CreateGrant", "RDS in the DR region should be able to use the key")
+	assert.NotEmpty(t, grantedActions[fmt.Sprintf("logs.%s.amazonaws.com", replicaRegion)],
+		"CloudWatch Logs grant should name the replica region, not the primary")
+	assert.Contains(t, grantedActions[appRoleArn], "kms:Decrypt", "App role should decrypt PHI with the replica key")
+	assert.Contains(t, grantedActions[appRoleArn], "kms:GenerateDataKey*", "App role should encrypt PHI with the replica key")
+}
+
+// TestKMSEBSEncryptionByDefault verifies enable_ebs_encryption_by_default plans account-level EBS encryption with the master key as the default key.
+// The settings are account-wide and destroying them turns default encryption off for every test sharing the account, so the test only plans.
+func TestKMSEBSEncryptionByDefault(t *testing.T) {
+	t.Parallel()
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../../modules/kms",
+		Vars: map[string]interface{}{
+			"environment":                      "dev",
+			"name_suffix":                      helpers.UniqueNameSuffix(t),
+			"aws_account_id":                   aws.GetAccountId(t),
+			"enable_ebs_encryption_by_default": true,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": "us-east-1",
+		},
+		PlanFilePath: filepath.Join(t.TempDir(), "ebs-encryption.tfplan"),
+		NoColor:      true,
+	}
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, terraformOptions)
+
+	encryption, ok := plan.ResourcePlannedValuesMap["aws_ebs_encryption_by_default.main[0]"]
+	require.True(t, ok, "EBS encryption by default should be planned when enabled")
+	assert.Equal(t, true, encryption.AttributeValues["enabled"], "EBS encryption by default should be enabled")
+
+	_, ok = plan.ResourcePlannedValuesMap["aws_ebs_default_kms_key.main[0]"]
+	require.True(t, ok, "The default EBS key should be planned when enabled")
+
+	// The key ARN is unknown until apply, so check the wiring instead of the value
+	var keyReferences []string
+	for _, resource := range plan.RawPlan.Config.RootModule.Resources {
+		if resource.Address == "aws_ebs_default_kms_key.main" {
+			require.NotNil(t, resource.Expressions["key_arn"])
+			keyReferences = resource.Expressions["key_arn"].References
+		}
+	}
+	assert.True(t, containsReference(keyReferences, "aws_kms_key.master"), "Master key should be the default EBS key")
+}
+
 // Helper function to parse JSON output (if needed for complex assertions)
 func parseJSONOutput(t *testing.T, output string) map[string]interface{} {
 	var result map[string]interface{}
@@ -0,0 +1,133 @@
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ==============================================================================
+// Destroy Guard Tests
+// ==============================================================================
+// lifecycle.prevent_destroy must be a literal, so setting it would also block
+// the Destroy every test defers. Stateful resources instead carry an
+// attribute-level guard that references var.allow_destroy, which tests set to
+// tear down cleanly and production leaves at its false default.
+
+// destroyGuardAttributes maps each stateful resource type to the attribute guarding its deletion
+var destroyGuardAttributes = map[string]string{
+	"aws_s3_bucket":       "force_destroy",
+	"aws_db_instance":     "deletion_protection",
+	"aws_rds_cluster":     "deletion_protection",
+	"aws_kms_key":         "deletion_window_in_days",
+	"aws_kms_replica_key": "deletion_window_in_days",
+}
+
+// unguardedStatefulResources lists stateful resources exempt from a destroy guard
+var unguardedStatefulResources = map[string]string{
+	"aws_db_instance.read_replica":      "rebuilt from the primary",
+	"aws_db_instance.reporting_replica": "rebuilt from the primary",
+}
+
+// TestStatefulResourcesHaveDestroyGuards verifies every bucket, DB instance, Aurora cluster and KMS key is guarded and overridable by allow_destroy
+func TestStatefulResourcesHaveDestroyGuards(t *testing.T) {
+	t.Parallel()
+
+	moduleDirs, err := filepath.Glob("../../modules/*")
+	require.NoError(t, err)
+	require.NotEmpty(t, moduleDirs)
+
+	for _, moduleDir := range moduleDirs {
+		moduleDir := moduleDir
+		t.Run(filepath.Base(moduleDir), func(t *testing.T) {
+			t.Parallel()
+
+			blocks := parseModuleBlocks(t, moduleDir)
+
+			guarded := 0
+			for _, block := range blocks {
+				if block.Type != "resource" || len(block.Labels) != 2 {
+					continue
+				}
+				address := block.Labels[0] + "." + block.Labels[1]
+
+				// A literal prevent_destroy would make test teardown impossible
+				for _, nested := range block.Body.Blocks {
+					if nested.Type == "lifecycle" {
+						assert.NotContains(t, nested.Body.Attributes, "prevent_destroy",
+							"%s should use an allow_destroy-aware guard instead of lifecycle.prevent_destroy", address)
+					}
+				}
+
+				guardAttribute, stateful := destroyGuardAttributes[block.Labels[0]]
+				if !stateful {
+					continue
+				}
+				if _, exempt := unguardedStatefulResources[address]; exempt {
+					continue
+				}
+
+				attribute, ok := block.Body.Attributes[guardAttribute]
+				if !assert.True(t, ok, "%s should set %s", address, guardAttribute) {
+					continue
+				}
+				assert.True(t, referencesVariable(attribute.Expr, "allow_destroy"),
+					"%s.%s should be overridable through var.allow_destroy", address, guardAttribute)
+				guarded++
+			}
+
+			if guarded == 0 {
+				return
+			}
+
+			// Modules with guarded resources must expose the escape hatch, off by default
+			var allowDestroy *hclsyntax.Block
+			for _, block := range blocks {
+				if block.Type == "variable" && len(block.Labels) == 1 && block.Labels[0] == "allow_destroy" {
+					allowDestroy = block
+				}
+			}
+			require.NotNil(t, allowDestroy, "Module with stateful resources should declare variable allow_destroy")
+
+			defaultAttribute, ok := allowDestroy.Body.Attributes["default"]
+			require.True(t, ok, "allow_destroy should have a default")
+			defaultValue, diags := defaultAttribute.Expr.Value(nil)
+			require.False(t, diags.HasErrors(), diags.Error())
+			assert.True(t, defaultValue.False(), "allow_destroy should default to false so guards are on in production")
+		})
+	}
+}
+
+// parseModuleBlocks returns the top-level blocks of every .tf file in a module directory
+func parseModuleBlocks(t *testing.T, moduleDir string) []*hclsyntax.Block {
+	files, err := filepath.Glob(filepath.Join(moduleDir, "*.tf"))
+	require.NoError(t, err)
+
+	parser := hclparse.NewParser()
+	var blocks []*hclsyntax.Block
+	for _, path := range files {
+		file, diags := parser.ParseHCLFile(path)
+		require.False(t, diags.HasErrors(), diags.Error())
+		blocks = append(blocks, file.Body.(*hclsyntax.Body).Blocks...)
+	}
+
+	return blocks
+}
+
+// referencesVariable reports whether an expression reads var.<name>
+func referencesVariable(expr hclsyntax.Expression, name string) bool {
+	for _, traversal := range expr.Variables() {
+		if traversal.RootName() != "var" || len(traversal) < 2 {
+			continue
+		}
+		if attr, ok := traversal[1].(hcl.TraverseAttr); ok && attr.Name == name {
+			return true
+		}
+	}
+	return false
+}
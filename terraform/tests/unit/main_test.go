@@ -0,0 +1,91 @@
+package test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hipaa-compliant-stack/terraform/tests/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ==============================================================================
+// Test Binary Setup
+// ==============================================================================
+// Parallel tests create buckets, keys and roles named after their name_suffix.
+// helpers.UniqueNameSuffix registers every suffix it hands out, and TestMain
+// fails the run if any test was refused a suffix another test already held,
+// so a shared name cannot surface later as an intermittent apply failure.
+// ==============================================================================
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+
+	if collisions := helpers.NameSuffixCollisions(); len(collisions) > 0 {
+		fmt.Fprintf(os.Stderr, "name_suffix collisions between tests:\n  %s\n", strings.Join(collisions, "\n  "))
+		if code == 0 {
+			code = 1
+		}
+	}
+
+	os.Exit(code)
+}
+
+// TestNameSuffixRegistry verifies suffixes are unique across parallel callers and a suffix cannot be claimed by two tests
+func TestNameSuffixRegistry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unique under parallel use", func(t *testing.T) {
+		const callers = 200
+
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		seen := map[string]bool{}
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				suffix := helpers.UniqueNameSuffix(t)
+				mu.Lock()
+				defer mu.Unlock()
+				assert.False(t, seen[suffix], "suffix %s handed out twice", suffix)
+				seen[suffix] = true
+			}()
+		}
+		wg.Wait()
+
+		assert.Len(t, seen, callers)
+		for suffix := range seen {
+			assert.True(t, strings.HasPrefix(suffix, "test-"), "suffix %s should keep the test- prefix", suffix)
+		}
+	})
+
+	t.Run("owner", func(t *testing.T) {
+		suffix := helpers.UniqueNameSuffix(t)
+		require.NoError(t, helpers.RegisterNameSuffixE(t, suffix), "a test may reuse its own suffix")
+
+		t.Run("subtest", func(t *testing.T) {
+			assert.NoError(t, helpers.RegisterNameSuffixE(t, suffix), "subtests may share their parent's suffix")
+		})
+
+		t.Run("other", func(t *testing.T) {
+			assert.NoError(t, helpers.RegisterNameSuffixE(t, suffix+"-other"))
+		})
+	})
+
+	t.Run("collision", func(t *testing.T) {
+		var suffix string
+		t.Run("first", func(t *testing.T) {
+			suffix = helpers.UniqueNameSuffix(t)
+		})
+
+		t.Run("second", func(t *testing.T) {
+			err := helpers.RegisterNameSuffixE(t, suffix)
+			require.Error(t, err, "a suffix held by another test must be refused")
+			assert.Contains(t, err.Error(), "collision/first")
+		})
+	})
+}
@@ -0,0 +1,268 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hipaa-compliant-stack/terraform/tests/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMonitoringCentralAlarmRegion verifies critical alarms notify the central-region topic only when central_alarm_region is set
+func TestMonitoringCentralAlarmRegion(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	centralRegion := "us-west-2"
+
+	testCases := []struct {
+		name                 string
+		centralAlarmRegion   string
+		expectCentralActions bool
+	}{
+		{name: "CentralRegionSet", centralAlarmRegion: centralRegion, expectCentralActions: true},
+		{name: "CentralRegionUnset", centralAlarmRegion: "", expectCentralActions: false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			nameSuffix := helpers.UniqueNameSuffix(t)
+
+			terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+				TerraformDir: "../fixtures/monitoring",
+				Vars: map[string]interface{}{
+					"aws_region":           awsRegion,
+					"central_alarm_region": tc.centralAlarmRegion,
+					"name_suffix":          nameSuffix,
+				},
+				NoColor: true,
+			})
+
+			defer terraform.Destroy(t, terraformOptions)
+			terraform.InitAndApply(t, terraformOptions)
+
+			regionalTopicARN := terraform.Output(t, terraformOptions, "alarm_topic_arn")
+			centralTopicARN := terraform.Output(t, terraformOptions, "central_alarm_topic_arn")
+
+			if tc.expectCentralActions {
+				require.NotEmpty(t, centralTopicARN)
+				assert.Contains(t, centralTopicARN, fmt.Sprintf(":sns:%s:", centralRegion), "Central topic should live in the central region")
+			} else {
+				assert.Empty(t, centralTopicARN)
+			}
+
+			alarmNames := terraform.OutputMap(t, terraformOptions, "critical_alarm_names")
+			require.NotEmpty(t, alarmNames)
+
+			for key, alarmName := range alarmNames {
+				alarm := helpers.GetMetricAlarm(t, awsRegion, alarmName)
+				actions := awssdk.StringValueSlice(alarm.AlarmActions)
+
+				assert.Contains(t, actions, regionalTopicARN, "Alarm %s should notify the regional topic", key)
+				if tc.expectCentralActions {
+					assert.Contains(t, actions, centralTopicARN, "Alarm %s should notify the central-region topic", key)
+				} else {
+					assert.Len(t, actions, 1, "Alarm %s should only notify the regional topic", key)
+				}
+			}
+		})
+	}
+}
+
+// TestMonitoringRDSThroughputAlarm verifies the gp3 throughput alarm sums read and write throughput of the RDS instance
+func TestMonitoringRDSThroughputAlarm(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../fixtures/monitoring",
+		Vars: map[string]interface{}{
+			"aws_region":           awsRegion,
+			"central_alarm_region": "",
+			"name_suffix":          nameSuffix,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	alarmARN := terraform.Output(t, terraformOptions, "rds_throughput_alarm_arn")
+	rdsIdentifier := terraform.Output(t, terraformOptions, "rds_instance_identifier")
+	alarmNames := terraform.OutputMap(t, terraformOptions, "critical_alarm_names")
+	require.Contains(t, alarmNames, "rds_storage_throughput_high")
+
+	alarm := helpers.GetMetricAlarm(t, awsRegion, alarmNames["rds_storage_throughput_high"])
+	assert.Equal(t, alarmARN, awssdk.StringValue(alarm.AlarmArn))
+	assert.Contains(t, awssdk.StringValueSlice(alarm.AlarmActions), terraform.Output(t, terraformOptions, "alarm_topic_arn"))
+
+	metricNames := []string{}
+	for _, query := range alarm.Metrics {
+		if query.MetricStat == nil {
+			continue
+		}
+		metric := query.MetricStat.Metric
+		metricNames = append(metricNames, awssdk.StringValue(metric.MetricName))
+		assert.Equal(t, "AWS/RDS", awssdk.StringValue(metric.Namespace))
+
+		require.Len(t, metric.Dimensions, 1)
+		assert.Equal(t, "DBInstanceIdentifier", awssdk.StringValue(metric.Dimensions[0].Name))
+		assert.Equal(t, rdsIdentifier, awssdk.StringValue(metric.Dimensions[0].Value), "Throughput metrics should reference the RDS instance")
+	}
+	assert.ElementsMatch(t, []string{"ReadThroughput", "WriteThroughput"}, metricNames)
+}
+
+// TestMonitoringCertExpiryAlarms verifies the ACM and RDS CA expiry alarms use cert_expiry_warning_days and notify the alarm topic
+func TestMonitoringCertExpiryAlarms(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	warningDays := 45
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../fixtures/monitoring",
+		Vars: map[string]interface{}{
+			"aws_region":               awsRegion,
+			"central_alarm_region":     "",
+			"name_suffix":              nameSuffix,
+			"enable_acm_certificate":   true,
+			"cert_expiry_warning_days": warningDays,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	topicARN := terraform.Output(t, terraformOptions, "alarm_topic_arn")
+	certificateARN := terraform.Output(t, terraformOptions, "acm_certificate_arn")
+	certificateID := certificateARN[strings.LastIndex(certificateARN, "/")+1:]
+
+	alarmARNs := terraform.OutputMap(t, terraformOptions, "cert_expiry_alarm_arns")
+	require.Len(t, alarmARNs, 2, "Expected the RDS CA alarm and one ACM alarm")
+	require.Contains(t, alarmARNs, "rds_ca")
+	require.Contains(t, alarmARNs, "acm-"+certificateID)
+
+	for key, alarmARN := range alarmARNs {
+		alarm := helpers.GetMetricAlarm(t, awsRegion, alarmARN[strings.LastIndex(alarmARN, ":alarm:")+len(":alarm:"):])
+		assert.Equal(t, float64(warningDays), awssdk.Float64Value(alarm.Threshold), "Alarm %s should fire at cert_expiry_warning_days", key)
+		assert.Equal(t, "LessThanThreshold", awssdk.StringValue(alarm.ComparisonOperator), "Alarm %s should fire as expiry approaches", key)
+		assert.Contains(t, awssdk.StringValueSlice(alarm.AlarmActions), topicARN, "Alarm %s should notify the alarm topic", key)
+	}
+
+	acmAlarm := helpers.GetMetricAlarm(t, awsRegion, fmt.Sprintf("dev-%s-acm-expiry-%s", nameSuffix, certificateID))
+	assert.Equal(t, "AWS/CertificateManager", awssdk.StringValue(acmAlarm.Namespace))
+	assert.Equal(t, "DaysToExpiry", awssdk.StringValue(acmAlarm.MetricName))
+	require.Len(t, acmAlarm.Dimensions, 1)
+	assert.Equal(t, "CertificateArn", awssdk.StringValue(acmAlarm.Dimensions[0].Name))
+	assert.Equal(t, certificateARN, awssdk.StringValue(acmAlarm.Dimensions[0].Value))
+
+	// The CA alarm counts down to the CA's valid_till, so its expression must embed that instant
+	validTill, err := time.Parse(time.RFC3339, terraform.Output(t, terraformOptions, "rds_ca_valid_till"))
+	require.NoError(t, err)
+	assert.True(t, validTill.After(time.Now()), "The watched RDS CA should not already be expired")
+
+	caAlarm := helpers.GetMetricAlarm(t, awsRegion, terraform.OutputMap(t, terraformOptions, "critical_alarm_names")["rds_ca_expiry"])
+	expressions := []string{}
+	for _, query := range caAlarm.Metrics {
+		if query.Expression != nil {
+			expressions = append(expressions, awssdk.StringValue(query.Expression))
+		}
+	}
+	require.Len(t, expressions, 1)
+	assert.Contains(t, expressions[0], fmt.Sprintf("%d", validTill.Unix()))
+}
+
+// TestMonitoringSIEMForwarding verifies the SIEM Firehose stream is KMS-encrypted and receives Config and GuardDuty events
+func TestMonitoringSIEMForwarding(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../fixtures/monitoring",
+		Vars: map[string]interface{}{
+			"aws_region":             awsRegion,
+			"central_alarm_region":   "",
+			"name_suffix":            nameSuffix,
+			"enable_siem_forwarding": true,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	firehoseARN := terraform.Output(t, terraformOptions, "siem_firehose_arn")
+	require.NotEmpty(t, firehoseARN, "SIEM stream should be created when forwarding is enabled")
+
+	streamName := firehoseARN[strings.LastIndex(firehoseARN, "/")+1:]
+	stream := helpers.GetDeliveryStream(t, awsRegion, streamName)
+	require.NotNil(t, stream.DeliveryStreamEncryptionConfiguration)
+	encryption := stream.DeliveryStreamEncryptionConfiguration
+	assert.Equal(t, "ENABLED", awssdk.StringValue(encryption.Status), "SIEM stream should be encrypted at rest")
+	assert.Equal(t, "CUSTOMER_MANAGED_CMK", awssdk.StringValue(encryption.KeyType))
+	assert.Equal(t, terraform.Output(t, terraformOptions, "siem_kms_key_arn"), awssdk.StringValue(encryption.KeyARN))
+
+	expectedSources := map[string]string{
+		"config-compliance":  "aws.config",
+		"guardduty-findings": "aws.guardduty",
+	}
+	ruleNames := terraform.OutputMap(t, terraformOptions, "siem_event_rule_names")
+	require.Len(t, ruleNames, len(expectedSources))
+
+	for key, source := range expectedSources {
+		ruleName, ok := ruleNames[key]
+		require.True(t, ok, "SIEM should subscribe to %s", key)
+
+		var pattern struct {
+			Source []string `json:"source"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(helpers.GetEventRulePattern(t, awsRegion, ruleName)), &pattern))
+		assert.Equal(t, []string{source}, pattern.Source, "Rule %s should match %s events", key, source)
+		assert.Contains(t, helpers.GetEventRuleTargetArns(t, awsRegion, ruleName), firehoseARN, "Rule %s should target the SIEM stream", key)
+	}
+}
+
+// TestMonitoringXRayEncryption verifies X-Ray trace storage is encrypted with the supplied KMS key
+func TestMonitoringXRayEncryption(t *testing.T) {
+	// The X-Ray encryption config is an account-wide singleton, so this test does not run in parallel
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../fixtures/monitoring",
+		Vars: map[string]interface{}{
+			"aws_region":           awsRegion,
+			"central_alarm_region": "",
+			"name_suffix":          nameSuffix,
+			"enable_xray_tracing":  true,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	keyARN := terraform.Output(t, terraformOptions, "xray_test_key_arn")
+	require.NotEmpty(t, keyARN)
+	assert.Equal(t, keyARN, terraform.Output(t, terraformOptions, "xray_encryption_key_arn"))
+
+	config := helpers.GetXRayEncryptionConfig(t, awsRegion)
+	assert.Equal(t, "KMS", awssdk.StringValue(config.Type), "X-Ray traces should use a customer managed key")
+	assert.Equal(t, keyARN, awssdk.StringValue(config.KeyId), "X-Ray should encrypt with the master key")
+}
@@ -1,22 +1,23 @@
 package test
 
 import (
-	"fmt"
-	"strings"
 	"testing"
 
-	"github.com/gruntwork-io/terratest/modules/random"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/gruntwork-io/terratest/modules/aws"
 	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hipaa-compliant-stack/terraform/tests/helpers"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestNetworkingModuleSecurityGroupsCreated verifies that all three security groups are created
 func TestNetworkingModuleSecurityGroupsCreated(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/networking",
@@ -45,9 +46,8 @@ func TestNetworkingModuleSecurityGroupsCreated(t *testing.T) {
 func TestRDSSecurityGroupIngressRules(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/networking",
@@ -74,9 +74,8 @@ func TestRDSSecurityGroupIngressRules(t *testing.T) {
 func TestAppSecurityGroupConfiguration(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	railwayIPRanges := []string{"192.0.2.0/24", "198.51.100.0/24"}
 
@@ -102,9 +101,8 @@ func TestAppSecurityGroupConfiguration(t *testing.T) {
 func TestVPCEndpointSecurityGroup(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/networking",
@@ -128,9 +126,8 @@ func TestVPCEndpointSecurityGroup(t *testing.T) {
 func TestSecurityGroupsWithEmptyRailwayIPRanges(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/networking",
@@ -160,9 +157,8 @@ func TestSecurityGroupsWithEmptyRailwayIPRanges(t *testing.T) {
 func TestSecurityGroupsEnvironmentTagging(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	customTags := map[string]string{
 		"Project":     "HIPAA-Compliant",
@@ -187,3 +183,201 @@ func TestSecurityGroupsEnvironmentTagging(t *testing.T) {
 	rdsSecurityGroupID := terraform.Output(t, terraformOptions, "rds_security_group_id")
 	assert.NotEmpty(t, rdsSecurityGroupID, "RDS security group should be created with tags")
 }
+
+// TestAppSecurityGroupBedrockEgress verifies restrict_bedrock_egress scopes app HTTPS egress to the endpoint security group
+func TestAppSecurityGroupBedrockEgress(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	vpcID := aws.GetDefaultVpc(t, awsRegion).Id
+
+	testCases := []struct {
+		name     string
+		restrict bool
+	}{
+		{name: "restricted", restrict: true},
+		{name: "unrestricted", restrict: false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			nameSuffix := helpers.UniqueNameSuffix(t)
+
+			terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+				TerraformDir: "../../modules/networking",
+				Vars: map[string]interface{}{
+					"environment":             "dev",
+					"name_suffix":             nameSuffix,
+					"vpc_id":                  vpcID,
+					"railway_ip_ranges":       []string{"192.0.2.0/24"},
+					"restrict_bedrock_egress": tc.restrict,
+				},
+				EnvVars: map[string]string{
+					"AWS_DEFAULT_REGION": awsRegion,
+				},
+				NoColor: true,
+			})
+
+			defer terraform.Destroy(t, terraformOptions)
+			terraform.InitAndApply(t, terraformOptions)
+
+			appGroupID := terraform.Output(t, terraformOptions, "app_security_group_id")
+			endpointGroupID := terraform.Output(t, terraformOptions, "vpc_endpoint_security_group_id")
+
+			ec2Client := aws.NewEc2Client(t, awsRegion)
+			result, err := ec2Client.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{
+				GroupIds: awssdk.StringSlice([]string{appGroupID}),
+			})
+			require.NoError(t, err)
+			require.Len(t, result.SecurityGroups, 1)
+
+			toEndpoints, toInternet := false, false
+			for _, permission := range result.SecurityGroups[0].IpPermissionsEgress {
+				if awssdk.Int64Value(permission.FromPort) != 443 || awssdk.Int64Value(permission.ToPort) != 443 {
+					continue
+				}
+				for _, pair := range permission.UserIdGroupPairs {
+					if awssdk.StringValue(pair.GroupId) == endpointGroupID {
+						toEndpoints = true
+					}
+				}
+				for _, ipRange := range permission.IpRanges {
+					if awssdk.StringValue(ipRange.CidrIp) == "0.0.0.0/0" {
+						toInternet = true
+					}
+				}
+			}
+
+			assert.True(t, toEndpoints, "App HTTPS egress should always target the VPC endpoint security group")
+			if tc.restrict {
+				assert.False(t, toInternet, "App HTTPS egress should not reach 0.0.0.0/0 when restrict_bedrock_egress is set")
+			} else {
+				assert.True(t, toInternet, "App HTTPS egress should reach 0.0.0.0/0 when restrict_bedrock_egress is false")
+			}
+		})
+	}
+}
+
+// TestAppSecurityGroupNoIngressFromRDS verifies traffic between the app and database tiers is one-way, app to RDS
+func TestAppSecurityGroupNoIngressFromRDS(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/networking",
+		Vars: map[string]interface{}{
+			"environment":       "dev",
+			"name_suffix":       nameSuffix,
+			"vpc_id":            aws.GetDefaultVpc(t, awsRegion).Id,
+			"railway_ip_ranges": []string{"192.0.2.0/24"},
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	appGroupID := terraform.Output(t, terraformOptions, "app_security_group_id")
+	rdsGroupID := terraform.Output(t, terraformOptions, "rds_security_group_id")
+
+	ec2Client := aws.NewEc2Client(t, awsRegion)
+	result, err := ec2Client.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{
+		GroupIds: awssdk.StringSlice([]string{appGroupID}),
+	})
+	require.NoError(t, err)
+	require.Len(t, result.SecurityGroups, 1)
+	appGroup := result.SecurityGroups[0]
+
+	// A reversed or symmetric rule would let a compromised database open connections into the app tier
+	for _, permission := range appGroup.IpPermissions {
+		for _, pair := range permission.UserIdGroupPairs {
+			assert.NotEqual(t, rdsGroupID, awssdk.StringValue(pair.GroupId), "App security group must not allow ingress from the RDS security group")
+		}
+	}
+
+	toRDS := false
+	for _, permission := range appGroup.IpPermissionsEgress {
+		if awssdk.Int64Value(permission.FromPort) != 5432 || awssdk.Int64Value(permission.ToPort) != 5432 {
+			continue
+		}
+		for _, pair := range permission.UserIdGroupPairs {
+			if awssdk.StringValue(pair.GroupId) == rdsGroupID {
+				toRDS = true
+			}
+		}
+	}
+	assert.True(t, toRDS, "App security group should reach the RDS security group on 5432 through egress")
+}
+
+// TestBedrockEndpointSecurityGroupAllowsOnlyAppHTTPS verifies the security group on the Bedrock endpoint admits only TCP 443 from the app security group
+func TestBedrockEndpointSecurityGroupAllowsOnlyAppHTTPS(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../fixtures/vpc_endpoints",
+		Vars: map[string]interface{}{
+			"aws_region":  awsRegion,
+			"name_suffix": nameSuffix,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	appGroupID := terraform.Output(t, terraformOptions, "app_security_group_id")
+	endpointGroupID := terraform.Output(t, terraformOptions, "vpc_endpoint_security_group_id")
+
+	// Check the group actually attached to the endpoint, not just the one the module creates
+	ec2Client := aws.NewEc2Client(t, awsRegion)
+	endpoints, err := ec2Client.DescribeVpcEndpoints(&ec2.DescribeVpcEndpointsInput{
+		VpcEndpointIds: awssdk.StringSlice([]string{terraform.Output(t, terraformOptions, "vpc_endpoint_bedrock_id")}),
+	})
+	require.NoError(t, err)
+	require.Len(t, endpoints.VpcEndpoints, 1)
+	attachedGroupIDs := []string{}
+	for _, group := range endpoints.VpcEndpoints[0].Groups {
+		attachedGroupIDs = append(attachedGroupIDs, awssdk.StringValue(group.GroupId))
+	}
+	assert.Equal(t, []string{endpointGroupID}, attachedGroupIDs, "Bedrock endpoint should use only the networking module's endpoint security group")
+
+	rules, err := ec2Client.DescribeSecurityGroupRules(&ec2.DescribeSecurityGroupRulesInput{
+		Filters: []*ec2.Filter{
+			{Name: awssdk.String("group-id"), Values: awssdk.StringSlice([]string{endpointGroupID})},
+		},
+	})
+	require.NoError(t, err)
+
+	ingress := []*ec2.SecurityGroupRule{}
+	for _, rule := range rules.SecurityGroupRules {
+		if !awssdk.BoolValue(rule.IsEgress) {
+			ingress = append(ingress, rule)
+		}
+	}
+
+	// Any broader source would let other VPC resources send PHI to Bedrock
+	require.Len(t, ingress, 1, "Endpoint security group should have exactly one ingress rule")
+	rule := ingress[0]
+	assert.Equal(t, "tcp", awssdk.StringValue(rule.IpProtocol))
+	assert.Equal(t, int64(443), awssdk.Int64Value(rule.FromPort))
+	assert.Equal(t, int64(443), awssdk.Int64Value(rule.ToPort))
+	require.NotNil(t, rule.ReferencedGroupInfo, "Ingress should be sourced from a security group")
+	assert.Equal(t, appGroupID, awssdk.StringValue(rule.ReferencedGroupInfo.GroupId), "Ingress should come from the app security group")
+	assert.Empty(t, awssdk.StringValue(rule.CidrIpv4), "Ingress must not allow an IPv4 CIDR")
+	assert.Empty(t, awssdk.StringValue(rule.CidrIpv6), "Ingress must not allow an IPv6 CIDR")
+	assert.Empty(t, awssdk.StringValue(rule.PrefixListId), "Ingress must not allow a prefix list")
+}
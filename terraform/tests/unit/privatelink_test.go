@@ -0,0 +1,91 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hipaa-compliant-stack/terraform/tests/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPrivateLinkEndpointService verifies the endpoint service requires acceptance and allows only the configured principals
+func TestPrivateLinkEndpointService(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+	allowedPrincipals := []string{fmt.Sprintf("arn:aws:iam::%s:root", aws.GetAccountId(t))}
+
+	vpcOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/vpc",
+		Vars: map[string]interface{}{
+			"vpc_cidr":             "10.0.0.0/16",
+			"environment":          "dev",
+			"name_suffix":          nameSuffix,
+			"enable_nat_gateway":   false,
+			"enable_vpc_endpoints": false,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, vpcOptions)
+	terraform.InitAndApply(t, vpcOptions)
+
+	privateLinkOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/privatelink",
+		Vars: map[string]interface{}{
+			"environment":            "dev",
+			"name_suffix":            nameSuffix,
+			"vpc_id":                 terraform.Output(t, vpcOptions, "vpc_id"),
+			"subnet_ids":             terraform.OutputList(t, vpcOptions, "private_subnet_ids"),
+			"allowed_principal_arns": allowedPrincipals,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, privateLinkOptions)
+	terraform.InitAndApply(t, privateLinkOptions)
+
+	serviceName := terraform.Output(t, privateLinkOptions, "endpoint_service_name")
+	assert.Contains(t, serviceName, fmt.Sprintf("com.amazonaws.vpce.%s.", awsRegion))
+
+	serviceID := terraform.Output(t, privateLinkOptions, "endpoint_service_id")
+	config := helpers.GetVpcEndpointServiceConfiguration(t, awsRegion, serviceID)
+	assert.True(t, awssdk.BoolValue(config.AcceptanceRequired), "Partner connections should require manual acceptance")
+	assert.Equal(t, serviceName, awssdk.StringValue(config.ServiceName))
+	require.Len(t, config.NetworkLoadBalancerArns, 1)
+	assert.Equal(t, terraform.Output(t, privateLinkOptions, "nlb_arn"), awssdk.StringValue(config.NetworkLoadBalancerArns[0]))
+
+	principals := helpers.GetVpcEndpointServiceAllowedPrincipals(t, awsRegion, serviceID)
+	assert.ElementsMatch(t, allowedPrincipals, principals, "Only the configured principals should be allowed")
+}
+
+// TestPrivateLinkRejectsWildcardPrincipal verifies a wildcard principal fails validation instead of opening the service to every account
+func TestPrivateLinkRejectsWildcardPrincipal(t *testing.T) {
+	t.Parallel()
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../../modules/privatelink",
+		Vars: map[string]interface{}{
+			"environment":            "dev",
+			"vpc_id":                 "vpc-test123",
+			"subnet_ids":             []string{"subnet-test1"},
+			"allowed_principal_arns": []string{"*"},
+		},
+		NoColor: true,
+	}
+
+	_, err := terraform.InitAndPlanE(t, terraformOptions)
+	require.Error(t, err, "Plan should fail for a wildcard principal")
+	assert.Contains(t, err.Error(), "wildcards are not allowed")
+}
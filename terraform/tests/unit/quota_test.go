@@ -0,0 +1,73 @@
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ==============================================================================
+// Service Quota Pre-Flight Tests
+// ==============================================================================
+// Plans the root module with service_quota_overrides standing in for the
+// Service Quotas lookup, so a low quota can be stubbed without touching the
+// account's real limits.
+
+// TestServiceQuotaPreflightFailsOnLowQuota verifies a quota too small for the stack fails the plan with the quota named
+func TestServiceQuotaPreflightFailsOnLowQuota(t *testing.T) {
+	t.Parallel()
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: copyRootWithLocalBackend(t),
+		Vars: map[string]interface{}{
+			"environment":        "dev",
+			"enable_nat_gateway": true,
+			"service_quota_overrides": map[string]interface{}{
+				"elastic_ips":   1,
+				"vpcs":          1000,
+				"rds_instances": 1000,
+			},
+		},
+		NoColor: true,
+	}
+
+	_, err := terraform.InitAndPlanE(t, terraformOptions)
+	require.Error(t, err, "Three NAT gateway EIPs must not plan against a quota of one")
+	assert.Contains(t, err.Error(), "Service quota pre-flight failed")
+	assert.Contains(t, err.Error(), "EC2-VPC Elastic IPs (L-0263D0A3): this stack needs 3")
+	assert.Contains(t, err.Error(), "quota is 1")
+	assert.NotContains(t, err.Error(), "VPCs per Region", "Only the exceeded quota should be reported")
+}
+
+// TestServiceQuotaPreflightPassesWithHeadroom verifies the pre-flight stays out of the way when quotas have room
+func TestServiceQuotaPreflightPassesWithHeadroom(t *testing.T) {
+	t.Parallel()
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: copyRootWithLocalBackend(t),
+		Vars: map[string]interface{}{
+			"environment":        "dev",
+			"enable_nat_gateway": true,
+			"service_quota_overrides": map[string]interface{}{
+				"elastic_ips":   1000,
+				"vpcs":          1000,
+				"rds_instances": 1000,
+			},
+		},
+		PlanFilePath: filepath.Join(t.TempDir(), "quota.tfplan"),
+		NoColor:      true,
+	}
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, terraformOptions)
+
+	preflight, ok := plan.ResourcePlannedValuesMap["terraform_data.service_quota_preflight[0]"]
+	require.True(t, ok, "Plan should include the quota pre-flight")
+	assert.Equal(t, map[string]interface{}{
+		"elastic_ips":   float64(3),
+		"vpcs":          float64(1),
+		"rds_instances": float64(1),
+	}, preflight.AttributeValues["input"], "Pre-flight should record what the stack adds against each quota")
+}
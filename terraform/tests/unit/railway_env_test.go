@@ -0,0 +1,81 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRailwayEnvFile verifies the dotenv file has the expected keys and references secrets only by SSM path
+func TestRailwayEnvFile(t *testing.T) {
+	t.Parallel()
+
+	envFilePath := filepath.Join(t.TempDir(), ".env.railway")
+	passwordParameter := "/dev-hipaa-db/master-password"
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/railway_env",
+		Vars: map[string]interface{}{
+			"env_vars": map[string]string{
+				"AWS_REGION":          "us-east-1",
+				"DATABASE_HOST":       "dev-hipaa-db-primary.abc123.us-east-1.rds.amazonaws.com",
+				"S3_DOCUMENTS_BUCKET": "hipaa-compliant-docs-dev-123456789012",
+			},
+			"secret_ssm_parameters": map[string]string{
+				"DATABASE_PASSWORD": passwordParameter,
+			},
+			"write_env_file": true,
+			"env_file_path":  envFilePath,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	content, err := os.ReadFile(envFilePath)
+	require.NoError(t, err, "Env file should be written")
+
+	info, err := os.Stat(envFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), "Env file should be readable only by its owner")
+
+	env := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		key, value, found := strings.Cut(line, "=")
+		require.True(t, found, "Line %q should be KEY=value", line)
+		env[key] = value
+	}
+
+	assert.Equal(t, "us-east-1", env["AWS_REGION"])
+	assert.Equal(t, "dev-hipaa-db-primary.abc123.us-east-1.rds.amazonaws.com", env["DATABASE_HOST"])
+	assert.Equal(t, "hipaa-compliant-docs-dev-123456789012", env["S3_DOCUMENTS_BUCKET"])
+	assert.Equal(t, passwordParameter, env["DATABASE_PASSWORD_SSM_PARAMETER"], "Password should be referenced by SSM path")
+	assert.NotContains(t, env, "DATABASE_PASSWORD", "Password must not be written as a plaintext variable")
+
+	assert.Equal(t, strings.TrimSpace(string(content)), strings.TrimSpace(terraform.Output(t, terraformOptions, "env_file_content")))
+}
+
+// TestRailwayEnvRejectsPlaintextSecret verifies secrets given as values instead of SSM paths are rejected
+func TestRailwayEnvRejectsPlaintextSecret(t *testing.T) {
+	t.Parallel()
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../../modules/railway_env",
+		Vars: map[string]interface{}{
+			"secret_ssm_parameters": map[string]string{
+				"DATABASE_PASSWORD": "hunter2-not-a-path",
+			},
+		},
+		NoColor: true,
+	}
+
+	_, err := terraform.InitAndPlanE(t, terraformOptions)
+	require.Error(t, err, "Plaintext secret values should fail validation")
+	assert.Contains(t, err.Error(), "SSM parameter path")
+}
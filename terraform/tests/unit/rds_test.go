@@ -1,22 +1,33 @@
 package test
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/gruntwork-io/terratest/modules/aws"
 	"github.com/gruntwork-io/terratest/modules/terraform"
+	test_structure "github.com/gruntwork-io/terratest/modules/test-structure"
+	"github.com/hipaa-compliant-stack/terraform/tests/helpers"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestRDSSubnetGroupCreation verifies DB subnet group is created correctly
 func TestRDSSubnetGroupCreation(t *testing.T) {
 	t.Parallel()
 
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/rds",
 		Vars: map[string]interface{}{
-			"environment":         "test",
+			"environment":         "dev",
+			"name_suffix":         nameSuffix,
 			"private_subnet_ids":  []string{"subnet-test1", "subnet-test2", "subnet-test3"},
 			"security_group_id":   "sg-test123",
 			"kms_key_id":          fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/test", aws.GetAccountId(t)),
@@ -41,10 +52,13 @@ func TestRDSSubnetGroupCreation(t *testing.T) {
 func TestRDSParameterGroupWithPgVector(t *testing.T) {
 	t.Parallel()
 
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/rds",
 		Vars: map[string]interface{}{
-			"environment":         "test",
+			"environment":         "dev",
+			"name_suffix":         nameSuffix,
 			"private_subnet_ids":  []string{"subnet-test1", "subnet-test2", "subnet-test3"},
 			"security_group_id":   "sg-test123",
 			"kms_key_id": fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/test", aws.GetAccountId(t)),
@@ -61,16 +75,23 @@ func TestRDSParameterGroupWithPgVector(t *testing.T) {
 	parameterGroupName := terraform.Output(t, terraformOptions, "db_parameter_group_name")
 	assert.NotEmpty(t, parameterGroupName)
 	assert.Contains(t, parameterGroupName, "pgvector")
+
+	// A group can exist while the instance still uses the engine default
+	liveParameterGroup := helpers.GetRDSParameterGroup(t, "us-east-1", terraform.Output(t, terraformOptions, "rds_identifier"))
+	assert.Equal(t, parameterGroupName, liveParameterGroup, "Instance should reference the pgvector parameter group")
 }
 
 // TestRDSInstanceCreation verifies RDS instance is created with encryption
 func TestRDSInstanceCreation(t *testing.T) {
 	t.Parallel()
 
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/rds",
 		Vars: map[string]interface{}{
-			"environment":         "test",
+			"environment":         "dev",
+			"name_suffix":         nameSuffix,
 			"private_subnet_ids":  []string{"subnet-test1", "subnet-test2", "subnet-test3"},
 			"security_group_id":   "sg-test123",
 			"kms_key_id": fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/test", aws.GetAccountId(t)),
@@ -93,10 +114,13 @@ func TestRDSInstanceCreation(t *testing.T) {
 func TestRDSInstanceEncryptionEnabled(t *testing.T) {
 	t.Parallel()
 
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/rds",
 		Vars: map[string]interface{}{
-			"environment":         "test",
+			"environment":         "dev",
+			"name_suffix":         nameSuffix,
 			"private_subnet_ids":  []string{"subnet-test1", "subnet-test2", "subnet-test3"},
 			"security_group_id":   "sg-test123",
 			"kms_key_id": fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/test", aws.GetAccountId(t)),
@@ -119,10 +143,13 @@ func TestRDSInstanceEncryptionEnabled(t *testing.T) {
 func TestRDSBackupConfiguration(t *testing.T) {
 	t.Parallel()
 
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/rds",
 		Vars: map[string]interface{}{
-			"environment":          "test",
+			"environment":          "dev",
+			"name_suffix":          nameSuffix,
 			"private_subnet_ids":   []string{"subnet-test1", "subnet-test2", "subnet-test3"},
 			"security_group_id":    "sg-test123",
 			"kms_key_id": fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/test", aws.GetAccountId(t)),
@@ -141,20 +168,78 @@ func TestRDSBackupConfiguration(t *testing.T) {
 	assert.NotEmpty(t, rdsEndpoint)
 }
 
-// TestRDSMultiAZConfiguration verifies Multi-AZ deployment configuration
+// TestRDSMultiAZConfiguration verifies the live instance is Multi-AZ exactly when multi_az is set
 func TestRDSMultiAZConfiguration(t *testing.T) {
 	t.Parallel()
 
+	awsRegion := "us-east-1"
+
+	// Distinct environments keep the instance identifiers from colliding
+	testCases := []struct {
+		name        string
+		environment string
+		multiAZ     bool
+	}{
+		{name: "MultiAZ", environment: "staging", multiAZ: true},
+		{name: "SingleAZ", environment: "dev", multiAZ: false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			nameSuffix := helpers.UniqueNameSuffix(t)
+
+			terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+				TerraformDir: "../../modules/rds",
+				Vars: map[string]interface{}{
+					"environment":        tc.environment,
+					"name_suffix":        nameSuffix,
+					"private_subnet_ids": []string{"subnet-test1", "subnet-test2", "subnet-test3"},
+					"security_group_id":  "sg-test123",
+					"kms_key_id":         fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/test", aws.GetAccountId(t)),
+					"instance_class":     "db.t3.small",
+					"allocated_storage":  50,
+					"multi_az":           tc.multiAZ,
+				},
+				NoColor: true,
+			})
+
+			defer terraform.Destroy(t, terraformOptions)
+			terraform.InitAndApply(t, terraformOptions)
+
+			// Verify RDS instance created
+			rdsEndpoint := terraform.Output(t, terraformOptions, "rds_endpoint")
+			assert.NotEmpty(t, rdsEndpoint)
+
+			// Multi-AZ is an availability control; check the live instance, not just the input
+			multiAZ := helpers.GetRDSMultiAZ(t, awsRegion, terraform.Output(t, terraformOptions, "rds_identifier"))
+			assert.Equal(t, tc.multiAZ, multiAZ, "Live instance Multi-AZ should match multi_az")
+		})
+	}
+}
+
+// TestRDSStorageAutoscalingCeiling verifies the live instance carries the configured max_allocated_storage
+func TestRDSStorageAutoscalingCeiling(t *testing.T) {
+	t.Parallel()
+
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	awsRegion := "us-east-1"
+	maxAllocatedStorage := 150
+
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/rds",
 		Vars: map[string]interface{}{
-			"environment":         "staging",
-			"private_subnet_ids":  []string{"subnet-test1", "subnet-test2", "subnet-test3"},
-			"security_group_id":   "sg-test123",
-			"kms_key_id": fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/test", aws.GetAccountId(t)),
-			"instance_class":      "db.t3.small",
-			"allocated_storage":   50,
-			"multi_az":            true,
+			"environment":           "dev",
+			"name_suffix":           nameSuffix,
+			"private_subnet_ids":    []string{"subnet-test1", "subnet-test2", "subnet-test3"},
+			"security_group_id":     "sg-test123",
+			"kms_key_id":            fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/test", aws.GetAccountId(t)),
+			"instance_class":        "db.t3.micro",
+			"allocated_storage":     20,
+			"max_allocated_storage": maxAllocatedStorage,
 		},
 		NoColor: true,
 	})
@@ -162,19 +247,22 @@ func TestRDSMultiAZConfiguration(t *testing.T) {
 	defer terraform.Destroy(t, terraformOptions)
 	terraform.InitAndApply(t, terraformOptions)
 
-	// Verify RDS instance created
-	rdsEndpoint := terraform.Output(t, terraformOptions, "rds_endpoint")
-	assert.NotEmpty(t, rdsEndpoint)
+	// A non-default ceiling proves the value reached the instance rather than an API default
+	ceiling := helpers.GetRDSMaxAllocatedStorage(t, awsRegion, terraform.Output(t, terraformOptions, "rds_identifier"))
+	assert.Equal(t, maxAllocatedStorage, ceiling, "Live storage autoscaling ceiling should match max_allocated_storage")
 }
 
 // TestRDSReadReplicaConditional verifies read replica is created when enabled
 func TestRDSReadReplicaConditional(t *testing.T) {
 	t.Parallel()
 
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/rds",
 		Vars: map[string]interface{}{
 			"environment":         "production",
+			"name_suffix":         nameSuffix,
 			"private_subnet_ids":  []string{"subnet-test1", "subnet-test2", "subnet-test3"},
 			"security_group_id":   "sg-test123",
 			"kms_key_id": fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/test", aws.GetAccountId(t)),
@@ -194,14 +282,109 @@ func TestRDSReadReplicaConditional(t *testing.T) {
 	assert.NotEmpty(t, readerEndpoint)
 }
 
+// TestRDSReadReplicaRequiresBackups verifies the plan fails when a read replica is enabled without automated backups
+func TestRDSReadReplicaRequiresBackups(t *testing.T) {
+	t.Parallel()
+
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/rds",
+		Vars: map[string]interface{}{
+			"environment":           "dev",
+			"name_suffix":           nameSuffix,
+			"private_subnet_ids":    []string{"subnet-test1", "subnet-test2", "subnet-test3"},
+			"security_group_id":     "sg-test123",
+			"kms_key_id":            fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/test", aws.GetAccountId(t)),
+			"instance_class":        "db.t3.micro",
+			"allocated_storage":     20,
+			"enable_read_replica":   true,
+			"backup_retention_days": 0,
+		},
+		NoColor: true,
+	})
+
+	_, err := terraform.InitAndPlanE(t, terraformOptions)
+	require.Error(t, err, "Plan should fail when a read replica is enabled without backups")
+	assert.Contains(t, err.Error(), "enable_read_replica requires automated backups")
+}
+
+// TestRDSMasterPasswordLength verifies a master password shorter than 16 characters fails validation
+func TestRDSMasterPasswordLength(t *testing.T) {
+	t.Parallel()
+
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../../modules/rds",
+		Vars: map[string]interface{}{
+			"environment":            "dev",
+			"name_suffix":            nameSuffix,
+			"private_subnet_ids":     []string{"subnet-test1", "subnet-test2", "subnet-test3"},
+			"security_group_id":      "sg-test123",
+			"kms_key_id":             fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/test", aws.GetAccountId(t)),
+			"master_password_length": 15,
+		},
+		NoColor: true,
+	}
+
+	_, err := terraform.InitAndPlanE(t, terraformOptions)
+	require.Error(t, err, "Plan should fail for a master password shorter than 16 characters")
+	assert.Contains(t, err.Error(), "master_password_length must be between 16 and 128 characters")
+}
+
+// TestRDSCopyTagsToSnapshot verifies the instance copies its tags, including DataClassification, to snapshots
+func TestRDSCopyTagsToSnapshot(t *testing.T) {
+	t.Parallel()
+
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	awsRegion := "us-east-1"
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/rds",
+		Vars: map[string]interface{}{
+			"environment":        "dev",
+			"name_suffix":        nameSuffix,
+			"private_subnet_ids": []string{"subnet-test1", "subnet-test2", "subnet-test3"},
+			"security_group_id":  "sg-test123",
+			"kms_key_id":         fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/test", aws.GetAccountId(t)),
+			"instance_class":     "db.t3.micro",
+			"allocated_storage":  20,
+			"tags": map[string]string{
+				"DataClassification": "PHI",
+			},
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	instance, err := aws.GetRdsInstanceDetailsE(t, terraform.Output(t, terraformOptions, "rds_identifier"), awsRegion)
+	require.NoError(t, err)
+	assert.True(t, *instance.CopyTagsToSnapshot, "Snapshots should inherit instance tags")
+
+	// The tags snapshots inherit must be on the instance in the first place
+	tags := map[string]string{}
+	for _, tag := range instance.TagList {
+		tags[*tag.Key] = *tag.Value
+	}
+	assert.Equal(t, "PHI", tags["DataClassification"])
+	assert.Equal(t, "dev", tags["Environment"])
+}
+
 // TestRDSOutputsPopulated verifies all required outputs are populated
 func TestRDSOutputsPopulated(t *testing.T) {
 	t.Parallel()
 
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/rds",
 		Vars: map[string]interface{}{
-			"environment":         "test",
+			"environment":         "dev",
+			"name_suffix":         nameSuffix,
 			"private_subnet_ids":  []string{"subnet-test1", "subnet-test2", "subnet-test3"},
 			"security_group_id":   "sg-test123",
 			"kms_key_id": fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/test", aws.GetAccountId(t)),
@@ -223,3 +406,668 @@ func TestRDSOutputsPopulated(t *testing.T) {
 	assert.NotEmpty(t, rdsDbName)
 	assert.NotEmpty(t, rdsArn)
 }
+
+// TestRDSReportingReplica verifies the reporting replica is encrypted, in another AZ, and behind its own security group
+func TestRDSReportingReplica(t *testing.T) {
+	t.Parallel()
+
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	awsRegion := "us-east-1"
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/rds",
+		Vars: map[string]interface{}{
+			"environment":                          "staging",
+			"name_suffix":                          nameSuffix,
+			"private_subnet_ids":                   []string{"subnet-test1", "subnet-test2", "subnet-test3"},
+			"security_group_id":                    "sg-test123",
+			"kms_key_id":                           fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/test", aws.GetAccountId(t)),
+			"instance_class":                       "db.t3.micro",
+			"allocated_storage":                    20,
+			"enable_reporting_replica":             true,
+			"reporting_allowed_security_group_ids": []string{"sg-reporting123"},
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	reportingEndpoint := terraform.Output(t, terraformOptions, "rds_reporting_endpoint")
+	assert.NotEmpty(t, reportingEndpoint, "Reporting replica endpoint should be populated")
+
+	primaryDetails, err := aws.GetRdsInstanceDetailsE(t, terraform.Output(t, terraformOptions, "rds_identifier"), awsRegion)
+	require.NoError(t, err)
+	reportingDetails, err := aws.GetRdsInstanceDetailsE(t, terraform.Output(t, terraformOptions, "rds_reporting_identifier"), awsRegion)
+	require.NoError(t, err)
+
+	assert.True(t, *reportingDetails.StorageEncrypted, "Reporting replica should be encrypted")
+	assert.NotEqual(t, *primaryDetails.AvailabilityZone, *reportingDetails.AvailabilityZone, "Reporting replica should be in a different AZ")
+
+	reportingSecurityGroupID := terraform.Output(t, terraformOptions, "rds_reporting_security_group_id")
+	require.Len(t, reportingDetails.VpcSecurityGroups, 1)
+	assert.Equal(t, reportingSecurityGroupID, *reportingDetails.VpcSecurityGroups[0].VpcSecurityGroupId)
+	for _, primaryGroup := range primaryDetails.VpcSecurityGroups {
+		assert.NotEqual(t, reportingSecurityGroupID, *primaryGroup.VpcSecurityGroupId, "Reporting replica SG must differ from the primary's")
+	}
+}
+
+// TestRDSProxyRequiresTLS verifies RDS Proxy enforces TLS and applies the connection borrow timeout
+func TestRDSProxyRequiresTLS(t *testing.T) {
+	t.Parallel()
+
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	awsRegion := "us-east-1"
+	borrowTimeout := 60
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/rds",
+		Vars: map[string]interface{}{
+			"environment":                     "staging",
+			"name_suffix":                     nameSuffix,
+			"private_subnet_ids":              []string{"subnet-test1", "subnet-test2", "subnet-test3"},
+			"security_group_id":               "sg-test123",
+			"kms_key_id":                      fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/test", aws.GetAccountId(t)),
+			"instance_class":                  "db.t3.micro",
+			"allocated_storage":               20,
+			"enable_rds_proxy":                true,
+			"proxy_require_tls":               true,
+			"proxy_connection_borrow_timeout": borrowTimeout,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	assert.Equal(t, "true", terraform.Output(t, terraformOptions, "rds_proxy_require_tls"))
+
+	proxyConfig := helpers.GetRDSProxyConfig(t, awsRegion, terraform.Output(t, terraformOptions, "rds_proxy_name"))
+	assert.True(t, proxyConfig.RequireTLS, "RDS Proxy should require TLS")
+	assert.Equal(t, int64(borrowTimeout), proxyConfig.ConnectionBorrowTimeout, "Connection borrow timeout should be applied")
+}
+
+// TestRDSProxyReaderEndpoint verifies the proxy exposes a reader endpoint only when the read replica is enabled
+func TestRDSProxyReaderEndpoint(t *testing.T) {
+	t.Parallel()
+
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/rds",
+		Vars: map[string]interface{}{
+			"environment":         "production",
+			"name_suffix":         nameSuffix,
+			"private_subnet_ids":  []string{"subnet-test1", "subnet-test2", "subnet-test3"},
+			"security_group_id":   "sg-test123",
+			"kms_key_id":          fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/test", aws.GetAccountId(t)),
+			"instance_class":      "db.t3.small",
+			"allocated_storage":   100,
+			"multi_az":            true,
+			"enable_read_replica": true,
+			"enable_rds_proxy":    true,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	readerEndpoint := terraform.Output(t, terraformOptions, "rds_proxy_reader_endpoint")
+	assert.NotEmpty(t, readerEndpoint, "Reader endpoint should be populated when proxy and read replica are enabled")
+	assert.NotEqual(t, terraform.Output(t, terraformOptions, "rds_proxy_endpoint"), readerEndpoint, "Reads and writes should use separate proxy endpoints")
+}
+
+// TestRDSAuroraCluster verifies the aurora-postgresql engine provisions an encrypted cluster with a reader endpoint
+func TestRDSAuroraCluster(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	// The cluster resolves its key to an ARN, so it needs a real key
+	kmsOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/kms",
+		Vars: map[string]interface{}{
+			"environment":    "staging",
+			"name_suffix":    nameSuffix,
+			"aws_account_id": aws.GetAccountId(t),
+			"allow_destroy":  true,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, kmsOptions)
+	terraform.InitAndApply(t, kmsOptions)
+	kmsKeyARN := terraform.Output(t, kmsOptions, "kms_master_key_arn")
+
+	auroraVars := func(overrides map[string]interface{}) map[string]interface{} {
+		vars := map[string]interface{}{
+			"environment":         "staging",
+			"name_suffix":         nameSuffix,
+			"private_subnet_ids":  []string{"subnet-test1", "subnet-test2", "subnet-test3"},
+			"security_group_id":   "sg-test123",
+			"kms_key_id":          kmsKeyARN,
+			"engine_type":         "aurora-postgresql",
+			"instance_class":      "db.t3.medium",
+			"aurora_reader_count": 1,
+			"allow_destroy":       true,
+			"skip_final_snapshot": true,
+		}
+		for name, value := range overrides {
+			vars[name] = value
+		}
+		return vars
+	}
+
+	invalidCases := []struct {
+		name          string
+		overrides     map[string]interface{}
+		expectedError string
+	}{
+		{name: "read-replica", overrides: map[string]interface{}{"enable_read_replica": true}, expectedError: "set aurora_reader_count instead"},
+		{name: "small-class", overrides: map[string]interface{}{"instance_class": "db.t3.small"}, expectedError: "Aurora PostgreSQL does not support db.t3.small"},
+		{name: "production-without-reader", overrides: map[string]interface{}{"environment": "production", "aurora_reader_count": 0}, expectedError: "aurora_reader_count >= 1"},
+	}
+
+	for _, tc := range invalidCases {
+		_, err := terraform.InitAndPlanE(t, &terraform.Options{
+			TerraformDir: "../../modules/rds",
+			Vars:         auroraVars(tc.overrides),
+			EnvVars: map[string]string{
+				"AWS_DEFAULT_REGION": awsRegion,
+			},
+			NoColor: true,
+		})
+		require.Error(t, err, "%s should fail the plan", tc.name)
+		assert.Contains(t, err.Error(), tc.expectedError)
+	}
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/rds",
+		Vars:         auroraVars(nil),
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	assert.Equal(t, "aurora-postgresql", terraform.Output(t, terraformOptions, "engine_type"))
+	assert.Equal(t, "true", terraform.Output(t, terraformOptions, "storage_encrypted"))
+	assert.NotEmpty(t, terraform.Output(t, terraformOptions, "aurora_cluster_endpoint"))
+	readerEndpoint := terraform.Output(t, terraformOptions, "aurora_reader_endpoint")
+	assert.Contains(t, readerEndpoint, ".cluster-ro-", "Reader endpoint should be the cluster's read-only endpoint")
+
+	cluster := helpers.GetAuroraCluster(t, awsRegion, terraform.Output(t, terraformOptions, "aurora_cluster_identifier"))
+	assert.True(t, awssdk.BoolValue(cluster.StorageEncrypted), "Aurora cluster storage should be encrypted")
+	assert.Equal(t, kmsKeyARN, awssdk.StringValue(cluster.KmsKeyId), "Aurora cluster should use the customer managed key")
+	assert.Equal(t, readerEndpoint, awssdk.StringValue(cluster.ReaderEndpoint))
+	assert.Len(t, cluster.DBClusterMembers, 2, "Cluster should have a writer and one reader")
+}
+
+// TestRDSInstanceClassMatrix verifies the module across instance classes, including graceful failure where Performance Insights is unsupported
+func TestRDSInstanceClassMatrix(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+
+	testCases := []struct {
+		instanceClass      string
+		enablePerfInsights bool
+		expectPlanError    bool
+	}{
+		{instanceClass: "db.t3.micro", enablePerfInsights: false, expectPlanError: false},
+		{instanceClass: "db.t3.micro", enablePerfInsights: true, expectPlanError: true},
+		{instanceClass: "db.t3.small", enablePerfInsights: true, expectPlanError: true},
+		{instanceClass: "db.r6g.large", enablePerfInsights: true, expectPlanError: false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(fmt.Sprintf("%s-pi-%t", tc.instanceClass, tc.enablePerfInsights), func(t *testing.T) {
+			t.Parallel()
+
+			nameSuffix := helpers.UniqueNameSuffix(t)
+
+			// Parallel cases each apply their own instance, so each needs its own state
+			rdsDir := test_structure.CopyTerraformFolderToTemp(t, "../..", "modules/rds")
+
+			terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+				TerraformDir: rdsDir,
+				Vars: map[string]interface{}{
+					"environment":                 "dev",
+					"name_suffix":                 nameSuffix,
+					"private_subnet_ids":          []string{"subnet-test1", "subnet-test2", "subnet-test3"},
+					"security_group_id":           "sg-test123",
+					"kms_key_id":                  fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/test", aws.GetAccountId(t)),
+					"instance_class":              tc.instanceClass,
+					"allocated_storage":           20,
+					"max_allocated_storage":       100,
+					"enable_performance_insights": tc.enablePerfInsights,
+				},
+				NoColor: true,
+			})
+
+			if tc.expectPlanError {
+				_, err := terraform.InitAndPlanE(t, terraformOptions)
+				require.Error(t, err, "Plan should fail for unsupported Performance Insights class")
+				assert.Contains(t, err.Error(), "Performance Insights is not supported")
+				return
+			}
+
+			defer terraform.Destroy(t, terraformOptions)
+			terraform.InitAndApply(t, terraformOptions)
+
+			instance, err := aws.GetRdsInstanceDetailsE(t, terraform.Output(t, terraformOptions, "rds_identifier"), awsRegion)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.instanceClass, *instance.DBInstanceClass)
+			assert.Equal(t, "gp3", *instance.StorageType, "Storage should be gp3 on every class")
+			assert.Equal(t, int64(100), *instance.MaxAllocatedStorage, "Storage autoscaling ceiling should be applied")
+			assert.Equal(t, tc.enablePerfInsights, *instance.PerformanceInsightsEnabled)
+		})
+	}
+}
+
+// TestRDSCrossAccountSnapshotCopy verifies snapshot copies target the configured backup account with a grantable key
+func TestRDSCrossAccountSnapshotCopy(t *testing.T) {
+	t.Parallel()
+
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	awsRegion := "us-east-1"
+
+	// KMS rejects key policies naming accounts that do not exist, so share with
+	// a real backup account when given and otherwise with the caller's own
+	backupAccountID := os.Getenv("TEST_BACKUP_ACCOUNT_ID")
+	if backupAccountID == "" {
+		backupAccountID = aws.GetAccountId(t)
+	}
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/rds",
+		Vars: map[string]interface{}{
+			"environment":              "dev",
+			"name_suffix":              nameSuffix,
+			"private_subnet_ids":       []string{"subnet-test1", "subnet-test2", "subnet-test3"},
+			"security_group_id":        "sg-test123",
+			"kms_key_id":               fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/test", aws.GetAccountId(t)),
+			"instance_class":           "db.t3.micro",
+			"allocated_storage":        20,
+			"snapshot_copy_account_id": backupAccountID,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	copyConfig := terraform.OutputMap(t, terraformOptions, "snapshot_copy_configuration")
+	assert.Equal(t, backupAccountID, copyConfig["target_account_id"])
+
+	env := helpers.GetLambdaEnvironment(t, awsRegion, copyConfig["lambda_function"])
+	assert.Equal(t, backupAccountID, env["TARGET_ACCOUNT_ID"], "Lambda should share snapshots with the configured account")
+	assert.Equal(t, copyConfig["kms_key_arn"], env["SHARE_KMS_KEY_ARN"], "Lambda should re-encrypt with the shareable key")
+	assert.Equal(t, terraform.Output(t, terraformOptions, "rds_identifier"), env["SOURCE_DB_INSTANCE_IDENTIFIER"])
+
+	// A finished copy is a notification-category event, so matching only
+	// creation would never run the share pass
+	var pattern struct {
+		Source     []string `json:"source"`
+		DetailType []string `json:"detail-type"`
+		Detail     struct {
+			EventID []string `json:"EventID"`
+		} `json:"detail"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(helpers.GetEventRulePattern(t, awsRegion, copyConfig["event_rule"])), &pattern))
+	assert.Equal(t, []string{"aws.rds"}, pattern.Source)
+	assert.Equal(t, []string{"RDS DB Snapshot Event"}, pattern.DetailType)
+	assert.Subset(t, pattern.Detail.EventID, []string{"RDS-EVENT-0042", "RDS-EVENT-0091"}, "Snapshot creation should trigger the copy pass")
+	assert.Contains(t, pattern.Detail.EventID, "RDS-EVENT-0197", "A finished copy should trigger the share pass")
+
+	targets := helpers.GetEventRuleTargetArns(t, awsRegion, copyConfig["event_rule"])
+	require.Len(t, targets, 1)
+	assert.True(t, strings.HasSuffix(targets[0], ":function:"+copyConfig["lambda_function"]), "Rule should invoke the snapshot copy Lambda")
+
+	var keyPolicy struct {
+		Statement []struct {
+			Effect    string
+			Principal map[string]interface{}
+			Action    []string
+		}
+	}
+	require.NoError(t, json.Unmarshal([]byte(helpers.GetKMSKeyPolicy(t, awsRegion, copyConfig["kms_key_arn"])), &keyPolicy))
+
+	var grantable bool
+	for _, statement := range keyPolicy.Statement {
+		if statement.Effect == "Allow" && statement.Principal["AWS"] == fmt.Sprintf("arn:aws:iam::%s:root", backupAccountID) {
+			assert.Contains(t, statement.Action, "kms:CreateGrant")
+			assert.Contains(t, statement.Action, "kms:Decrypt")
+			grantable = true
+		}
+	}
+	assert.True(t, grantable, "Shareable key should grant the backup account use of the key")
+}
+
+// TestRDSSizingRecommendation verifies the sizing recommendation is populated and undersized production configs warn
+func TestRDSSizingRecommendation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name                string
+		environment         string
+		instanceClass       string
+		expectedConnections int
+		expectedStorageGB   int
+		expectedClass       string
+		expectWarning       bool
+	}{
+		{name: "dev-right-sized", environment: "dev", instanceClass: "db.t3.medium", expectedConnections: 100, expectedStorageGB: 10, expectedClass: "db.t3.small", expectWarning: false},
+		{name: "production-undersized", environment: "production", instanceClass: "db.t3.micro", expectedConnections: 1000, expectedStorageGB: 200, expectedClass: "db.r6g.large", expectWarning: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			nameSuffix := helpers.UniqueNameSuffix(t)
+
+			terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+				TerraformDir: "../../modules/rds",
+				Vars: map[string]interface{}{
+					"environment":          tc.environment,
+					"name_suffix":          nameSuffix,
+					"private_subnet_ids":   []string{"subnet-test1", "subnet-test2", "subnet-test3"},
+					"security_group_id":    "sg-test123",
+					"kms_key_id":           fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/test", aws.GetAccountId(t)),
+					"instance_class":       tc.instanceClass,
+					"allocated_storage":    20,
+					"expected_connections": tc.expectedConnections,
+					"expected_storage_gb":  tc.expectedStorageGB,
+					"multi_az":             tc.environment == "production",
+				},
+				PlanFilePath: filepath.Join(t.TempDir(), "sizing.tfplan"),
+				NoColor:      true,
+			})
+
+			planOutput := terraform.InitAndPlan(t, terraformOptions)
+			plan := terraform.ShowWithStruct(t, terraformOptions)
+
+			outputChange, ok := plan.RawPlan.OutputChanges["rds_sizing_recommendation"]
+			require.True(t, ok, "Plan should include rds_sizing_recommendation")
+			recommendation, ok := outputChange.After.(map[string]interface{})
+			require.True(t, ok, "rds_sizing_recommendation should be known at plan time")
+
+			assert.Equal(t, tc.expectedClass, recommendation["instance_class"])
+			assert.NotZero(t, recommendation["allocated_storage"])
+			assert.Equal(t, tc.expectWarning, recommendation["undersized"])
+
+			if tc.expectWarning {
+				assert.Contains(t, planOutput, "RDS instance looks undersized", "Undersized config should emit a check warning")
+				assert.NotEmpty(t, recommendation["warnings"])
+			} else {
+				assert.NotContains(t, planOutput, "RDS instance looks undersized")
+			}
+		})
+	}
+}
+
+// TestRDSProductionMinimums verifies production plans fail without Multi-AZ or with under 7 days of backups
+func TestRDSProductionMinimums(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name                string
+		multiAZ             bool
+		backupRetentionDays int
+		expectedError       string
+	}{
+		{name: "single-az", multiAZ: false, backupRetentionDays: 30, expectedError: "Production requires multi_az = true"},
+		{name: "no-backups", multiAZ: true, backupRetentionDays: 0, expectedError: "Production requires backup_retention_days >= 7"},
+		{name: "short-backups", multiAZ: true, backupRetentionDays: 6, expectedError: "Production requires backup_retention_days >= 7"},
+		{name: "compliant", multiAZ: true, backupRetentionDays: 7},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			nameSuffix := helpers.UniqueNameSuffix(t)
+
+			terraformOptions := &terraform.Options{
+				TerraformDir: "../../modules/rds",
+				Vars: map[string]interface{}{
+					"environment":           "production",
+					"name_suffix":           nameSuffix,
+					"private_subnet_ids":    []string{"subnet-test1", "subnet-test2", "subnet-test3"},
+					"security_group_id":     "sg-test123",
+					"kms_key_id":            fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/test", aws.GetAccountId(t)),
+					"instance_class":        "db.t3.small",
+					"allocated_storage":     20,
+					"multi_az":              tc.multiAZ,
+					"backup_retention_days": tc.backupRetentionDays,
+				},
+				NoColor: true,
+			}
+
+			_, err := terraform.InitAndPlanE(t, terraformOptions)
+			if tc.expectedError == "" {
+				require.NoError(t, err, "Multi-AZ production with 7 days of backups should plan")
+				return
+			}
+			require.Error(t, err, "Production plan should fail below the minimums")
+			assert.Contains(t, err.Error(), tc.expectedError)
+		})
+	}
+}
+
+// TestRDSTenancyAndLicenseModel verifies supported tenancy and license settings apply to the plan and unsupported ones are rejected
+func TestRDSTenancyAndLicenseModel(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		tenancy       string
+		licenseModel  string
+		expectedError string
+	}{
+		{name: "default-postgresql-license", tenancy: "default", licenseModel: "postgresql-license"},
+		{name: "dedicated", tenancy: "dedicated", licenseModel: "postgresql-license", expectedError: "does not support dedicated tenancy"},
+		{name: "bring-your-own-license", tenancy: "default", licenseModel: "bring-your-own-license", expectedError: "only supports license_model"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			nameSuffix := helpers.UniqueNameSuffix(t)
+
+			terraformOptions := &terraform.Options{
+				TerraformDir: "../../modules/rds",
+				Vars: map[string]interface{}{
+					"environment":        "dev",
+					"name_suffix":        nameSuffix,
+					"private_subnet_ids": []string{"subnet-test1", "subnet-test2", "subnet-test3"},
+					"security_group_id":  "sg-test123",
+					"kms_key_id":         fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/test", aws.GetAccountId(t)),
+					"instance_class":     "db.t3.small",
+					"allocated_storage":  20,
+					"tenancy":            tc.tenancy,
+					"license_model":      tc.licenseModel,
+				},
+				PlanFilePath: filepath.Join(t.TempDir(), "tenancy.tfplan"),
+				NoColor:      true,
+			}
+
+			if tc.expectedError != "" {
+				_, err := terraform.InitAndPlanE(t, terraformOptions)
+				require.Error(t, err, "Unsupported setting should fail the plan")
+				assert.Contains(t, err.Error(), tc.expectedError)
+				return
+			}
+
+			plan := terraform.InitAndPlanAndShowWithStruct(t, terraformOptions)
+
+			primary, ok := plan.ResourcePlannedValuesMap["aws_db_instance.main[0]"]
+			require.True(t, ok, "Plan should include the primary instance")
+			assert.Equal(t, tc.licenseModel, primary.AttributeValues["license_model"])
+
+			tenancy, ok := plan.RawPlan.OutputChanges["tenancy"]
+			require.True(t, ok, "Plan should include the tenancy output")
+			assert.Equal(t, tc.tenancy, tenancy.After)
+		})
+	}
+}
+
+// TestRDSCARotationReadiness verifies rds_ca_rotation_needed is computed from the instance's CA and the latest available CA
+func TestRDSCARotationReadiness(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		caCertIdentifier string
+		expectKnown      bool
+		expectedError    string
+	}{
+		{name: "default-ca", caCertIdentifier: "", expectKnown: false},
+		{name: "pinned-ca", caCertIdentifier: "rds-ca-rsa2048-g1", expectKnown: true},
+		{name: "invalid-ca", caCertIdentifier: "global-bundle", expectedError: "must be an RDS CA identifier"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			nameSuffix := helpers.UniqueNameSuffix(t)
+
+			terraformOptions := &terraform.Options{
+				TerraformDir: "../../modules/rds",
+				Vars: map[string]interface{}{
+					"environment":        "dev",
+					"name_suffix":        nameSuffix,
+					"private_subnet_ids": []string{"subnet-test1", "subnet-test2", "subnet-test3"},
+					"security_group_id":  "sg-test123",
+					"kms_key_id":         fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/test", aws.GetAccountId(t)),
+					"instance_class":     "db.t3.small",
+					"allocated_storage":  20,
+					"ca_cert_identifier": tc.caCertIdentifier,
+				},
+				PlanFilePath: filepath.Join(t.TempDir(), "ca.tfplan"),
+				NoColor:      true,
+			}
+
+			if tc.expectedError != "" {
+				_, err := terraform.InitAndPlanE(t, terraformOptions)
+				require.Error(t, err, "Invalid CA identifier should fail the plan")
+				assert.Contains(t, err.Error(), tc.expectedError)
+				return
+			}
+
+			plan := terraform.InitAndPlanAndShowWithStruct(t, terraformOptions)
+
+			latest, ok := plan.RawPlan.OutputChanges["rds_ca_latest_identifier"]
+			require.True(t, ok, "Plan should include rds_ca_latest_identifier")
+			assert.Regexp(t, "^rds-ca-", latest.After, "Latest CA should be read from the RDS API at plan time")
+
+			rotationNeeded, ok := plan.RawPlan.OutputChanges["rds_ca_rotation_needed"]
+			require.True(t, ok, "Plan should include rds_ca_rotation_needed")
+
+			if !tc.expectKnown {
+				// RDS assigns the default CA at creation, so the comparison resolves on apply
+				assert.Equal(t, true, rotationNeeded.AfterUnknown, "rds_ca_rotation_needed should be computed after apply")
+				return
+			}
+
+			needed, ok := rotationNeeded.After.(bool)
+			require.True(t, ok, "rds_ca_rotation_needed should be a boolean known at plan time")
+			assert.Equal(t, latest.After != tc.caCertIdentifier, needed)
+		})
+	}
+}
+
+// TestRDSScheduling verifies the stop/start rules are planned outside production and never created in production
+func TestRDSScheduling(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name            string
+		environment     string
+		expectScheduler bool
+	}{
+		{name: "dev", environment: "dev", expectScheduler: true},
+		{name: "production", environment: "production", expectScheduler: false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			nameSuffix := helpers.UniqueNameSuffix(t)
+
+			terraformOptions := &terraform.Options{
+				TerraformDir: "../../modules/rds",
+				Vars: map[string]interface{}{
+					"environment":               tc.environment,
+					"name_suffix":               nameSuffix,
+					"private_subnet_ids":        []string{"subnet-test1", "subnet-test2", "subnet-test3"},
+					"security_group_id":         "sg-test123",
+					"kms_key_id":                fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/test", aws.GetAccountId(t)),
+					"instance_class":            "db.t3.small",
+					"allocated_storage":         20,
+					"multi_az":                  tc.environment == "production",
+					"backup_retention_days":     7,
+					"enable_scheduling":         true,
+					"schedule_stop_expression":  "cron(0 2 ? * * *)",
+					"schedule_start_expression": "cron(0 11 ? * MON-FRI *)",
+				},
+				PlanFilePath: filepath.Join(t.TempDir(), "scheduling.tfplan"),
+				NoColor:      true,
+			}
+
+			plan := terraform.InitAndPlanAndShowWithStruct(t, terraformOptions)
+
+			stopRule, hasStop := plan.ResourcePlannedValuesMap[`aws_cloudwatch_event_rule.scheduler["stop"]`]
+			startRule, hasStart := plan.ResourcePlannedValuesMap[`aws_cloudwatch_event_rule.scheduler["start"]`]
+			_, hasLambda := plan.ResourcePlannedValuesMap["aws_lambda_function.scheduler[0]"]
+			assert.Equal(t, tc.expectScheduler, hasStop, "Stop rule presence")
+			assert.Equal(t, tc.expectScheduler, hasStart, "Start rule presence")
+			assert.Equal(t, tc.expectScheduler, hasLambda, "Scheduler Lambda presence")
+
+			outputChange, ok := plan.RawPlan.OutputChanges["schedule_expressions"]
+			require.True(t, ok, "Plan should include schedule_expressions")
+			expressions, ok := outputChange.After.(map[string]interface{})
+			require.True(t, ok, "schedule_expressions should be known at plan time")
+
+			instance, ok := plan.ResourcePlannedValuesMap["aws_db_instance.main[0]"]
+			require.True(t, ok, "Primary instance should be planned")
+			tags, _ := instance.AttributeValues["tags"].(map[string]interface{})
+
+			if !tc.expectScheduler {
+				assert.Empty(t, expressions, "Production should have no schedules")
+				assert.NotContains(t, tags, "Schedule", "Production database should not carry the schedule tag")
+				return
+			}
+
+			assert.Equal(t, "cron(0 2 ? * * *)", stopRule.AttributeValues["schedule_expression"])
+			assert.Equal(t, "cron(0 11 ? * MON-FRI *)", startRule.AttributeValues["schedule_expression"])
+			assert.Equal(t, map[string]interface{}{
+				"stop":  "cron(0 2 ? * * *)",
+				"start": "cron(0 11 ? * MON-FRI *)",
+			}, expressions)
+			assert.Equal(t, "office-hours", tags["Schedule"], "Scheduled database should carry the schedule tag")
+		})
+	}
+}
@@ -2,15 +2,19 @@ package test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/gruntwork-io/terratest/modules/aws"
-	"github.com/gruntwork-io/terratest/modules/random"
 	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hipaa-compliant-stack/terraform/tests/helpers"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -21,9 +25,8 @@ func TestS3ModuleBucketCreation(t *testing.T) {
 
 	awsRegion := "us-east-1"
 	expectedAccountID := aws.GetAccountId(t)
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/s3",
@@ -81,9 +84,8 @@ func TestS3ModuleEncryption(t *testing.T) {
 
 	awsRegion := "us-east-1"
 	expectedAccountID := aws.GetAccountId(t)
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/s3",
@@ -146,9 +148,8 @@ func TestS3ModuleVersioning(t *testing.T) {
 
 	awsRegion := "us-east-1"
 	expectedAccountID := aws.GetAccountId(t)
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/s3",
@@ -205,9 +206,8 @@ func TestS3ModulePublicAccessBlock(t *testing.T) {
 
 	awsRegion := "us-east-1"
 	expectedAccountID := aws.GetAccountId(t)
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/s3",
@@ -252,9 +252,8 @@ func TestS3ModuleOutputs(t *testing.T) {
 
 	awsRegion := "us-east-1"
 	expectedAccountID := aws.GetAccountId(t)
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/s3",
@@ -294,9 +293,8 @@ func TestS3ModuleMinimalInputs(t *testing.T) {
 
 	awsRegion := "us-east-1"
 	expectedAccountID := aws.GetAccountId(t)
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/s3",
@@ -318,3 +316,878 @@ func TestS3ModuleMinimalInputs(t *testing.T) {
 	documentsBucket := terraform.Output(t, terraformOptions, "s3_bucket_documents")
 	assert.NotEmpty(t, documentsBucket)
 }
+
+// TestS3ModuleCrossRegionReplica verifies the documents replica bucket lands in the replica provider region
+func TestS3ModuleCrossRegionReplica(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	replicaRegion := "us-west-2"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../fixtures/s3_replica",
+		Vars: map[string]interface{}{
+			"aws_region":     awsRegion,
+			"replica_region": replicaRegion,
+			"name_suffix":    nameSuffix,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	replicaBucket := terraform.Output(t, terraformOptions, "s3_bucket_documents_replica")
+	require.NotEmpty(t, replicaBucket, "Replica bucket should be created when replication is enabled")
+
+	replicaKeyArn := terraform.Output(t, terraformOptions, "kms_replica_key_arn")
+	assert.Contains(t, replicaKeyArn, fmt.Sprintf("arn:aws:kms:%s:", replicaRegion), "Replica key should live in the replica region")
+
+	cfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRegion(awsRegion))
+	require.NoError(t, err)
+	s3Client := s3.NewFromConfig(cfg)
+
+	location, err := s3Client.GetBucketLocation(context.TODO(), &s3.GetBucketLocationInput{
+		Bucket: &replicaBucket,
+	})
+	require.NoError(t, err, "Should be able to get replica bucket location")
+	assert.Equal(t, replicaRegion, string(location.LocationConstraint), "Replica bucket should be in the replica region")
+}
+
+// TestS3ModuleCanaryBucket verifies the canary bucket has object-level CloudTrail logging and an access alarm wired to SNS
+func TestS3ModuleCanaryBucket(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../fixtures/cloudtrail",
+		Vars: map[string]interface{}{
+			"aws_region":           awsRegion,
+			"name_suffix":          nameSuffix,
+			"create_canary_bucket": true,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	canaryBucketArn := terraform.Output(t, terraformOptions, "canary_bucket_arn")
+	require.NotEmpty(t, canaryBucketArn, "Canary bucket should be created")
+
+	dataEventResources := helpers.GetCloudTrailS3DataEventResources(t, awsRegion, terraform.Output(t, terraformOptions, "cloudtrail_name"))
+	assert.Contains(t, dataEventResources, canaryBucketArn+"/", "Canary bucket object access should be logged as data events")
+
+	alarm := helpers.GetMetricAlarm(t, awsRegion, terraform.Output(t, terraformOptions, "canary_alarm_name"))
+	assert.Equal(t, "GetRequests", *alarm.MetricName)
+	assert.Equal(t, "GreaterThanThreshold", *alarm.ComparisonOperator)
+	require.Len(t, alarm.AlarmActions, 1, "Canary alarm should notify exactly one SNS topic")
+	assert.Equal(t, terraform.Output(t, terraformOptions, "canary_alert_topic_arn"), *alarm.AlarmActions[0])
+	assert.Contains(t, *alarm.AlarmActions[0], ":sns:", "Canary alarm action should be an SNS topic")
+}
+
+// TestS3ModuleQuarantineBucket verifies the quarantine bucket policy denies object access to everyone but the quarantine role
+func TestS3ModuleQuarantineBucket(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+	expectedAccountID := aws.GetAccountId(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/s3",
+		Vars: map[string]interface{}{
+			"environment":               "dev",
+			"name_suffix":               nameSuffix,
+			"aws_account_id":            expectedAccountID,
+			"kms_key_id":                fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/test-key-id", expectedAccountID),
+			"enable_lifecycle_policies": false,
+			"create_quarantine_bucket":  true,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	quarantineBucket := terraform.Output(t, terraformOptions, "quarantine_bucket_name")
+	quarantineRoleArn := terraform.Output(t, terraformOptions, "quarantine_role_arn")
+	require.NotEmpty(t, quarantineBucket, "Quarantine bucket should be created")
+	require.NotEmpty(t, quarantineRoleArn, "Quarantine role should be created")
+	assert.Equal(t, fmt.Sprintf("arn:aws:s3:::%s", quarantineBucket), terraform.Output(t, terraformOptions, "quarantine_bucket_arn"))
+
+	aws.AssertS3BucketVersioningExists(t, awsRegion, quarantineBucket)
+
+	var policy struct {
+		Statement []struct {
+			Sid       string
+			Effect    string
+			Principal interface{}
+			Action    interface{}
+			Condition map[string]map[string]interface{}
+		}
+	}
+	require.NoError(t, json.Unmarshal([]byte(aws.GetS3BucketPolicy(t, awsRegion, quarantineBucket)), &policy))
+
+	var denyFound, allowFound bool
+	for _, statement := range policy.Statement {
+		switch statement.Sid {
+		case "DenyObjectAccessExceptQuarantineRole":
+			denyFound = true
+			assert.Equal(t, "Deny", statement.Effect)
+			assert.Equal(t, "*", statement.Principal, "Deny should apply to all principals")
+			assert.Contains(t, statement.Action, "s3:GetObject*")
+			assert.Equal(t, quarantineRoleArn, statement.Condition["StringNotEquals"]["aws:PrincipalArn"],
+				"Only the quarantine role should be exempt from the deny")
+		case "AllowQuarantineRole":
+			allowFound = true
+			assert.Equal(t, "Allow", statement.Effect)
+			assert.Equal(t, map[string]interface{}{"AWS": quarantineRoleArn}, statement.Principal)
+		}
+	}
+
+	assert.True(t, denyFound, "Quarantine policy should deny object access to all other principals")
+	assert.True(t, allowFound, "Quarantine policy should allow the quarantine role")
+}
+
+// TestS3ModuleQuarantineAutomation verifies the quarantine bucket is locked down and the findings Lambda is wired to high-severity Macie/GuardDuty events
+func TestS3ModuleQuarantineAutomation(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+	expectedAccountID := aws.GetAccountId(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/s3",
+		Vars: map[string]interface{}{
+			"environment":                  "dev",
+			"name_suffix":                  nameSuffix,
+			"aws_account_id":               expectedAccountID,
+			"kms_key_id":                   fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/test-key-id", expectedAccountID),
+			"enable_lifecycle_policies":    false,
+			"create_quarantine_bucket":     true,
+			"enable_quarantine_automation": true,
+			"quarantine_retention_days":    30,
+			"allow_destroy":                true,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	quarantineBucket := terraform.Output(t, terraformOptions, "quarantine_bucket_name")
+	automation := terraform.OutputMap(t, terraformOptions, "quarantine_automation")
+	require.NotEmpty(t, automation["lambda_function"], "Findings Lambda should be created")
+
+	// Bucket hardening: KMS encryption, Object Lock, and no public access
+	cfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRegion(awsRegion))
+	require.NoError(t, err)
+	s3Client := s3.NewFromConfig(cfg)
+
+	encryption, err := s3Client.GetBucketEncryption(context.TODO(), &s3.GetBucketEncryptionInput{Bucket: &quarantineBucket})
+	require.NoError(t, err)
+	require.NotEmpty(t, encryption.ServerSideEncryptionConfiguration.Rules)
+	assert.Equal(t, "aws:kms", string(encryption.ServerSideEncryptionConfiguration.Rules[0].ApplyServerSideEncryptionByDefault.SSEAlgorithm))
+
+	objectLock, err := s3Client.GetObjectLockConfiguration(context.TODO(), &s3.GetObjectLockConfigurationInput{Bucket: &quarantineBucket})
+	require.NoError(t, err)
+	assert.Equal(t, "Enabled", string(objectLock.ObjectLockConfiguration.ObjectLockEnabled))
+	require.NotNil(t, objectLock.ObjectLockConfiguration.Rule)
+	assert.Equal(t, "GOVERNANCE", string(objectLock.ObjectLockConfiguration.Rule.DefaultRetention.Mode))
+	assert.Equal(t, int32(30), *objectLock.ObjectLockConfiguration.Rule.DefaultRetention.Days)
+
+	publicAccessBlock, err := s3Client.GetPublicAccessBlock(context.TODO(), &s3.GetPublicAccessBlockInput{Bucket: &quarantineBucket})
+	require.NoError(t, err)
+	assert.True(t, *publicAccessBlock.PublicAccessBlockConfiguration.BlockPublicAcls)
+	assert.True(t, *publicAccessBlock.PublicAccessBlockConfiguration.BlockPublicPolicy)
+	assert.True(t, *publicAccessBlock.PublicAccessBlockConfiguration.IgnorePublicAcls)
+	assert.True(t, *publicAccessBlock.PublicAccessBlockConfiguration.RestrictPublicBuckets)
+
+	// The Lambda moves objects into the quarantine bucket and alerts the topic
+	env := helpers.GetLambdaEnvironment(t, awsRegion, automation["lambda_function"])
+	assert.Equal(t, quarantineBucket, env["QUARANTINE_BUCKET"])
+	assert.Equal(t, automation["alert_topic_arn"], env["ALERT_TOPIC_ARN"])
+	assert.Contains(t, env["SOURCE_BUCKETS"], terraform.Output(t, terraformOptions, "s3_bucket_documents"))
+
+	lambdaArn := fmt.Sprintf("arn:aws:lambda:%s:%s:function:%s", awsRegion, expectedAccountID, automation["lambda_function"])
+
+	var guardDutyPattern struct {
+		Source     []string `json:"source"`
+		DetailType []string `json:"detail-type"`
+		Detail     struct {
+			Severity []struct {
+				Numeric []interface{} `json:"numeric"`
+			} `json:"severity"`
+		} `json:"detail"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(helpers.GetEventRulePattern(t, awsRegion, automation["guardduty_rule"])), &guardDutyPattern))
+	assert.Equal(t, []string{"aws.guardduty"}, guardDutyPattern.Source)
+	assert.Equal(t, []string{"GuardDuty Finding"}, guardDutyPattern.DetailType)
+	require.Len(t, guardDutyPattern.Detail.Severity, 1)
+	assert.Equal(t, []interface{}{">=", float64(7)}, guardDutyPattern.Detail.Severity[0].Numeric, "Only high-severity GuardDuty findings should trigger quarantine")
+	assert.Contains(t, helpers.GetEventRuleTargetArns(t, awsRegion, automation["guardduty_rule"]), lambdaArn)
+
+	var maciePattern struct {
+		Source     []string `json:"source"`
+		DetailType []string `json:"detail-type"`
+		Detail     struct {
+			Severity struct {
+				Description []string `json:"description"`
+			} `json:"severity"`
+		} `json:"detail"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(helpers.GetEventRulePattern(t, awsRegion, automation["macie_rule"])), &maciePattern))
+	assert.Equal(t, []string{"aws.macie"}, maciePattern.Source)
+	assert.Equal(t, []string{"Macie Finding"}, maciePattern.DetailType)
+	assert.Equal(t, []string{"High"}, maciePattern.Detail.Severity.Description, "Only high-severity Macie findings should trigger quarantine")
+	assert.Contains(t, helpers.GetEventRuleTargetArns(t, awsRegion, automation["macie_rule"]), lambdaArn)
+}
+
+// TestBucketNamesDistinct verifies the documents, backups and audit bucket names are unique and an override colliding with the audit bucket is rejected
+func TestBucketNamesDistinct(t *testing.T) {
+	t.Parallel()
+
+	expectedAccountID := aws.GetAccountId(t)
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	vars := map[string]interface{}{
+		"environment":               "dev",
+		"name_suffix":               nameSuffix,
+		"aws_account_id":            expectedAccountID,
+		"kms_key_id":                fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/test-key-id", expectedAccountID),
+		"enable_lifecycle_policies": false,
+	}
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/s3",
+		Vars:         vars,
+		PlanFilePath: filepath.Join(t.TempDir(), "names.tfplan"),
+		NoColor:      true,
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, terraformOptions)
+
+	names := map[string]string{}
+	for _, address := range []string{"aws_s3_bucket.documents", "aws_s3_bucket.backups", "aws_s3_bucket.audit_logs"} {
+		resource, ok := plan.ResourcePlannedValuesMap[address]
+		require.True(t, ok, "Plan should include %s", address)
+		name, ok := resource.AttributeValues["bucket"].(string)
+		require.True(t, ok, "%s should have a known bucket name at plan time", address)
+
+		for otherAddress, otherName := range names {
+			assert.NotEqual(t, otherName, name, "%s and %s should not share a bucket name", address, otherAddress)
+		}
+		names[address] = name
+	}
+
+	// Pointing the documents bucket at the audit bucket name would make it log to itself
+	collidingVars := map[string]interface{}{}
+	for key, value := range vars {
+		collidingVars[key] = value
+	}
+	collidingVars["documents_bucket_name"] = names["aws_s3_bucket.audit_logs"]
+
+	_, err := terraform.InitAndPlanE(t, &terraform.Options{
+		TerraformDir: "../../modules/s3",
+		Vars:         collidingVars,
+		NoColor:      true,
+	})
+	require.Error(t, err, "Documents bucket named like the audit bucket should fail the precondition")
+	assert.Contains(t, err.Error(), "access logging loop")
+}
+
+// TestS3ModuleRequestMetrics verifies request metrics are enabled on the documents bucket with a 4xx alarm on that filter
+func TestS3ModuleRequestMetrics(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+	expectedAccountID := aws.GetAccountId(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/s3",
+		Vars: map[string]interface{}{
+			"environment":               "dev",
+			"name_suffix":               nameSuffix,
+			"aws_account_id":            expectedAccountID,
+			"kms_key_id":                fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/test-key-id", expectedAccountID),
+			"enable_lifecycle_policies": false,
+			"enable_request_metrics":    true,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	documentsBucket := terraform.Output(t, terraformOptions, "s3_bucket_documents")
+	metricsID := terraform.Output(t, terraformOptions, "request_metrics_id")
+	require.NotEmpty(t, metricsID, "Request metrics should be configured")
+
+	bucket, filterID, found := strings.Cut(metricsID, ":")
+	require.True(t, found, "Metrics ID %q should be bucket:filter", metricsID)
+	assert.Equal(t, documentsBucket, bucket)
+
+	cfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRegion(awsRegion))
+	require.NoError(t, err)
+	s3Client := s3.NewFromConfig(cfg)
+
+	metrics, err := s3Client.GetBucketMetricsConfiguration(context.TODO(), &s3.GetBucketMetricsConfigurationInput{
+		Bucket: &documentsBucket,
+		Id:     &filterID,
+	})
+	require.NoError(t, err, "Documents bucket should have a request metrics configuration")
+	assert.Equal(t, filterID, *metrics.MetricsConfiguration.Id)
+	assert.Nil(t, metrics.MetricsConfiguration.Filter, "Request metrics should cover the entire bucket")
+
+	alarmNames := terraform.OutputMap(t, terraformOptions, "request_error_alarm_names")
+	alarm := helpers.GetMetricAlarm(t, awsRegion, alarmNames["documents_4xx"])
+	assert.Equal(t, "4xxErrors", awssdk.StringValue(alarm.MetricName))
+
+	dimensions := map[string]string{}
+	for _, dimension := range alarm.Dimensions {
+		dimensions[awssdk.StringValue(dimension.Name)] = awssdk.StringValue(dimension.Value)
+	}
+	assert.Equal(t, documentsBucket, dimensions["BucketName"])
+	assert.Equal(t, filterID, dimensions["FilterId"], "Alarm should read the documents bucket request metrics")
+}
+
+// TestS3ModuleSecondaryKMSKey verifies both documents keys are authorized by the bucket policy and new writes use the secondary key once it is active
+func TestS3ModuleSecondaryKMSKey(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+	expectedAccountID := aws.GetAccountId(t)
+
+	keyArns := map[string]string{}
+	for _, key := range []string{"primary", "secondary"} {
+		kmsOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+			TerraformDir: "../../modules/kms",
+			Vars: map[string]interface{}{
+				"environment":    "dev",
+				"name_suffix":    fmt.Sprintf("%s-%s", nameSuffix, key),
+				"aws_account_id": expectedAccountID,
+				"allow_destroy":  true,
+			},
+			EnvVars: map[string]string{
+				"AWS_DEFAULT_REGION": awsRegion,
+			},
+			NoColor: true,
+		})
+
+		defer terraform.Destroy(t, kmsOptions)
+		terraform.InitAndApply(t, kmsOptions)
+		keyArns[key] = terraform.Output(t, kmsOptions, "kms_master_key_arn")
+	}
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/s3",
+		Vars: map[string]interface{}{
+			"environment":               "dev",
+			"name_suffix":               nameSuffix,
+			"aws_account_id":            expectedAccountID,
+			"kms_key_id":                keyArns["primary"],
+			"secondary_kms_key_arn":     keyArns["secondary"],
+			"documents_active_kms_key":  "secondary",
+			"enable_lifecycle_policies": false,
+			"allow_destroy":             true,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	documentsBucket := terraform.Output(t, terraformOptions, "s3_bucket_documents")
+	documentsKeys := terraform.OutputMapOfObjects(t, terraformOptions, "documents_kms_keys")
+	assert.Equal(t, keyArns["secondary"], documentsKeys["active_key_arn"])
+
+	var policy struct {
+		Statement []struct {
+			Sid       string
+			Effect    string
+			Condition map[string]map[string]interface{}
+		}
+	}
+	require.NoError(t, json.Unmarshal([]byte(aws.GetS3BucketPolicy(t, awsRegion, documentsBucket)), &policy))
+
+	var authorizedKeys []interface{}
+	for _, statement := range policy.Statement {
+		if statement.Sid == "DenyUnauthorizedKMSKeys" {
+			assert.Equal(t, "Deny", statement.Effect)
+			authorizedKeys, _ = statement.Condition["StringNotEqualsIfExists"]["s3:x-amz-server-side-encryption-aws-kms-key-id"].([]interface{})
+		}
+	}
+	assert.ElementsMatch(t, []interface{}{keyArns["primary"], keyArns["secondary"]}, authorizedKeys,
+		"Bucket policy should authorize exactly the primary and secondary keys")
+
+	cfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRegion(awsRegion))
+	require.NoError(t, err)
+	s3Client := s3.NewFromConfig(cfg)
+
+	// Default encryption should pick up the new key for writes that name none
+	newKey := "key-transition/new.txt"
+	_, err = s3Client.PutObject(context.TODO(), &s3.PutObjectInput{
+		Bucket: &documentsBucket,
+		Key:    &newKey,
+		Body:   strings.NewReader("written under the secondary key"),
+	})
+	require.NoError(t, err, "Write should succeed under the active secondary key")
+
+	head, err := s3Client.HeadObject(context.TODO(), &s3.HeadObjectInput{Bucket: &documentsBucket, Key: &newKey})
+	require.NoError(t, err)
+	assert.Equal(t, keyArns["secondary"], awssdk.StringValue(head.SSEKMSKeyId), "New objects should be encrypted with the secondary key")
+
+	// The old key stays authorized so objects still under it remain usable mid-migration
+	oldKey := "key-transition/old.txt"
+	_, err = s3Client.PutObject(context.TODO(), &s3.PutObjectInput{
+		Bucket:               &documentsBucket,
+		Key:                  &oldKey,
+		Body:                 strings.NewReader("written under the primary key"),
+		ServerSideEncryption: types.ServerSideEncryptionAwsKms,
+		SSEKMSKeyId:          awssdk.String(keyArns["primary"]),
+	})
+	require.NoError(t, err, "Primary key should remain authorized during the transition")
+
+	_, err = s3Client.GetObject(context.TODO(), &s3.GetObjectInput{Bucket: &documentsBucket, Key: &oldKey})
+	assert.NoError(t, err, "Objects under the primary key should remain decryptable")
+}
+
+// TestS3ModuleDenyUnencryptedUploads verifies every bucket rejects uploads that request other encryption, and accepts header-less, SSE-KMS and multipart uploads under the master key
+func TestS3ModuleDenyUnencryptedUploads(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+	expectedAccountID := aws.GetAccountId(t)
+
+	kmsOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/kms",
+		Vars: map[string]interface{}{
+			"environment":    "dev",
+			"name_suffix":    nameSuffix,
+			"aws_account_id": expectedAccountID,
+			"allow_destroy":  true,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, kmsOptions)
+	terraform.InitAndApply(t, kmsOptions)
+	keyArn := terraform.Output(t, kmsOptions, "kms_master_key_arn")
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/s3",
+		Vars: map[string]interface{}{
+			"environment":               "dev",
+			"name_suffix":               nameSuffix,
+			"aws_account_id":            expectedAccountID,
+			"kms_key_id":                keyArn,
+			"deny_unencrypted_uploads":  true,
+			"enable_lifecycle_policies": false,
+			"allow_destroy":             true,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	cfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRegion(awsRegion))
+	require.NoError(t, err)
+	s3Client := s3.NewFromConfig(cfg)
+
+	for _, output := range []string{"s3_bucket_documents", "s3_bucket_backups", "s3_bucket_audit_logs"} {
+		bucket := terraform.Output(t, terraformOptions, output)
+
+		// No encryption header: default bucket encryption applies the authorized key
+		_, err := s3Client.PutObject(context.TODO(), &s3.PutObjectInput{
+			Bucket: awssdk.String(bucket),
+			Key:    awssdk.String("encryption-check/no-header.txt"),
+			Body:   strings.NewReader("default-encrypted upload"),
+		})
+		require.NoError(t, err, "%s should accept uploads that rely on default encryption", output)
+
+		head, err := s3Client.HeadObject(context.TODO(), &s3.HeadObjectInput{
+			Bucket: awssdk.String(bucket),
+			Key:    awssdk.String("encryption-check/no-header.txt"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, keyArn, awssdk.StringValue(head.SSEKMSKeyId), "%s should encrypt header-less uploads with the master key", output)
+
+		// aws:kms without a key ID would fall back to the AWS managed aws/s3 key
+		_, err = s3Client.PutObject(context.TODO(), &s3.PutObjectInput{
+			Bucket:               awssdk.String(bucket),
+			Key:                  awssdk.String("encryption-check/aws-managed.txt"),
+			Body:                 strings.NewReader("AWS managed key upload"),
+			ServerSideEncryption: types.ServerSideEncryptionAwsKms,
+		})
+		require.Error(t, err, "%s should reject SSE-KMS uploads that name no key", output)
+		assert.Contains(t, err.Error(), "AccessDenied")
+
+		_, err = s3Client.PutObject(context.TODO(), &s3.PutObjectInput{
+			Bucket:               awssdk.String(bucket),
+			Key:                  awssdk.String("encryption-check/sse-s3.txt"),
+			Body:                 strings.NewReader("SSE-S3 upload"),
+			ServerSideEncryption: types.ServerSideEncryptionAes256,
+		})
+		require.Error(t, err, "%s should reject SSE-S3 uploads", output)
+		assert.Contains(t, err.Error(), "AccessDenied")
+
+		_, err = s3Client.PutObject(context.TODO(), &s3.PutObjectInput{
+			Bucket:               awssdk.String(bucket),
+			Key:                  awssdk.String("encryption-check/sse-kms.txt"),
+			Body:                 strings.NewReader("SSE-KMS upload"),
+			ServerSideEncryption: types.ServerSideEncryptionAwsKms,
+			SSEKMSKeyId:          awssdk.String(keyArn),
+		})
+		assert.NoError(t, err, "%s should accept SSE-KMS uploads under the master key", output)
+
+		// UploadPart is authorized as s3:PutObject but never carries encryption headers
+		multipartKey := awssdk.String("encryption-check/multipart.txt")
+		upload, err := s3Client.CreateMultipartUpload(context.TODO(), &s3.CreateMultipartUploadInput{
+			Bucket:               awssdk.String(bucket),
+			Key:                  multipartKey,
+			ServerSideEncryption: types.ServerSideEncryptionAwsKms,
+			SSEKMSKeyId:          awssdk.String(keyArn),
+		})
+		require.NoError(t, err, "%s should accept SSE-KMS multipart uploads under the master key", output)
+
+		part, err := s3Client.UploadPart(context.TODO(), &s3.UploadPartInput{
+			Bucket:     awssdk.String(bucket),
+			Key:        multipartKey,
+			UploadId:   upload.UploadId,
+			PartNumber: awssdk.Int32(1),
+			Body:       strings.NewReader("multipart upload"),
+		})
+		require.NoError(t, err, "%s should accept the parts of an SSE-KMS multipart upload", output)
+
+		_, err = s3Client.CompleteMultipartUpload(context.TODO(), &s3.CompleteMultipartUploadInput{
+			Bucket:   awssdk.String(bucket),
+			Key:      multipartKey,
+			UploadId: upload.UploadId,
+			MultipartUpload: &types.CompletedMultipartUpload{
+				Parts: []types.CompletedPart{{ETag: part.ETag, PartNumber: awssdk.Int32(1)}},
+			},
+		})
+		assert.NoError(t, err, "%s should complete SSE-KMS multipart uploads", output)
+	}
+}
+
+// TestS3ModuleIntelligentTiering verifies the documents bucket has archive tiers and transitions into Intelligent-Tiering
+func TestS3ModuleIntelligentTiering(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+	expectedAccountID := aws.GetAccountId(t)
+
+	kmsOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/kms",
+		Vars: map[string]interface{}{
+			"environment":    "dev",
+			"name_suffix":    nameSuffix,
+			"aws_account_id": expectedAccountID,
+			"allow_destroy":  true,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, kmsOptions)
+	terraform.InitAndApply(t, kmsOptions)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/s3",
+		Vars: map[string]interface{}{
+			"environment":                "dev",
+			"name_suffix":                nameSuffix,
+			"aws_account_id":             expectedAccountID,
+			"kms_key_id":                 terraform.Output(t, kmsOptions, "kms_master_key_id"),
+			"enable_lifecycle_policies":  true,
+			"enable_intelligent_tiering": true,
+			"allow_destroy":              true,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	bucket := terraform.Output(t, terraformOptions, "s3_bucket_documents")
+	configurationID := terraform.Output(t, terraformOptions, "intelligent_tiering_configuration_id")
+	require.NotEmpty(t, configurationID)
+	configurationName := strings.TrimPrefix(configurationID, bucket+":")
+
+	cfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRegion(awsRegion))
+	require.NoError(t, err)
+	s3Client := s3.NewFromConfig(cfg)
+
+	tiering, err := s3Client.GetBucketIntelligentTieringConfiguration(context.TODO(), &s3.GetBucketIntelligentTieringConfigurationInput{
+		Bucket: awssdk.String(bucket),
+		Id:     awssdk.String(configurationName),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, types.IntelligentTieringStatusEnabled, tiering.IntelligentTieringConfiguration.Status)
+
+	tierDays := map[types.IntelligentTieringAccessTier]int32{}
+	for _, tier := range tiering.IntelligentTieringConfiguration.Tierings {
+		require.NotNil(t, tier.Days)
+		tierDays[tier.AccessTier] = *tier.Days
+	}
+	assert.Equal(t, int32(90), tierDays[types.IntelligentTieringAccessTierArchiveAccess], "Archive Access tier should apply after 90 days")
+	assert.Equal(t, int32(180), tierDays[types.IntelligentTieringAccessTierDeepArchiveAccess], "Deep Archive Access tier should apply after 180 days")
+
+	// Objects only reach Intelligent-Tiering through the lifecycle transition
+	lifecycle, err := s3Client.GetBucketLifecycleConfiguration(context.TODO(), &s3.GetBucketLifecycleConfigurationInput{
+		Bucket: awssdk.String(bucket),
+	})
+	require.NoError(t, err)
+	var storageClasses []types.TransitionStorageClass
+	for _, rule := range lifecycle.Rules {
+		for _, transition := range rule.Transitions {
+			storageClasses = append(storageClasses, transition.StorageClass)
+		}
+	}
+	assert.Equal(t, []types.TransitionStorageClass{types.TransitionStorageClassIntelligentTiering}, storageClasses,
+		"Documents should transition only to Intelligent-Tiering")
+}
+
+// TestS3ModuleTransferAccelerationDisabled verifies PHI buckets keep Transfer Acceleration suspended and that enabling it fails validation
+func TestS3ModuleTransferAccelerationDisabled(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+	expectedAccountID := aws.GetAccountId(t)
+
+	kmsOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/kms",
+		Vars: map[string]interface{}{
+			"environment":    "dev",
+			"name_suffix":    nameSuffix,
+			"aws_account_id": expectedAccountID,
+			"allow_destroy":  true,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, kmsOptions)
+	terraform.InitAndApply(t, kmsOptions)
+
+	vars := map[string]interface{}{
+		"environment":    "dev",
+		"name_suffix":    nameSuffix,
+		"aws_account_id": expectedAccountID,
+		"kms_key_id":     terraform.Output(t, kmsOptions, "kms_master_key_id"),
+		"allow_destroy":  true,
+	}
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/s3",
+		Vars:         vars,
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+		NoColor: true,
+	})
+
+	enabledVars := map[string]interface{}{"enable_transfer_acceleration": true}
+	for key, value := range vars {
+		enabledVars[key] = value
+	}
+	_, err := terraform.InitAndPlanE(t, &terraform.Options{
+		TerraformDir: "../../modules/s3",
+		Vars:         enabledVars,
+		EnvVars:      terraformOptions.EnvVars,
+		NoColor:      true,
+	})
+	require.Error(t, err, "Enabling Transfer Acceleration should fail validation")
+	assert.Contains(t, err.Error(), "enable_transfer_acceleration must be false")
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	cfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRegion(awsRegion))
+	require.NoError(t, err)
+	s3Client := s3.NewFromConfig(cfg)
+
+	for _, output := range []string{"s3_bucket_documents", "s3_bucket_backups"} {
+		bucket := terraform.Output(t, terraformOptions, output)
+
+		accelerate, err := s3Client.GetBucketAccelerateConfiguration(context.TODO(), &s3.GetBucketAccelerateConfigurationInput{
+			Bucket: awssdk.String(bucket),
+		})
+		require.NoError(t, err)
+		assert.NotEqual(t, types.BucketAccelerateStatusEnabled, accelerate.Status, "Transfer Acceleration should not be enabled on %s", bucket)
+		assert.Equal(t, types.BucketAccelerateStatusSuspended, accelerate.Status, "Transfer Acceleration should be explicitly suspended on %s", bucket)
+	}
+}
+
+// TestPlanAllBucketsHavePublicAccessBlock verifies from the plan alone that every bucket in the s3 module has a public access block with all four flags on
+func TestPlanAllBucketsHavePublicAccessBlock(t *testing.T) {
+	t.Parallel()
+
+	expectedAccountID := aws.GetAccountId(t)
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/s3",
+		Vars: map[string]interface{}{
+			"environment":               "dev",
+			"name_suffix":               nameSuffix,
+			"aws_account_id":            expectedAccountID,
+			"kms_key_id":                fmt.Sprintf("arn:aws:kms:us-east-1:%s:key/test-key-id", expectedAccountID),
+			"enable_lifecycle_policies": false,
+			"create_canary_bucket":      true,
+			"create_quarantine_bucket":  true,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": "us-east-1",
+		},
+		PlanFilePath: filepath.Join(t.TempDir(), "pab.tfplan"),
+		NoColor:      true,
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, terraformOptions)
+	flags := []string{"block_public_acls", "block_public_policy", "ignore_public_acls", "restrict_public_buckets"}
+
+	// Configuration covers buckets whose count is 0 in this plan (e.g. the replica), so
+	// match each aws_s3_bucket to the public access block whose bucket argument references it
+	configBuckets := map[string]bool{}
+	protected := map[string]bool{}
+	for _, resource := range plan.RawPlan.Config.RootModule.Resources {
+		switch resource.Type {
+		case "aws_s3_bucket":
+			configBuckets[resource.Address] = true
+		case "aws_s3_bucket_public_access_block":
+			for _, flag := range flags {
+				expression, ok := resource.Expressions[flag]
+				require.True(t, ok, "%s should set %s", resource.Address, flag)
+				assert.Equal(t, true, expression.ConstantValue, "%s should set %s = true", resource.Address, flag)
+			}
+
+			bucket, ok := resource.Expressions["bucket"]
+			require.True(t, ok, "%s should set bucket", resource.Address)
+			for _, reference := range bucket.References {
+				// aws_s3_bucket.canary[0].id refers to the aws_s3_bucket.canary resource
+				parts := strings.Split(strings.SplitN(reference, "[", 2)[0], ".")
+				if len(parts) >= 2 && parts[0] == "aws_s3_bucket" {
+					protected[parts[0]+"."+parts[1]] = true
+				}
+			}
+		}
+	}
+	require.NotEmpty(t, configBuckets)
+	for address := range configBuckets {
+		assert.True(t, protected[address], "%s has no aws_s3_bucket_public_access_block", address)
+	}
+
+	// Every bucket instance in this plan gets a public access block instance
+	bucketInstances := 0
+	blockInstances := 0
+	for address, resource := range plan.ResourcePlannedValuesMap {
+		switch resource.Type {
+		case "aws_s3_bucket":
+			bucketInstances++
+		case "aws_s3_bucket_public_access_block":
+			blockInstances++
+			for _, flag := range flags {
+				assert.Equal(t, true, resource.AttributeValues[flag], "%s should plan %s = true", address, flag)
+			}
+		}
+	}
+	assert.Equal(t, 5, bucketInstances, "Plan should include documents, backups, audit, canary and quarantine buckets")
+	assert.Equal(t, bucketInstances, blockInstances, "Every planned bucket needs a public access block")
+}
+
+// TestS3ModuleEventBridgeNotifications verifies the documents and backups buckets publish object events to EventBridge when enabled
+func TestS3ModuleEventBridgeNotifications(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+	expectedAccountID := aws.GetAccountId(t)
+
+	kmsOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/kms",
+		Vars: map[string]interface{}{
+			"environment":    "dev",
+			"name_suffix":    nameSuffix,
+			"aws_account_id": expectedAccountID,
+			"allow_destroy":  true,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, kmsOptions)
+	terraform.InitAndApply(t, kmsOptions)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/s3",
+		Vars: map[string]interface{}{
+			"environment":                      "dev",
+			"name_suffix":                      nameSuffix,
+			"aws_account_id":                   expectedAccountID,
+			"kms_key_id":                       terraform.Output(t, kmsOptions, "kms_master_key_id"),
+			"enable_eventbridge_notifications": true,
+			"allow_destroy":                    true,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	status := terraform.OutputMap(t, terraformOptions, "eventbridge_notifications")
+	assert.Equal(t, map[string]string{"documents": "true", "backups": "true"}, status)
+
+	cfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRegion(awsRegion))
+	require.NoError(t, err)
+	s3Client := s3.NewFromConfig(cfg)
+
+	// The output reflects configuration; the live bucket setting is what feeds the audit pipeline
+	for _, output := range []string{"s3_bucket_documents", "s3_bucket_backups"} {
+		bucket := terraform.Output(t, terraformOptions, output)
+		notification, err := s3Client.GetBucketNotificationConfiguration(context.TODO(), &s3.GetBucketNotificationConfigurationInput{
+			Bucket: awssdk.String(bucket),
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, notification.EventBridgeConfiguration, "Bucket %s should send events to EventBridge", bucket)
+	}
+}
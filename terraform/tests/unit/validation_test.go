@@ -0,0 +1,104 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ==============================================================================
+// Required ARN Validation Tests
+// ==============================================================================
+// An empty ARN or ID passed between modules otherwise surfaces as a confusing
+// provider error (or a silently invalid policy) at apply time. Each required
+// reference is validated at plan time; these cases blank one at a time.
+
+// validModuleVars holds well-formed values for every module's required inputs
+var validModuleVars = map[string]map[string]interface{}{
+	"iam": {
+		"environment":              "dev",
+		"s3_bucket_documents_arn":  "arn:aws:s3:::test-documents",
+		"s3_bucket_backups_arn":    "arn:aws:s3:::test-backups",
+		"s3_bucket_audit_logs_arn": "arn:aws:s3:::test-audit-logs",
+		"kms_master_key_arn":       "arn:aws:kms:us-east-1:123456789012:key/test-key-id",
+	},
+	"cloudtrail": {
+		"environment":          "dev",
+		"s3_bucket_audit_logs": "test-audit-logs",
+		"kms_key_arn":          "arn:aws:kms:us-east-1:123456789012:key/test-key-id",
+	},
+	"config": {
+		"environment":          "dev",
+		"s3_bucket_audit_logs": "test-audit-logs",
+	},
+	"networking": {
+		"environment": "dev",
+		"vpc_id":      "vpc-test123",
+	},
+	"rds": {
+		"environment":        "dev",
+		"private_subnet_ids": []string{"subnet-test1", "subnet-test2"},
+		"security_group_id":  "sg-test123",
+		"kms_key_id":         "arn:aws:kms:us-east-1:123456789012:key/test-key-id",
+	},
+	"s3": {
+		"environment":    "dev",
+		"aws_account_id": "123456789012",
+		"kms_key_id":     "arn:aws:kms:us-east-1:123456789012:key/test-key-id",
+	},
+	"monitoring": {
+		"environment":             "dev",
+		"rds_instance_identifier": "dev-hipaa-db",
+	},
+}
+
+// TestRequiredArnValidation verifies each module rejects an empty required ARN or ID at plan time with a precise message
+func TestRequiredArnValidation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		module        string
+		variable      string
+		expectedError string
+	}{
+		{module: "iam", variable: "kms_master_key_arn", expectedError: "Must be a valid KMS key ARN"},
+		{module: "iam", variable: "s3_bucket_documents_arn", expectedError: "Must be a valid S3 bucket ARN"},
+		{module: "iam", variable: "s3_bucket_backups_arn", expectedError: "Must be a valid S3 bucket ARN"},
+		{module: "iam", variable: "s3_bucket_audit_logs_arn", expectedError: "Must be a valid S3 bucket ARN"},
+		{module: "cloudtrail", variable: "kms_key_arn", expectedError: "Must be a valid KMS key ARN"},
+		{module: "cloudtrail", variable: "s3_bucket_audit_logs", expectedError: "s3_bucket_audit_logs must be an S3 bucket name"},
+		{module: "config", variable: "s3_bucket_audit_logs", expectedError: "s3_bucket_audit_logs must be an S3 bucket name"},
+		{module: "networking", variable: "vpc_id", expectedError: "VPC ID must be a valid AWS VPC identifier"},
+		{module: "rds", variable: "kms_key_id", expectedError: "kms_key_id must be a KMS key ID, key ARN or alias"},
+		{module: "rds", variable: "security_group_id", expectedError: "security_group_id must be a security group ID"},
+		{module: "s3", variable: "kms_key_id", expectedError: "kms_key_id must be a KMS key ID, key ARN or alias"},
+		{module: "monitoring", variable: "rds_instance_identifier", expectedError: "rds_instance_identifier must be an RDS instance identifier"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.module+"/"+tc.variable, func(t *testing.T) {
+			t.Parallel()
+
+			vars := map[string]interface{}{}
+			for name, value := range validModuleVars[tc.module] {
+				vars[name] = value
+			}
+			require.Contains(t, vars, tc.variable, "Case should blank a required input of %s", tc.module)
+			vars[tc.variable] = ""
+
+			terraformOptions := &terraform.Options{
+				TerraformDir: "../../modules/" + tc.module,
+				Vars:         vars,
+				NoColor:      true,
+			}
+
+			_, err := terraform.InitAndPlanE(t, terraformOptions)
+			require.Error(t, err, "Empty %s should fail the plan", tc.variable)
+			assert.Contains(t, err.Error(), tc.expectedError)
+			assert.Contains(t, err.Error(), "var."+tc.variable, "Error should name the offending variable")
+		})
+	}
+}
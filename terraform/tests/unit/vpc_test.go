@@ -1,13 +1,19 @@
 package test
 
 import (
+	"encoding/json"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"testing"
 
-	"github.com/gruntwork-io/terratest/modules/random"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/gruntwork-io/terratest/modules/aws"
 	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hipaa-compliant-stack/terraform/tests/helpers"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // ==============================================================================
@@ -21,9 +27,8 @@ import (
 func TestVPCCreation(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/vpc",
@@ -55,9 +60,8 @@ func TestVPCCreation(t *testing.T) {
 func TestSubnetCreation(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/vpc",
@@ -85,13 +89,70 @@ func TestSubnetCreation(t *testing.T) {
 	assert.Len(t, publicSubnets, 3, "Expected 3 public subnets")
 }
 
+// TestSubnetTierTags verifies every subnet carries a Tier tag matching its role, so tooling can tell public from private subnets
+func TestSubnetTierTags(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/vpc",
+		Vars: map[string]interface{}{
+			"vpc_cidr":             "10.0.0.0/16",
+			"environment":          "dev",
+			"name_suffix":          nameSuffix,
+			"enable_nat_gateway":   false,
+			"enable_vpc_endpoints": false,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	expectedTiers := map[string]string{}
+	for _, subnetID := range terraform.OutputList(t, terraformOptions, "private_subnet_ids") {
+		expectedTiers[subnetID] = "private"
+	}
+	for _, subnetID := range terraform.OutputList(t, terraformOptions, "public_subnet_ids") {
+		expectedTiers[subnetID] = "public"
+	}
+	require.Len(t, expectedTiers, 6, "Expected 3 private and 3 public subnets")
+
+	ec2Client := aws.NewEc2Client(t, awsRegion)
+	result, err := ec2Client.DescribeSubnets(&ec2.DescribeSubnetsInput{
+		Filters: []*ec2.Filter{
+			{Name: awssdk.String("vpc-id"), Values: awssdk.StringSlice([]string{terraform.Output(t, terraformOptions, "vpc_id")})},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Subnets, len(expectedTiers), "Every subnet in the VPC should be a module subnet")
+
+	for _, subnet := range result.Subnets {
+		subnetID := awssdk.StringValue(subnet.SubnetId)
+		var tier string
+		for _, tag := range subnet.Tags {
+			if awssdk.StringValue(tag.Key) == "Tier" {
+				tier = awssdk.StringValue(tag.Value)
+			}
+		}
+
+		assert.Equal(t, expectedTiers[subnetID], tier, "Subnet %s has the wrong Tier tag", subnetID)
+		// A private-tier subnet that assigns public IPs would expose PHI workloads
+		assert.Equal(t, tier == "public", awssdk.BoolValue(subnet.MapPublicIpOnLaunch), "Subnet %s public IP assignment should match its tier", subnetID)
+	}
+}
+
 // TestInternetGateway verifies Internet Gateway is created
 func TestInternetGateway(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/vpc",
@@ -118,9 +179,8 @@ func TestInternetGateway(t *testing.T) {
 func TestNATGatewayCreation(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/vpc",
@@ -147,9 +207,8 @@ func TestNATGatewayCreation(t *testing.T) {
 func TestNATGatewayDisabled(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/vpc",
@@ -170,15 +229,70 @@ func TestNATGatewayDisabled(t *testing.T) {
 	// Verify NAT Gateway IDs list is empty
 	natGatewayIDs := terraform.OutputList(t, terraformOptions, "nat_gateway_ids")
 	assert.Empty(t, natGatewayIDs, "Expected no NAT gateways when disabled")
+	assert.Empty(t, terraform.OutputList(t, terraformOptions, "nat_gateway_eips"), "Expected no NAT EIPs when disabled")
+}
+
+// TestNATGatewayEIPs verifies each NAT gateway has its own VPC Elastic IP and the output lists them
+func TestNATGatewayEIPs(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/vpc",
+		Vars: map[string]interface{}{
+			"vpc_cidr":             "10.0.0.0/16",
+			"environment":          "dev",
+			"name_suffix":          nameSuffix,
+			"enable_nat_gateway":   true,
+			"enable_vpc_endpoints": false,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	natGatewayIDs := terraform.OutputList(t, terraformOptions, "nat_gateway_ids")
+	natGatewayEIPs := terraform.OutputList(t, terraformOptions, "nat_gateway_eips")
+	require.Len(t, natGatewayEIPs, len(natGatewayIDs), "Expected one EIP per NAT gateway")
+
+	ec2Client := aws.NewEc2Client(t, awsRegion)
+	gateways, err := ec2Client.DescribeNatGateways(&ec2.DescribeNatGatewaysInput{
+		NatGatewayIds: awssdk.StringSlice(natGatewayIDs),
+	})
+	require.NoError(t, err)
+	require.Len(t, gateways.NatGateways, len(natGatewayIDs))
+
+	var gatewayIPs []string
+	for _, gateway := range gateways.NatGateways {
+		require.Len(t, gateway.NatGatewayAddresses, 1, "NAT gateway %s should have exactly one address", awssdk.StringValue(gateway.NatGatewayId))
+		gatewayIPs = append(gatewayIPs, awssdk.StringValue(gateway.NatGatewayAddresses[0].PublicIp))
+	}
+	assert.ElementsMatch(t, natGatewayEIPs, gatewayIPs, "nat_gateway_eips should list the NAT gateways' public IPs")
+
+	addresses, err := ec2Client.DescribeAddresses(&ec2.DescribeAddressesInput{
+		PublicIps: awssdk.StringSlice(natGatewayEIPs),
+	})
+	require.NoError(t, err)
+	require.Len(t, addresses.Addresses, len(natGatewayEIPs))
+	for _, address := range addresses.Addresses {
+		publicIP := awssdk.StringValue(address.PublicIp)
+		assert.Equal(t, "vpc", awssdk.StringValue(address.Domain), "EIP %s should be allocated in the VPC domain", publicIP)
+		assert.NotEmpty(t, awssdk.StringValue(address.AssociationId), "EIP %s should be associated", publicIP)
+	}
 }
 
 // TestRouteTables verifies route tables are created
 func TestRouteTables(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/vpc",
@@ -205,13 +319,83 @@ func TestRouteTables(t *testing.T) {
 	assert.Len(t, privateRouteTableIDs, 3, "Expected 3 private route tables")
 }
 
+// TestPrivateRouteTablesDefaultRoute verifies private route tables only carry a 0.0.0.0/0 route to NAT when NAT is enabled
+func TestPrivateRouteTablesDefaultRoute(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+
+	testCases := []struct {
+		name             string
+		enableNATGateway bool
+	}{
+		{name: "NATDisabled", enableNATGateway: false},
+		{name: "NATEnabled", enableNATGateway: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			nameSuffix := helpers.UniqueNameSuffix(t)
+
+			terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+				TerraformDir: "../../modules/vpc",
+				Vars: map[string]interface{}{
+					"vpc_cidr":             "10.0.0.0/16",
+					"environment":          "dev",
+					"name_suffix":          nameSuffix,
+					"enable_nat_gateway":   tc.enableNATGateway,
+					"enable_vpc_endpoints": false,
+				},
+				EnvVars: map[string]string{
+					"AWS_DEFAULT_REGION": awsRegion,
+				},
+				NoColor: true,
+			})
+
+			defer terraform.Destroy(t, terraformOptions)
+			terraform.InitAndApply(t, terraformOptions)
+
+			privateRouteTableIDs := terraform.OutputList(t, terraformOptions, "private_route_table_ids")
+			require.Len(t, privateRouteTableIDs, 3, "Expected 3 private route tables")
+
+			ec2Client := aws.NewEc2Client(t, awsRegion)
+			result, err := ec2Client.DescribeRouteTables(&ec2.DescribeRouteTablesInput{
+				RouteTableIds: awssdk.StringSlice(privateRouteTableIDs),
+			})
+			require.NoError(t, err)
+			require.Len(t, result.RouteTables, 3)
+
+			for _, routeTable := range result.RouteTables {
+				var defaultRoutes []*ec2.Route
+				for _, route := range routeTable.Routes {
+					if awssdk.StringValue(route.DestinationCidrBlock) == "0.0.0.0/0" {
+						defaultRoutes = append(defaultRoutes, route)
+					}
+				}
+
+				routeTableID := awssdk.StringValue(routeTable.RouteTableId)
+				if !tc.enableNATGateway {
+					assert.Empty(t, defaultRoutes, "Private route table %s should have no default route when NAT is disabled", routeTableID)
+					continue
+				}
+
+				require.Len(t, defaultRoutes, 1, "Private route table %s should have exactly one default route", routeTableID)
+				assert.NotEmpty(t, awssdk.StringValue(defaultRoutes[0].NatGatewayId), "Default route in %s should target a NAT gateway", routeTableID)
+				assert.Empty(t, awssdk.StringValue(defaultRoutes[0].GatewayId), "Default route in %s must not target an internet gateway", routeTableID)
+			}
+		})
+	}
+}
+
 // TestVPCEndpointsEnabled verifies VPC endpoints are created when enabled
 func TestVPCEndpointsEnabled(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/vpc",
@@ -242,13 +426,80 @@ func TestVPCEndpointsEnabled(t *testing.T) {
 	assert.NotEmpty(t, bedrockEndpointID)
 }
 
+// TestVPCEndpointsList verifies the vpc_endpoints output lists the S3 gateway and the interface endpoints with their private DNS flags
+func TestVPCEndpointsList(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/vpc",
+		Vars: map[string]interface{}{
+			"vpc_cidr":             "10.0.0.0/16",
+			"environment":          "dev",
+			"name_suffix":          nameSuffix,
+			"enable_nat_gateway":   false,
+			"enable_vpc_endpoints": true,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	var endpoints []struct {
+		Name              string `json:"name"`
+		ID                string `json:"id"`
+		ServiceName       string `json:"service_name"`
+		Type              string `json:"type"`
+		PrivateDNSEnabled bool   `json:"private_dns_enabled"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(terraform.OutputJson(t, terraformOptions, "vpc_endpoints")), &endpoints))
+
+	expected := map[string]struct {
+		endpointType string
+		privateDNS   bool
+		idOutput     string
+	}{
+		"s3":      {endpointType: "gateway", privateDNS: false, idOutput: "vpc_endpoint_s3_id"},
+		"rds":     {endpointType: "interface", privateDNS: true, idOutput: "vpc_endpoint_rds_id"},
+		"bedrock": {endpointType: "interface", privateDNS: true, idOutput: "vpc_endpoint_bedrock_id"},
+	}
+	require.Len(t, endpoints, len(expected), "Expected one entry per enabled endpoint")
+
+	serviceNames := terraform.OutputMap(t, terraformOptions, "endpoint_service_names")
+	ec2Client := aws.NewEc2Client(t, awsRegion)
+
+	for _, endpoint := range endpoints {
+		want, ok := expected[endpoint.Name]
+		require.True(t, ok, "Unexpected endpoint %s", endpoint.Name)
+
+		assert.Equal(t, want.endpointType, endpoint.Type, "%s endpoint type", endpoint.Name)
+		assert.Equal(t, want.privateDNS, endpoint.PrivateDNSEnabled, "%s private DNS flag", endpoint.Name)
+		assert.Equal(t, serviceNames[endpoint.Name], endpoint.ServiceName, "%s service name", endpoint.Name)
+		assert.Equal(t, terraform.Output(t, terraformOptions, want.idOutput), endpoint.ID, "%s ID should match its dedicated output", endpoint.Name)
+
+		// The list must reflect what AWS reports, not just the configuration
+		result, err := ec2Client.DescribeVpcEndpoints(&ec2.DescribeVpcEndpointsInput{
+			VpcEndpointIds: awssdk.StringSlice([]string{endpoint.ID}),
+		})
+		require.NoError(t, err)
+		require.Len(t, result.VpcEndpoints, 1)
+		assert.Equal(t, want.endpointType, strings.ToLower(awssdk.StringValue(result.VpcEndpoints[0].VpcEndpointType)))
+		assert.Equal(t, want.privateDNS, awssdk.BoolValue(result.VpcEndpoints[0].PrivateDnsEnabled))
+	}
+}
+
 // TestVPCEndpointsDisabled verifies VPC endpoints are not created when disabled
 func TestVPCEndpointsDisabled(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
 	environment := "dev"
-	nameSuffix := strings.ToLower(fmt.Sprintf("test-%s", uniqueID))
+	nameSuffix := helpers.UniqueNameSuffix(t)
 
 	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
 		TerraformDir: "../../modules/vpc",
@@ -276,3 +527,499 @@ func TestVPCEndpointsDisabled(t *testing.T) {
 	bedrockEndpointID := terraform.Output(t, terraformOptions, "vpc_endpoint_bedrock_id")
 	assert.Empty(t, bedrockEndpointID)
 }
+
+// TestVPCInterfaceEndpointsPrivateDNS verifies the RDS and Bedrock interface endpoints have private DNS enabled
+func TestVPCInterfaceEndpointsPrivateDNS(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/vpc",
+		Vars: map[string]interface{}{
+			"vpc_cidr":             "10.0.0.0/16",
+			"environment":          "dev",
+			"name_suffix":          nameSuffix,
+			"enable_nat_gateway":   false,
+			"enable_vpc_endpoints": true,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	interfaceEndpointIDs := []string{
+		terraform.Output(t, terraformOptions, "vpc_endpoint_rds_id"),
+		terraform.Output(t, terraformOptions, "vpc_endpoint_bedrock_id"),
+	}
+
+	ec2Client := aws.NewEc2Client(t, awsRegion)
+	result, err := ec2Client.DescribeVpcEndpoints(&ec2.DescribeVpcEndpointsInput{
+		VpcEndpointIds: awssdk.StringSlice(interfaceEndpointIDs),
+	})
+	require.NoError(t, err)
+	require.Len(t, result.VpcEndpoints, len(interfaceEndpointIDs))
+
+	// Without private DNS the app resolves public service endpoints and bypasses the VPC path
+	for _, endpoint := range result.VpcEndpoints {
+		serviceName := awssdk.StringValue(endpoint.ServiceName)
+		assert.Equal(t, "Interface", awssdk.StringValue(endpoint.VpcEndpointType), "%s should be an interface endpoint", serviceName)
+		assert.True(t, awssdk.BoolValue(endpoint.PrivateDnsEnabled), "%s should have private DNS enabled", serviceName)
+	}
+}
+
+// TestVPCEndpointSubnetTier verifies interface endpoints land in the dedicated endpoint subnets when endpoint_subnet_newbits is set
+func TestVPCEndpointSubnetTier(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/vpc",
+		Vars: map[string]interface{}{
+			"vpc_cidr":                "10.0.0.0/16",
+			"environment":             "dev",
+			"name_suffix":             nameSuffix,
+			"enable_nat_gateway":      false,
+			"enable_vpc_endpoints":    true,
+			"endpoint_subnet_newbits": 10,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	endpointSubnetIDs := terraform.OutputList(t, terraformOptions, "endpoint_subnet_ids")
+	require.Len(t, endpointSubnetIDs, 3, "One endpoint subnet should be created per AZ")
+	privateSubnetIDs := terraform.OutputList(t, terraformOptions, "private_subnet_ids")
+
+	ec2Client := aws.NewEc2Client(t, awsRegion)
+	subnets, err := ec2Client.DescribeSubnets(&ec2.DescribeSubnetsInput{
+		SubnetIds: awssdk.StringSlice(endpointSubnetIDs),
+	})
+	require.NoError(t, err)
+	cidrs := []string{}
+	for _, subnet := range subnets.Subnets {
+		cidrs = append(cidrs, awssdk.StringValue(subnet.CidrBlock))
+	}
+	assert.ElementsMatch(t, []string{"10.0.30.0/26", "10.0.30.64/26", "10.0.30.128/26"}, cidrs, "Endpoint subnets should be /26 blocks carved from 10.0.30.0/24")
+
+	interfaceEndpointIDs := []string{
+		terraform.Output(t, terraformOptions, "vpc_endpoint_rds_id"),
+		terraform.Output(t, terraformOptions, "vpc_endpoint_bedrock_id"),
+	}
+	result, err := ec2Client.DescribeVpcEndpoints(&ec2.DescribeVpcEndpointsInput{
+		VpcEndpointIds: awssdk.StringSlice(interfaceEndpointIDs),
+	})
+	require.NoError(t, err)
+	require.Len(t, result.VpcEndpoints, len(interfaceEndpointIDs))
+
+	for _, endpoint := range result.VpcEndpoints {
+		serviceName := awssdk.StringValue(endpoint.ServiceName)
+		subnetIDs := awssdk.StringValueSlice(endpoint.SubnetIds)
+		assert.ElementsMatch(t, endpointSubnetIDs, subnetIDs, "%s should be placed in the dedicated endpoint subnets", serviceName)
+		for _, privateSubnetID := range privateSubnetIDs {
+			assert.NotContains(t, subnetIDs, privateSubnetID, "%s should not use private subnet addresses", serviceName)
+		}
+	}
+}
+
+// TestVPCNATWithoutEndpoints verifies NAT-only egress deploys cleanly with no endpoint security group in use
+func TestVPCNATWithoutEndpoints(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	vpcOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/vpc",
+		Vars: map[string]interface{}{
+			"vpc_cidr":             "10.0.0.0/16",
+			"environment":          "dev",
+			"name_suffix":          nameSuffix,
+			"enable_nat_gateway":   true,
+			"enable_vpc_endpoints": false,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, vpcOptions)
+	terraform.InitAndApply(t, vpcOptions)
+
+	vpcID := terraform.Output(t, vpcOptions, "vpc_id")
+	assert.Len(t, terraform.OutputList(t, vpcOptions, "nat_gateway_ids"), 3, "NAT gateways should be created without endpoints")
+	assert.Empty(t, terraform.Output(t, vpcOptions, "vpc_endpoint_rds_id"))
+	assert.Empty(t, terraform.Output(t, vpcOptions, "vpc_endpoint_bedrock_id"))
+
+	// The VPC module's interface endpoint security group is only created alongside the endpoints
+	ec2Client := aws.NewEc2Client(t, awsRegion)
+	vpcEndpointGroups, err := ec2Client.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{
+		Filters: []*ec2.Filter{
+			{Name: awssdk.String("vpc-id"), Values: awssdk.StringSlice([]string{vpcID})},
+			{Name: awssdk.String("group-name"), Values: awssdk.StringSlice([]string{"hipaa-vpc-endpoints-sg-*"})},
+		},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, vpcEndpointGroups.SecurityGroups, "Endpoint security group should not be created when endpoints are disabled")
+
+	// The networking module always creates its endpoint group; it must plan and apply cleanly and stay unattached
+	networkingOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/networking",
+		Vars: map[string]interface{}{
+			"environment":       "dev",
+			"name_suffix":       nameSuffix,
+			"vpc_id":            vpcID,
+			"railway_ip_ranges": []string{"192.0.2.0/24"},
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, networkingOptions)
+	terraform.InitAndApply(t, networkingOptions)
+
+	endpointGroupID := terraform.Output(t, networkingOptions, "vpc_endpoint_security_group_id")
+	interfaces, err := ec2Client.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{
+		Filters: []*ec2.Filter{
+			{Name: awssdk.String("group-id"), Values: awssdk.StringSlice([]string{endpointGroupID})},
+		},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, interfaces.NetworkInterfaces, "Endpoint security group should be unused when endpoints are disabled")
+}
+
+// TestVPCEndpointServiceNamesByPartition verifies endpoint service names follow the partition's DNS suffix
+func TestVPCEndpointServiceNamesByPartition(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		dnsSuffix       string
+		interfacePrefix string
+	}{
+		{dnsSuffix: "amazonaws.com", interfacePrefix: "com.amazonaws"},
+		{dnsSuffix: "amazonaws.com.cn", interfacePrefix: "cn.com.amazonaws"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.dnsSuffix, func(t *testing.T) {
+			t.Parallel()
+
+			awsRegion := "us-east-1"
+			terraformOptions := &terraform.Options{
+				TerraformDir: "../../modules/vpc",
+				Vars: map[string]interface{}{
+					"environment":          "dev",
+					"enable_vpc_endpoints": true,
+					"dns_suffix":           tc.dnsSuffix,
+				},
+				EnvVars: map[string]string{
+					"AWS_DEFAULT_REGION": awsRegion,
+				},
+				PlanFilePath: filepath.Join(t.TempDir(), "partition.tfplan"),
+				NoColor:      true,
+			}
+
+			plan := terraform.InitAndPlanAndShowWithStruct(t, terraformOptions)
+
+			outputChange, ok := plan.RawPlan.OutputChanges["endpoint_service_names"]
+			require.True(t, ok, "Plan should include endpoint_service_names")
+			serviceNames, ok := outputChange.After.(map[string]interface{})
+			require.True(t, ok, "endpoint_service_names should be known at plan time")
+
+			// The S3 gateway endpoint keeps com.amazonaws in every partition
+			assert.Equal(t, fmt.Sprintf("com.amazonaws.%s.s3", awsRegion), serviceNames["s3"])
+			assert.Equal(t, fmt.Sprintf("%s.%s.rds", tc.interfacePrefix, awsRegion), serviceNames["rds"])
+			assert.Equal(t, fmt.Sprintf("%s.%s.bedrock-runtime", tc.interfacePrefix, awsRegion), serviceNames["bedrock"])
+
+			rdsEndpoint, ok := plan.ResourcePlannedValuesMap["aws_vpc_endpoint.rds[0]"]
+			require.True(t, ok, "Plan should include the RDS interface endpoint")
+			assert.Equal(t, serviceNames["rds"], rdsEndpoint.AttributeValues["service_name"], "Endpoint should use the computed service name")
+		})
+	}
+}
+
+// TestDefaultVPCPresentOutput verifies default_vpc_present matches whether the region has a default VPC
+func TestDefaultVPCPresentOutput(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+
+	defaultVpcs, err := aws.GetVpcsE(t, []*ec2.Filter{
+		{Name: awssdk.String("isDefault"), Values: []*string{awssdk.String("true")}},
+	}, awsRegion)
+	require.NoError(t, err)
+	expectedPresent := len(defaultVpcs) > 0
+
+	testCases := []struct {
+		name     string
+		detect   bool
+		expected bool
+	}{
+		{name: "detection enabled", detect: true, expected: expectedPresent},
+		{name: "detection disabled", detect: false, expected: false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			terraformOptions := &terraform.Options{
+				TerraformDir: "../../modules/vpc",
+				Vars: map[string]interface{}{
+					"environment":        "dev",
+					"detect_default_vpc": tc.detect,
+				},
+				EnvVars: map[string]string{
+					"AWS_DEFAULT_REGION": awsRegion,
+				},
+				PlanFilePath: filepath.Join(t.TempDir(), "default-vpc.tfplan"),
+				NoColor:      true,
+			}
+
+			plan := terraform.InitAndPlanAndShowWithStruct(t, terraformOptions)
+
+			outputChange, ok := plan.RawPlan.OutputChanges["default_vpc_present"]
+			require.True(t, ok, "Plan should include default_vpc_present")
+			present, ok := outputChange.After.(bool)
+			require.True(t, ok, "default_vpc_present should be known at plan time")
+			assert.Equal(t, tc.expected, present)
+		})
+	}
+}
+
+// TestNetworkFirewallAllowedDomains verifies the egress firewall rule group allow-lists exactly the configured AWS service domains
+func TestNetworkFirewallAllowedDomains(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	allowedDomains := []string{
+		"textract.us-east-1.amazonaws.com",
+		".comprehendmedical.us-east-1.amazonaws.com",
+	}
+
+	t.Run("requires NAT gateway", func(t *testing.T) {
+		terraformOptions := &terraform.Options{
+			TerraformDir: "../../modules/vpc",
+			Vars: map[string]interface{}{
+				"environment":                 "dev",
+				"enable_nat_gateway":          false,
+				"enable_vpc_endpoints":        false,
+				"enable_network_firewall":     true,
+				"allowed_aws_service_domains": allowedDomains,
+			},
+			EnvVars: map[string]string{
+				"AWS_DEFAULT_REGION": awsRegion,
+			},
+			NoColor: true,
+		}
+
+		_, err := terraform.InitAndPlanE(t, terraformOptions)
+		require.Error(t, err, "The firewall must not be planned without NAT gateways")
+		assert.Contains(t, err.Error(), "requires enable_nat_gateway")
+	})
+
+	nameSuffix := helpers.UniqueNameSuffix(t)
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/vpc",
+		Vars: map[string]interface{}{
+			"vpc_cidr":                    "10.0.0.0/16",
+			"environment":                 "dev",
+			"name_suffix":                 nameSuffix,
+			"enable_nat_gateway":          true,
+			"enable_vpc_endpoints":        false,
+			"enable_network_firewall":     true,
+			"allowed_aws_service_domains": allowedDomains,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	ruleGroupARN := terraform.Output(t, terraformOptions, "network_firewall_rule_group_arn")
+	require.NotEmpty(t, ruleGroupARN)
+
+	ruleGroup := helpers.GetNetworkFirewallRuleGroup(t, awsRegion, ruleGroupARN)
+	require.NotNil(t, ruleGroup.RulesSource)
+	rulesList := ruleGroup.RulesSource.RulesSourceList
+	require.NotNil(t, rulesList, "Rule group should use a domain list")
+
+	assert.Equal(t, "ALLOWLIST", awssdk.StringValue(rulesList.GeneratedRulesType))
+	assert.ElementsMatch(t, []string{"TLS_SNI", "HTTP_HOST"}, awssdk.StringValueSlice(rulesList.TargetTypes))
+	assert.ElementsMatch(t, allowedDomains, awssdk.StringValueSlice(rulesList.Targets), "Rule group should contain exactly the configured domains")
+
+	// Private default routes must go to the firewall, not straight to NAT
+	ec2Client := aws.NewEc2Client(t, awsRegion)
+	result, err := ec2Client.DescribeRouteTables(&ec2.DescribeRouteTablesInput{
+		RouteTableIds: awssdk.StringSlice(terraform.OutputList(t, terraformOptions, "private_route_table_ids")),
+	})
+	require.NoError(t, err)
+	for _, routeTable := range result.RouteTables {
+		for _, route := range routeTable.Routes {
+			if awssdk.StringValue(route.DestinationCidrBlock) != "0.0.0.0/0" {
+				continue
+			}
+			routeTableID := awssdk.StringValue(routeTable.RouteTableId)
+			assert.Empty(t, awssdk.StringValue(route.NatGatewayId), "Default route in %s should not bypass the firewall", routeTableID)
+			assert.Contains(t, awssdk.StringValue(route.GatewayId), "vpce-", "Default route in %s should target a firewall endpoint", routeTableID)
+		}
+	}
+}
+
+// TestVPCSingleAZMode verifies single_az_mode collapses NAT and interface endpoints to one AZ and is rejected in production
+func TestVPCSingleAZMode(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+
+	t.Run("dev collapses to one AZ", func(t *testing.T) {
+		t.Parallel()
+
+		terraformOptions := &terraform.Options{
+			TerraformDir: "../../modules/vpc",
+			Vars: map[string]interface{}{
+				"environment":          "dev",
+				"single_az_mode":       true,
+				"enable_nat_gateway":   true,
+				"enable_vpc_endpoints": true,
+			},
+			EnvVars: map[string]string{
+				"AWS_DEFAULT_REGION": awsRegion,
+			},
+			PlanFilePath: filepath.Join(t.TempDir(), "single-az.tfplan"),
+			NoColor:      true,
+		}
+
+		plan := terraform.InitAndPlanAndShowWithStruct(t, terraformOptions)
+
+		outputChange, ok := plan.RawPlan.OutputChanges["active_availability_zones"]
+		require.True(t, ok, "Plan should include active_availability_zones")
+		assert.Equal(t, []interface{}{fmt.Sprintf("%sa", awsRegion)}, outputChange.After)
+
+		_, ok = plan.ResourcePlannedValuesMap["aws_nat_gateway.main[0]"]
+		assert.True(t, ok, "The first AZ should keep its NAT gateway")
+		for i := 1; i < 3; i++ {
+			_, ok = plan.ResourcePlannedValuesMap[fmt.Sprintf("aws_nat_gateway.main[%d]", i)]
+			assert.False(t, ok, "No NAT gateway should be planned in AZ %d", i)
+		}
+
+		// Subnets stay in every AZ so the RDS subnet group keeps its two-AZ minimum,
+		// and every private route table still gets a default route through the one NAT
+		for i := 0; i < 3; i++ {
+			_, ok = plan.ResourcePlannedValuesMap[fmt.Sprintf("aws_subnet.private[%d]", i)]
+			assert.True(t, ok, "Private subnet %d should still be planned", i)
+			_, ok = plan.ResourcePlannedValuesMap[fmt.Sprintf("aws_route.private_nat[%d]", i)]
+			assert.True(t, ok, "Private route table %d should keep a default route", i)
+		}
+
+		for _, address := range []string{"aws_vpc_endpoint.rds[0]", "aws_vpc_endpoint.bedrock[0]"} {
+			endpoint, ok := plan.ResourcePlannedValuesMap[address]
+			require.True(t, ok, "Plan should include %s", address)
+			if subnetIDs, known := endpoint.AttributeValues["subnet_ids"].([]interface{}); known {
+				assert.Len(t, subnetIDs, 1, "%s should place a single ENI", address)
+			}
+		}
+	})
+
+	t.Run("rejected in production", func(t *testing.T) {
+		t.Parallel()
+
+		terraformOptions := &terraform.Options{
+			TerraformDir: "../../modules/vpc",
+			Vars: map[string]interface{}{
+				"environment":          "production",
+				"single_az_mode":       true,
+				"enable_nat_gateway":   true,
+				"enable_vpc_endpoints": false,
+			},
+			EnvVars: map[string]string{
+				"AWS_DEFAULT_REGION": awsRegion,
+			},
+			NoColor: true,
+		}
+
+		_, err := terraform.InitAndPlanE(t, terraformOptions)
+		require.Error(t, err, "single_az_mode must not plan in production")
+		assert.Contains(t, err.Error(), "single_az_mode is for cost-sensitive non-production environments")
+	})
+}
+
+// TestVPCNatGatewayMode verifies nat_gateway_mode none leaves private route tables without a default route while endpoints stay up
+func TestVPCNatGatewayMode(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+
+	testCases := []struct {
+		mode           string
+		natGateways    int
+		internetEgress bool
+	}{
+		{mode: "none", natGateways: 0, internetEgress: false},
+		{mode: "single", natGateways: 1, internetEgress: true},
+		{mode: "per_az", natGateways: 3, internetEgress: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.mode, func(t *testing.T) {
+			t.Parallel()
+
+			terraformOptions := &terraform.Options{
+				TerraformDir: "../../modules/vpc",
+				Vars: map[string]interface{}{
+					"environment":          "dev",
+					"nat_gateway_mode":     tc.mode,
+					"enable_nat_gateway":   true,
+					"enable_vpc_endpoints": true,
+				},
+				EnvVars: map[string]string{
+					"AWS_DEFAULT_REGION": awsRegion,
+				},
+				PlanFilePath: filepath.Join(t.TempDir(), fmt.Sprintf("nat-%s.tfplan", tc.mode)),
+				NoColor:      true,
+			}
+
+			plan := terraform.InitAndPlanAndShowWithStruct(t, terraformOptions)
+
+			outputChange, ok := plan.RawPlan.OutputChanges["private_subnets_have_internet_egress"]
+			require.True(t, ok, "Plan should include private_subnets_have_internet_egress")
+			assert.Equal(t, tc.internetEgress, outputChange.After)
+
+			for i := 0; i < 3; i++ {
+				_, ok = plan.ResourcePlannedValuesMap[fmt.Sprintf("aws_nat_gateway.main[%d]", i)]
+				assert.Equal(t, i < tc.natGateways, ok, "NAT gateway %d planned in mode %s", i, tc.mode)
+				_, ok = plan.ResourcePlannedValuesMap[fmt.Sprintf("aws_route.private_nat[%d]", i)]
+				assert.Equal(t, tc.internetEgress, ok, "Private route table %d default route in mode %s", i, tc.mode)
+			}
+
+			// nat_gateway_mode takes precedence over enable_nat_gateway = true, and the
+			// endpoints remain the private subnets' path to AWS APIs
+			for _, address := range []string{"aws_vpc_endpoint.s3[0]", "aws_vpc_endpoint.rds[0]", "aws_vpc_endpoint.bedrock[0]"} {
+				_, ok = plan.ResourcePlannedValuesMap[address]
+				assert.True(t, ok, "Plan should include %s in mode %s", address, tc.mode)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package test
+
+import (
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hipaa-compliant-stack/terraform/tests/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWAFRailwayAllowList verifies the IP set holds the Railway ranges and the web ACL blocks everything else
+func TestWAFRailwayAllowList(t *testing.T) {
+	t.Parallel()
+
+	awsRegion := "us-east-1"
+	nameSuffix := helpers.UniqueNameSuffix(t)
+	railwayIPRanges := []string{"192.0.2.0/24", "198.51.100.0/24"}
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../../modules/waf",
+		Vars: map[string]interface{}{
+			"environment":       "dev",
+			"name_suffix":       nameSuffix,
+			"railway_ip_ranges": railwayIPRanges,
+		},
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+		NoColor: true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	ipSetARN := terraform.Output(t, terraformOptions, "waf_ip_set_arn")
+	require.NotEmpty(t, ipSetARN)
+
+	ipSet := helpers.GetWAFIPSet(t, awsRegion, ipSetARN)
+	assert.ElementsMatch(t, railwayIPRanges, awssdk.StringValueSlice(ipSet.Addresses), "IP set should contain exactly the Railway ranges")
+
+	webACL := helpers.GetWAFWebACL(t, awsRegion, terraform.Output(t, terraformOptions, "web_acl_arn"))
+	require.NotNil(t, webACL.DefaultAction)
+	assert.NotNil(t, webACL.DefaultAction.Block, "Web ACL should block requests by default")
+	assert.Nil(t, webACL.DefaultAction.Allow, "Web ACL should not allow requests by default")
+
+	// The only allow path is the rule referencing the Railway IP set
+	require.Len(t, webACL.Rules, 1)
+	rule := webACL.Rules[0]
+	require.NotNil(t, rule.Action)
+	assert.NotNil(t, rule.Action.Allow, "Railway rule should allow matching requests")
+	require.NotNil(t, rule.Statement.IPSetReferenceStatement, "Railway rule should reference the IP set")
+	assert.Equal(t, ipSetARN, awssdk.StringValue(rule.Statement.IPSetReferenceStatement.ARN))
+}
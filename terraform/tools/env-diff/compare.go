@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Finding is one control whose value differs between the two environments
+type Finding struct {
+	Category string
+	Control  string
+	Lower    string
+	Upper    string
+	// Weaker is set when the upper environment is less secure than the lower one
+	Weaker bool
+}
+
+// Compare returns every control that differs between lower and upper, ordered by category and control
+func Compare(lower Snapshot, upper Snapshot) []Finding {
+	var findings []Finding
+	add := func(finding Finding) {
+		if finding.Lower != finding.Upper {
+			findings = append(findings, finding)
+		}
+	}
+
+	for _, role := range roles(lower.Encryption.BucketSSEKMS, upper.Encryption.BucketSSEKMS) {
+		add(boolControl("encryption", "bucket_sse_kms["+role+"]", lower.Encryption.BucketSSEKMS[role], upper.Encryption.BucketSSEKMS[role], true))
+	}
+	add(boolControl("encryption", "kms_key_rotation", lower.Encryption.KMSKeyRotation, upper.Encryption.KMSKeyRotation, true))
+	add(boolControl("encryption", "rds_storage_encrypted", lower.Encryption.RDSStorageEncrypted, upper.Encryption.RDSStorageEncrypted, true))
+	add(boolControl("encryption", "cloudtrail_kms_encrypted", lower.Encryption.CloudTrailKMSEncrypted, upper.Encryption.CloudTrailKMSEncrypted, true))
+
+	add(setControl("rule_sets", "config_rules", lower.RuleSets.ConfigRules, upper.RuleSets.ConfigRules))
+	add(boolControl("rule_sets", "waf_enabled", lower.RuleSets.WAFEnabled, upper.RuleSets.WAFEnabled, true))
+	add(boolControl("rule_sets", "network_firewall_enabled", lower.RuleSets.NetworkFirewallEnabled, upper.RuleSets.NetworkFirewallEnabled, true))
+
+	add(Finding{
+		Category: "retention",
+		Control:  "rds_backup_retention_days",
+		Lower:    strconv.Itoa(lower.Retention.RDSBackupRetentionDays),
+		Upper:    strconv.Itoa(upper.Retention.RDSBackupRetentionDays),
+		Weaker:   upper.Retention.RDSBackupRetentionDays < lower.Retention.RDSBackupRetentionDays,
+	})
+	add(Finding{
+		Category: "retention",
+		Control:  "cloudtrail_log_retention_days",
+		Lower:    logRetentionLabel(lower.Retention.CloudTrailLogRetentionDays),
+		Upper:    logRetentionLabel(upper.Retention.CloudTrailLogRetentionDays),
+		Weaker:   logRetentionRank(upper.Retention.CloudTrailLogRetentionDays) < logRetentionRank(lower.Retention.CloudTrailLogRetentionDays),
+	})
+	for _, role := range roles(lower.Retention.BucketVersioning, upper.Retention.BucketVersioning) {
+		add(boolControl("retention", "bucket_versioning["+role+"]", lower.Retention.BucketVersioning[role], upper.Retention.BucketVersioning[role], true))
+	}
+
+	add(boolControl("network", "rds_publicly_accessible", lower.Network.RDSPubliclyAccessible, upper.Network.RDSPubliclyAccessible, false))
+	for _, role := range roles(lower.Network.BucketPublicAccessBlocked, upper.Network.BucketPublicAccessBlocked) {
+		add(boolControl("network", "bucket_public_access_blocked["+role+"]", lower.Network.BucketPublicAccessBlocked[role], upper.Network.BucketPublicAccessBlocked[role], true))
+	}
+	add(boolControl("network", "default_vpc_present", lower.Network.DefaultVPCPresent, upper.Network.DefaultVPCPresent, false))
+	// Interface endpoints keep PHI-bearing API calls off NAT egress
+	add(setControl("network", "interface_endpoints", lower.Network.InterfaceEndpoints, upper.Network.InterfaceEndpoints))
+
+	return findings
+}
+
+// boolControl compares a setting that is secure when it equals secureValue
+func boolControl(category string, control string, lower bool, upper bool, secureValue bool) Finding {
+	return Finding{
+		Category: category,
+		Control:  control,
+		Lower:    strconv.FormatBool(lower),
+		Upper:    strconv.FormatBool(upper),
+		Weaker:   lower == secureValue && upper != secureValue,
+	}
+}
+
+// setControl compares a set of enabled controls; upper is weaker when it lacks any member of lower
+func setControl(category string, control string, lower []string, upper []string) Finding {
+	upperMembers := map[string]bool{}
+	for _, member := range upper {
+		upperMembers[member] = true
+	}
+	var missing []string
+	for _, member := range lower {
+		if !upperMembers[member] {
+			missing = append(missing, member)
+		}
+	}
+
+	finding := Finding{
+		Category: category,
+		Control:  control,
+		Lower:    setLabel(lower),
+		Upper:    setLabel(upper),
+		Weaker:   len(missing) > 0,
+	}
+	if finding.Weaker {
+		finding.Upper += fmt.Sprintf(" (missing %s)", strings.Join(missing, ","))
+	}
+	return finding
+}
+
+func setLabel(members []string) string {
+	sorted := append([]string(nil), members...)
+	sort.Strings(sorted)
+	return "[" + strings.Join(sorted, ",") + "]"
+}
+
+// logRetentionRank orders log retention so that never-expiring logs rank
+// highest and logs not delivered to CloudWatch at all rank lowest
+func logRetentionRank(days int) int {
+	switch {
+	case days < 0:
+		return -1
+	case days == 0:
+		return math.MaxInt
+	default:
+		return days
+	}
+}
+
+func logRetentionLabel(days int) string {
+	switch {
+	case days < 0:
+		return "not-delivered"
+	case days == 0:
+		return "never-expire"
+	default:
+		return strconv.Itoa(days)
+	}
+}
+
+// roles returns the sorted union of role keys so a role missing from one side is still compared
+func roles(lower map[string]bool, upper map[string]bool) []string {
+	seen := map[string]bool{}
+	for role := range lower {
+		seen[role] = true
+	}
+	for role := range upper {
+		seen[role] = true
+	}
+	keys := make([]string, 0, len(seen))
+	for role := range seen {
+		keys = append(keys, role)
+	}
+	sort.Strings(keys)
+	return keys
+}
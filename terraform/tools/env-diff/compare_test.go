@@ -0,0 +1,169 @@
+package main
+
+import (
+	"testing"
+)
+
+// baselineSnapshot returns a fully hardened environment that test cases weaken or strengthen
+func baselineSnapshot(environment string) Snapshot {
+	return Snapshot{
+		Environment: environment,
+		Encryption: Encryption{
+			BucketSSEKMS:           map[string]bool{"documents": true, "backups": true, "audit_logs": true},
+			KMSKeyRotation:         true,
+			RDSStorageEncrypted:    true,
+			CloudTrailKMSEncrypted: true,
+		},
+		RuleSets: RuleSets{
+			ConfigRules:            []string{"cloudtrail_enabled", "rds_storage_encrypted", "s3_bucket_ssl_requests_only"},
+			WAFEnabled:             true,
+			NetworkFirewallEnabled: false,
+		},
+		Retention: Retention{
+			RDSBackupRetentionDays:     7,
+			CloudTrailLogRetentionDays: 365,
+			BucketVersioning:           map[string]bool{"documents": true, "backups": true, "audit_logs": true},
+		},
+		Network: Network{
+			RDSPubliclyAccessible:     false,
+			BucketPublicAccessBlocked: map[string]bool{"documents": true, "backups": true, "audit_logs": true},
+			DefaultVPCPresent:         false,
+			InterfaceEndpoints:        []string{"bedrock", "rds"},
+		},
+	}
+}
+
+// TestCompare verifies which differences count as the upper environment being weaker
+func TestCompare(t *testing.T) {
+	testCases := []struct {
+		name          string
+		mutate        func(upper *Snapshot)
+		expectControl string
+		expectWeaker  bool
+	}{
+		{
+			name:          "unencrypted documents bucket",
+			mutate:        func(upper *Snapshot) { upper.Encryption.BucketSSEKMS["documents"] = false },
+			expectControl: "bucket_sse_kms[documents]",
+			expectWeaker:  true,
+		},
+		{
+			name:          "key rotation off",
+			mutate:        func(upper *Snapshot) { upper.Encryption.KMSKeyRotation = false },
+			expectControl: "kms_key_rotation",
+			expectWeaker:  true,
+		},
+		{
+			name: "missing config rule",
+			mutate: func(upper *Snapshot) {
+				upper.RuleSets.ConfigRules = []string{"cloudtrail_enabled", "rds_storage_encrypted"}
+			},
+			expectControl: "config_rules",
+			expectWeaker:  true,
+		},
+		{
+			name: "extra config rule",
+			mutate: func(upper *Snapshot) {
+				upper.RuleSets.ConfigRules = append(upper.RuleSets.ConfigRules, "rds_multi_az_support")
+			},
+			expectControl: "config_rules",
+			expectWeaker:  false,
+		},
+		{
+			name:          "firewall only in upper",
+			mutate:        func(upper *Snapshot) { upper.RuleSets.NetworkFirewallEnabled = true },
+			expectControl: "network_firewall_enabled",
+			expectWeaker:  false,
+		},
+		{
+			name:          "shorter backup retention",
+			mutate:        func(upper *Snapshot) { upper.Retention.RDSBackupRetentionDays = 1 },
+			expectControl: "rds_backup_retention_days",
+			expectWeaker:  true,
+		},
+		{
+			name:          "longer backup retention",
+			mutate:        func(upper *Snapshot) { upper.Retention.RDSBackupRetentionDays = 35 },
+			expectControl: "rds_backup_retention_days",
+			expectWeaker:  false,
+		},
+		{
+			name:          "log group never expires",
+			mutate:        func(upper *Snapshot) { upper.Retention.CloudTrailLogRetentionDays = 0 },
+			expectControl: "cloudtrail_log_retention_days",
+			expectWeaker:  false,
+		},
+		{
+			name:          "trail not delivered to CloudWatch",
+			mutate:        func(upper *Snapshot) { upper.Retention.CloudTrailLogRetentionDays = -1 },
+			expectControl: "cloudtrail_log_retention_days",
+			expectWeaker:  true,
+		},
+		{
+			name:          "publicly accessible database",
+			mutate:        func(upper *Snapshot) { upper.Network.RDSPubliclyAccessible = true },
+			expectControl: "rds_publicly_accessible",
+			expectWeaker:  true,
+		},
+		{
+			name:          "bucket missing from upper",
+			mutate:        func(upper *Snapshot) { delete(upper.Network.BucketPublicAccessBlocked, "backups") },
+			expectControl: "bucket_public_access_blocked[backups]",
+			expectWeaker:  true,
+		},
+		{
+			name:          "default VPC left in place",
+			mutate:        func(upper *Snapshot) { upper.Network.DefaultVPCPresent = true },
+			expectControl: "default_vpc_present",
+			expectWeaker:  true,
+		},
+		{
+			name:          "Bedrock over NAT",
+			mutate:        func(upper *Snapshot) { upper.Network.InterfaceEndpoints = []string{"rds"} },
+			expectControl: "interface_endpoints",
+			expectWeaker:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			upper := baselineSnapshot("production")
+			tc.mutate(&upper)
+
+			findings := Compare(baselineSnapshot("dev"), upper)
+			if len(findings) != 1 {
+				t.Fatalf("expected exactly one finding, got %+v", findings)
+			}
+			if findings[0].Control != tc.expectControl {
+				t.Errorf("expected control %s, got %s", tc.expectControl, findings[0].Control)
+			}
+			if findings[0].Weaker != tc.expectWeaker {
+				t.Errorf("expected Weaker=%t for %+v", tc.expectWeaker, findings[0])
+			}
+		})
+	}
+}
+
+// TestCompareIdenticalEnvironments verifies matching postures produce no findings, regardless of list order
+func TestCompareIdenticalEnvironments(t *testing.T) {
+	upper := baselineSnapshot("production")
+	upper.RuleSets.ConfigRules = []string{"s3_bucket_ssl_requests_only", "rds_storage_encrypted", "cloudtrail_enabled"}
+
+	if findings := Compare(baselineSnapshot("dev"), upper); len(findings) != 0 {
+		t.Errorf("expected no findings, got %+v", findings)
+	}
+}
+
+// TestCompareOnlyFlagsRegressions verifies a lower environment that is already weak doesn't make the upper one weaker
+func TestCompareOnlyFlagsRegressions(t *testing.T) {
+	lower := baselineSnapshot("dev")
+	lower.Encryption.KMSKeyRotation = false
+	lower.Network.RDSPubliclyAccessible = true
+
+	for _, finding := range Compare(lower, baselineSnapshot("production")) {
+		if finding.Weaker {
+			t.Errorf("production is stronger than dev on %s but was flagged weaker", finding.Control)
+		}
+	}
+}
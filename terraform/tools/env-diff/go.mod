@@ -0,0 +1,3 @@
+module github.com/hipaa-compliant-stack/terraform/tools/env-diff
+
+go 1.23
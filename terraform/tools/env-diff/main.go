@@ -0,0 +1,112 @@
+// Command env-diff compares the security posture of two deployed environments
+// and exits non-zero when the upper environment (usually production) is
+// weaker than the lower one (usually dev) on any control.
+//
+// Each side is a Terraform workspace of the root stack, read through
+// terraform output and the AWS CLI, or a snapshot file saved with -save:
+//
+//	go run . -dir ../.. -lower dev -upper production
+//	go run . -dir ../.. -lower dev -upper production -save snapshots/
+//	go run . -lower snapshots/dev.json -upper snapshots/production.json
+//
+// Exit status is 0 when upper is at least as strong as lower on every control,
+// 1 when it is weaker on any control, and 2 when a snapshot cannot be read.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr, execRunner))
+}
+
+// run parses args, compares the two environments and returns the exit status
+func run(args []string, stdout io.Writer, stderr io.Writer, runner Runner) int {
+	flags := flag.NewFlagSet("env-diff", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	dir := flags.String("dir", ".", "root Terraform directory of the stack")
+	lowerRef := flags.String("lower", "dev", "lower environment: workspace name or snapshot .json file")
+	upperRef := flags.String("upper", "production", "upper environment: workspace name or snapshot .json file")
+	saveDir := flags.String("save", "", "directory to write the collected snapshots to (optional)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	lower, err := resolve(runner, *dir, *lowerRef)
+	if err != nil {
+		fmt.Fprintf(stderr, "env-diff: %s: %v\n", *lowerRef, err)
+		return 2
+	}
+	upper, err := resolve(runner, *dir, *upperRef)
+	if err != nil {
+		fmt.Fprintf(stderr, "env-diff: %s: %v\n", *upperRef, err)
+		return 2
+	}
+
+	if *saveDir != "" {
+		for _, snapshot := range []Snapshot{lower, upper} {
+			if err := save(*saveDir, snapshot); err != nil {
+				fmt.Fprintf(stderr, "env-diff: %v\n", err)
+				return 2
+			}
+		}
+	}
+
+	findings := Compare(lower, upper)
+	fmt.Fprintf(stdout, "Comparing %s (lower) with %s (upper)\n", lower.Environment, upper.Environment)
+	if len(findings) == 0 {
+		fmt.Fprintln(stdout, "No differences in encryption, rule sets, retention or network exposure")
+		return 0
+	}
+
+	weaker := 0
+	for _, finding := range findings {
+		status := "differs"
+		if finding.Weaker {
+			status = "WEAKER"
+			weaker++
+		}
+		fmt.Fprintf(stdout, "%-8s %-10s %-40s %s=%s %s=%s\n", status, finding.Category, finding.Control,
+			lower.Environment, finding.Lower, upper.Environment, finding.Upper)
+	}
+
+	if weaker > 0 {
+		fmt.Fprintf(stdout, "%s is weaker than %s on %d control(s)\n", upper.Environment, lower.Environment, weaker)
+		return 1
+	}
+	fmt.Fprintf(stdout, "%s is at least as strong as %s on every control\n", upper.Environment, lower.Environment)
+	return 0
+}
+
+// resolve loads a snapshot file, or collects one from the named workspace
+func resolve(runner Runner, dir string, ref string) (Snapshot, error) {
+	if strings.HasSuffix(ref, ".json") {
+		return LoadSnapshot(ref)
+	}
+	snapshot, err := Collect(runner, dir, ref)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	if snapshot.Environment == "" {
+		snapshot.Environment = ref
+	}
+	return snapshot, nil
+}
+
+func save(dir string, snapshot Snapshot) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, snapshot.Environment+".json"), append(data, '\n'), 0644)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// noRunner fails the test if run tries to collect a live snapshot
+func noRunner(t *testing.T) Runner {
+	return func(env []string, name string, args ...string) ([]byte, error) {
+		t.Fatalf("unexpected command %s %s", name, strings.Join(args, " "))
+		return nil, errors.New("unreachable")
+	}
+}
+
+func writeSnapshot(t *testing.T, snapshot Snapshot) string {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), snapshot.Environment+".json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestRunExitStatus verifies the exit status reflects whether production is weaker than dev
+func TestRunExitStatus(t *testing.T) {
+	weakened := baselineSnapshot("production")
+	weakened.Retention.RDSBackupRetentionDays = 1
+
+	strengthened := baselineSnapshot("production")
+	strengthened.Retention.RDSBackupRetentionDays = 35
+
+	testCases := []struct {
+		name         string
+		upper        Snapshot
+		expectStatus int
+		expectOutput string
+	}{
+		{name: "identical", upper: baselineSnapshot("production"), expectStatus: 0, expectOutput: "No differences"},
+		{name: "stronger", upper: strengthened, expectStatus: 0, expectOutput: "at least as strong"},
+		{name: "weaker", upper: weakened, expectStatus: 1, expectOutput: "WEAKER   retention  rds_backup_retention_days"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			status := run([]string{
+				"-lower", writeSnapshot(t, baselineSnapshot("dev")),
+				"-upper", writeSnapshot(t, tc.upper),
+			}, &stdout, &stderr, noRunner(t))
+
+			if status != tc.expectStatus {
+				t.Errorf("expected exit status %d, got %d\n%s%s", tc.expectStatus, status, stdout.String(), stderr.String())
+			}
+			if !strings.Contains(stdout.String(), tc.expectOutput) {
+				t.Errorf("expected output to contain %q, got:\n%s", tc.expectOutput, stdout.String())
+			}
+		})
+	}
+}
+
+// TestRunUnreadableSnapshot verifies a missing snapshot is an error, not a passing comparison
+func TestRunUnreadableSnapshot(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	status := run([]string{
+		"-lower", writeSnapshot(t, baselineSnapshot("dev")),
+		"-upper", filepath.Join(t.TempDir(), "missing.json"),
+	}, &stdout, &stderr, noRunner(t))
+
+	if status != 2 {
+		t.Errorf("expected exit status 2, got %d", status)
+	}
+}
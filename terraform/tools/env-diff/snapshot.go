@@ -0,0 +1,283 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// Snapshot is the security posture of one deployed environment. Values are
+// keyed by role or control, never by generated resource names, so snapshots
+// of different environments compare field by field.
+type Snapshot struct {
+	Environment string     `json:"environment"`
+	Encryption  Encryption `json:"encryption"`
+	RuleSets    RuleSets   `json:"rule_sets"`
+	Retention   Retention  `json:"retention"`
+	Network     Network    `json:"network"`
+}
+
+// Encryption holds at-rest encryption settings
+type Encryption struct {
+	BucketSSEKMS           map[string]bool `json:"bucket_sse_kms"`
+	KMSKeyRotation         bool            `json:"kms_key_rotation"`
+	RDSStorageEncrypted    bool            `json:"rds_storage_encrypted"`
+	CloudTrailKMSEncrypted bool            `json:"cloudtrail_kms_encrypted"`
+}
+
+// RuleSets holds detective and preventive rule coverage
+type RuleSets struct {
+	ConfigRules            []string `json:"config_rules"`
+	WAFEnabled             bool     `json:"waf_enabled"`
+	NetworkFirewallEnabled bool     `json:"network_firewall_enabled"`
+}
+
+// Retention holds how long audit evidence and recoverable data are kept
+type Retention struct {
+	RDSBackupRetentionDays int `json:"rds_backup_retention_days"`
+	// CloudTrailLogRetentionDays is 0 when the log group never expires events
+	// and -1 when the trail does not deliver to CloudWatch Logs
+	CloudTrailLogRetentionDays int             `json:"cloudtrail_log_retention_days"`
+	BucketVersioning           map[string]bool `json:"bucket_versioning"`
+}
+
+// Network holds how reachable the stack's data stores are
+type Network struct {
+	RDSPubliclyAccessible     bool            `json:"rds_publicly_accessible"`
+	BucketPublicAccessBlocked map[string]bool `json:"bucket_public_access_blocked"`
+	DefaultVPCPresent         bool            `json:"default_vpc_present"`
+	InterfaceEndpoints        []string        `json:"interface_endpoints"`
+}
+
+// Runner executes a command with extra environment variables and returns its stdout
+type Runner func(env []string, name string, args ...string) ([]byte, error)
+
+// execRunner runs commands on the local machine, folding stderr into errors
+func execRunner(env []string, name string, args ...string) ([]byte, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Env = append(os.Environ(), env...)
+	output, err := cmd.Output()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return nil, fmt.Errorf("%s %s: %s", name, strings.Join(args, " "), strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return output, err
+}
+
+// LoadSnapshot reads a snapshot previously written with -save
+func LoadSnapshot(path string) (Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return Snapshot{}, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return snapshot, nil
+}
+
+// stackOutputs is the subset of root outputs the snapshot is built from
+type stackOutputs struct {
+	AWSRegion                   string            `json:"aws_region"`
+	Environment                 string            `json:"environment"`
+	S3DocumentsBucket           string            `json:"s3_bucket_documents"`
+	S3BackupsBucket             string            `json:"s3_bucket_backups"`
+	S3AuditLogsBucket           string            `json:"s3_bucket_audit_logs"`
+	KMSMasterKeyID              string            `json:"kms_master_key_id"`
+	RDSARN                      string            `json:"rds_arn"`
+	CloudTrailName              string            `json:"cloudtrail_name"`
+	ConfigRules                 map[string]string `json:"config_rules"`
+	WAFIPSetARN                 string            `json:"waf_ip_set_arn"`
+	NetworkFirewallRuleGroupARN string            `json:"network_firewall_rule_group_arn"`
+	DefaultVPCPresent           bool              `json:"default_vpc_present"`
+	VPCEndpoints                []struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	} `json:"vpc_endpoints"`
+}
+
+// Collect builds a snapshot of the workspace's deployed stack from its root
+// outputs and the live resource settings reported by the AWS CLI
+func Collect(run Runner, dir string, workspace string) (Snapshot, error) {
+	outputs, err := loadOutputs(run, dir, workspace)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	aws := func(target interface{}, args ...string) error {
+		data, err := run(nil, "aws", append(args, "--region", outputs.AWSRegion, "--output", "json")...)
+		if err != nil {
+			return err
+		}
+		// Some calls, like get-bucket-versioning on a never-versioned bucket, print nothing
+		if len(strings.TrimSpace(string(data))) == 0 {
+			return nil
+		}
+		return json.Unmarshal(data, target)
+	}
+
+	snapshot := Snapshot{
+		Environment: outputs.Environment,
+		Encryption:  Encryption{BucketSSEKMS: map[string]bool{}},
+		RuleSets: RuleSets{
+			ConfigRules:            sortedKeys(outputs.ConfigRules),
+			WAFEnabled:             outputs.WAFIPSetARN != "",
+			NetworkFirewallEnabled: outputs.NetworkFirewallRuleGroupARN != "",
+		},
+		Retention: Retention{BucketVersioning: map[string]bool{}},
+		Network: Network{
+			BucketPublicAccessBlocked: map[string]bool{},
+			DefaultVPCPresent:         outputs.DefaultVPCPresent,
+			InterfaceEndpoints:        []string{},
+		},
+	}
+	for _, endpoint := range outputs.VPCEndpoints {
+		if endpoint.Type == "interface" {
+			snapshot.Network.InterfaceEndpoints = append(snapshot.Network.InterfaceEndpoints, endpoint.Name)
+		}
+	}
+	sort.Strings(snapshot.Network.InterfaceEndpoints)
+
+	buckets := map[string]string{
+		"documents":  outputs.S3DocumentsBucket,
+		"backups":    outputs.S3BackupsBucket,
+		"audit_logs": outputs.S3AuditLogsBucket,
+	}
+	for role, bucket := range buckets {
+		var encryption struct {
+			ServerSideEncryptionConfiguration struct {
+				Rules []struct {
+					ApplyServerSideEncryptionByDefault struct {
+						SSEAlgorithm string
+					}
+				}
+			}
+		}
+		if err := aws(&encryption, "s3api", "get-bucket-encryption", "--bucket", bucket); err != nil {
+			return Snapshot{}, err
+		}
+		rules := encryption.ServerSideEncryptionConfiguration.Rules
+		snapshot.Encryption.BucketSSEKMS[role] = len(rules) > 0 && strings.HasPrefix(rules[0].ApplyServerSideEncryptionByDefault.SSEAlgorithm, "aws:kms")
+
+		var versioning struct{ Status string }
+		if err := aws(&versioning, "s3api", "get-bucket-versioning", "--bucket", bucket); err != nil {
+			return Snapshot{}, err
+		}
+		snapshot.Retention.BucketVersioning[role] = versioning.Status == "Enabled"
+
+		var publicAccess struct {
+			PublicAccessBlockConfiguration struct {
+				BlockPublicAcls       bool
+				IgnorePublicAcls      bool
+				BlockPublicPolicy     bool
+				RestrictPublicBuckets bool
+			}
+		}
+		err := aws(&publicAccess, "s3api", "get-public-access-block", "--bucket", bucket)
+		if err != nil && !strings.Contains(err.Error(), "NoSuchPublicAccessBlockConfiguration") {
+			return Snapshot{}, err
+		}
+		block := publicAccess.PublicAccessBlockConfiguration
+		snapshot.Network.BucketPublicAccessBlocked[role] = block.BlockPublicAcls && block.IgnorePublicAcls && block.BlockPublicPolicy && block.RestrictPublicBuckets
+	}
+
+	var rotation struct{ KeyRotationEnabled bool }
+	if err := aws(&rotation, "kms", "get-key-rotation-status", "--key-id", outputs.KMSMasterKeyID); err != nil {
+		return Snapshot{}, err
+	}
+	snapshot.Encryption.KMSKeyRotation = rotation.KeyRotationEnabled
+
+	arnParts := strings.Split(outputs.RDSARN, ":")
+	var databases struct {
+		DBInstances []struct {
+			StorageEncrypted      bool
+			PubliclyAccessible    bool
+			BackupRetentionPeriod int
+		}
+	}
+	if err := aws(&databases, "rds", "describe-db-instances", "--db-instance-identifier", arnParts[len(arnParts)-1]); err != nil {
+		return Snapshot{}, err
+	}
+	if len(databases.DBInstances) == 0 {
+		return Snapshot{}, fmt.Errorf("DB instance %s not found", outputs.RDSARN)
+	}
+	database := databases.DBInstances[0]
+	snapshot.Encryption.RDSStorageEncrypted = database.StorageEncrypted
+	snapshot.Network.RDSPubliclyAccessible = database.PubliclyAccessible
+	snapshot.Retention.RDSBackupRetentionDays = database.BackupRetentionPeriod
+
+	var trail struct {
+		Trail struct {
+			KmsKeyId                  string
+			CloudWatchLogsLogGroupArn string
+		}
+	}
+	if err := aws(&trail, "cloudtrail", "get-trail", "--name", outputs.CloudTrailName); err != nil {
+		return Snapshot{}, err
+	}
+	snapshot.Encryption.CloudTrailKMSEncrypted = trail.Trail.KmsKeyId != ""
+	snapshot.Retention.CloudTrailLogRetentionDays = -1
+	if trail.Trail.CloudWatchLogsLogGroupArn != "" {
+		// arn:aws:logs:region:account:log-group:NAME:*
+		logGroupName := strings.Split(trail.Trail.CloudWatchLogsLogGroupArn, ":")[6]
+		var logGroups struct {
+			LogGroups []struct {
+				LogGroupName    string `json:"logGroupName"`
+				RetentionInDays int    `json:"retentionInDays"`
+			} `json:"logGroups"`
+		}
+		if err := aws(&logGroups, "logs", "describe-log-groups", "--log-group-name-prefix", logGroupName); err != nil {
+			return Snapshot{}, err
+		}
+		for _, group := range logGroups.LogGroups {
+			if group.LogGroupName == logGroupName {
+				snapshot.Retention.CloudTrailLogRetentionDays = group.RetentionInDays
+			}
+		}
+	}
+
+	return snapshot, nil
+}
+
+// loadOutputs reads the root outputs of the given workspace
+func loadOutputs(run Runner, dir string, workspace string) (stackOutputs, error) {
+	data, err := run([]string{"TF_WORKSPACE=" + workspace}, "terraform", "-chdir="+dir, "output", "-json")
+	if err != nil {
+		return stackOutputs{}, err
+	}
+
+	var raw map[string]struct {
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return stackOutputs{}, fmt.Errorf("parse outputs of workspace %s: %w", workspace, err)
+	}
+	if len(raw) == 0 {
+		return stackOutputs{}, fmt.Errorf("workspace %s has no outputs; is it deployed?", workspace)
+	}
+
+	values := map[string]json.RawMessage{}
+	for name, output := range raw {
+		values[name] = output.Value
+	}
+	flattened, err := json.Marshal(values)
+	if err != nil {
+		return stackOutputs{}, err
+	}
+	var outputs stackOutputs
+	if err := json.Unmarshal(flattened, &outputs); err != nil {
+		return stackOutputs{}, fmt.Errorf("parse outputs of workspace %s: %w", workspace, err)
+	}
+	return outputs, nil
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
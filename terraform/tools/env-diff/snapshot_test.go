@@ -0,0 +1,109 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeEnvironment answers terraform output and AWS CLI calls for one deployed environment
+func fakeEnvironment(t *testing.T, responses map[string]string) Runner {
+	return func(env []string, name string, args ...string) ([]byte, error) {
+		key := name + " " + strings.Join(args, " ")
+		// Drop the --region/--output suffix Collect adds to every AWS call
+		key = strings.Split(key, " --region ")[0]
+		response, ok := responses[key]
+		if !ok {
+			t.Fatalf("unexpected command %s", key)
+		}
+		if strings.HasPrefix(response, "error: ") {
+			return nil, errors.New(strings.TrimPrefix(response, "error: "))
+		}
+		return []byte(response), nil
+	}
+}
+
+// TestCollect verifies a snapshot is built from root outputs and live settings, keyed by role
+func TestCollect(t *testing.T) {
+	outputs := `{
+		"aws_region": {"value": "us-east-1"},
+		"environment": {"value": "staging"},
+		"s3_bucket_documents": {"value": "docs"},
+		"s3_bucket_backups": {"value": "backups"},
+		"s3_bucket_audit_logs": {"value": "audit"},
+		"kms_master_key_id": {"value": "key-1"},
+		"rds_arn": {"value": "arn:aws:rds:us-east-1:123456789012:db:staging-hipaa-db"},
+		"cloudtrail_name": {"value": "trail"},
+		"config_rules": {"value": {"s3_encryption": "s3-encryption-staging", "rds_encryption": "rds-encryption-staging"}},
+		"waf_ip_set_arn": {"value": ""},
+		"network_firewall_rule_group_arn": {"value": "arn:aws:network-firewall:us-east-1:123456789012:stateful-rulegroup/egress"},
+		"default_vpc_present": {"value": true},
+		"vpc_endpoints": {"value": [{"name": "s3", "type": "gateway"}, {"name": "rds", "type": "interface"}]}
+	}`
+	kms := `{"ServerSideEncryptionConfiguration": {"Rules": [{"ApplyServerSideEncryptionByDefault": {"SSEAlgorithm": "aws:kms"}}]}}`
+	blocked := `{"PublicAccessBlockConfiguration": {"BlockPublicAcls": true, "IgnorePublicAcls": true, "BlockPublicPolicy": true, "RestrictPublicBuckets": true}}`
+
+	runner := fakeEnvironment(t, map[string]string{
+		"terraform -chdir=stack output -json":                                          outputs,
+		"aws s3api get-bucket-encryption --bucket docs":                                kms,
+		"aws s3api get-bucket-encryption --bucket backups":                             kms,
+		"aws s3api get-bucket-encryption --bucket audit":                               `{"ServerSideEncryptionConfiguration": {"Rules": [{"ApplyServerSideEncryptionByDefault": {"SSEAlgorithm": "AES256"}}]}}`,
+		"aws s3api get-bucket-versioning --bucket docs":                                `{"Status": "Enabled"}`,
+		"aws s3api get-bucket-versioning --bucket backups":                             `{"Status": "Enabled"}`,
+		"aws s3api get-bucket-versioning --bucket audit":                               ``,
+		"aws s3api get-public-access-block --bucket docs":                              blocked,
+		"aws s3api get-public-access-block --bucket backups":                           blocked,
+		"aws s3api get-public-access-block --bucket audit":                             "error: An error occurred (NoSuchPublicAccessBlockConfiguration)",
+		"aws kms get-key-rotation-status --key-id key-1":                               `{"KeyRotationEnabled": true}`,
+		"aws rds describe-db-instances --db-instance-identifier staging-hipaa-db":      `{"DBInstances": [{"StorageEncrypted": true, "PubliclyAccessible": false, "BackupRetentionPeriod": 14}]}`,
+		"aws cloudtrail get-trail --name trail":                                        `{"Trail": {"KmsKeyId": "arn:aws:kms:us-east-1:123456789012:key/key-1", "CloudWatchLogsLogGroupArn": "arn:aws:logs:us-east-1:123456789012:log-group:/aws/cloudtrail/staging:*"}}`,
+		"aws logs describe-log-groups --log-group-name-prefix /aws/cloudtrail/staging": `{"logGroups": [{"logGroupName": "/aws/cloudtrail/staging-old", "retentionInDays": 1}, {"logGroupName": "/aws/cloudtrail/staging", "retentionInDays": 2557}]}`,
+	})
+
+	snapshot, err := Collect(runner, "stack", "staging")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Snapshot{
+		Environment: "staging",
+		Encryption: Encryption{
+			BucketSSEKMS:           map[string]bool{"documents": true, "backups": true, "audit_logs": false},
+			KMSKeyRotation:         true,
+			RDSStorageEncrypted:    true,
+			CloudTrailKMSEncrypted: true,
+		},
+		RuleSets: RuleSets{
+			ConfigRules:            []string{"rds_encryption", "s3_encryption"},
+			WAFEnabled:             false,
+			NetworkFirewallEnabled: true,
+		},
+		Retention: Retention{
+			RDSBackupRetentionDays:     14,
+			CloudTrailLogRetentionDays: 2557,
+			BucketVersioning:           map[string]bool{"documents": true, "backups": true, "audit_logs": false},
+		},
+		Network: Network{
+			RDSPubliclyAccessible:     false,
+			BucketPublicAccessBlocked: map[string]bool{"documents": true, "backups": true, "audit_logs": false},
+			DefaultVPCPresent:         true,
+			InterfaceEndpoints:        []string{"rds"},
+		},
+	}
+	if !reflect.DeepEqual(expected, snapshot) {
+		t.Errorf("unexpected snapshot\nexpected: %+v\nactual:   %+v", expected, snapshot)
+	}
+}
+
+// TestCollectUndeployedWorkspace verifies a workspace without outputs is reported rather than compared as empty
+func TestCollectUndeployedWorkspace(t *testing.T) {
+	runner := fakeEnvironment(t, map[string]string{
+		"terraform -chdir=stack output -json": `{}`,
+	})
+
+	_, err := Collect(runner, "stack", "production")
+	if err == nil || !strings.Contains(err.Error(), "is it deployed?") {
+		t.Errorf("expected an undeployed workspace error, got %v", err)
+	}
+}
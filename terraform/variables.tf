@@ -35,6 +35,56 @@ variable "aws_region" {
   default     = "us-east-1"
 }
 
+variable "aws_account_id" {
+  type        = string
+  description = "Expected target AWS account ID; when set, plans fail if the credentials (or assume_role_arn) resolve to another account"
+  default     = ""
+
+  validation {
+    condition     = var.aws_account_id == "" || can(regex("^[0-9]{12}$", var.aws_account_id))
+    error_message = "aws_account_id must be empty or a 12-digit AWS account ID"
+  }
+}
+
+variable "assume_role_arn" {
+  type        = string
+  description = "IAM role ARN the AWS providers assume so each environment can deploy into its own account (empty uses the caller's credentials)"
+  default     = ""
+
+  validation {
+    condition     = var.assume_role_arn == "" || can(regex("^arn:aws[a-z-]*:iam::[0-9]{12}:role/.+$", var.assume_role_arn))
+    error_message = "assume_role_arn must be empty or an IAM role ARN"
+  }
+}
+
+variable "replica_region" {
+  type        = string
+  description = "Secondary AWS region for multi-region resources (KMS replica key, S3 replication, RDS backup copies). Leave empty to disable."
+  default     = ""
+
+  validation {
+    condition     = var.replica_region == "" || can(regex("^[a-z]{2}(-gov)?-[a-z]+-[0-9]$", var.replica_region))
+    error_message = "replica_region must be empty or a valid AWS region name (e.g., us-west-2)."
+  }
+}
+
+variable "enable_quota_preflight" {
+  type        = bool
+  description = "Fail the plan when the Elastic IPs, VPCs or RDS instances this stack creates would exceed the region's Service Quotas"
+  default     = true
+}
+
+variable "service_quota_overrides" {
+  type        = map(number)
+  description = "Quota values used instead of the Service Quotas lookup, keyed by elastic_ips, vpcs or rds_instances (for accounts without servicequotas:GetServiceQuota, and tests)"
+  default     = {}
+
+  validation {
+    condition     = alltrue([for key in keys(var.service_quota_overrides) : contains(["elastic_ips", "vpcs", "rds_instances"], key)])
+    error_message = "service_quota_overrides keys must be elastic_ips, vpcs or rds_instances"
+  }
+}
+
 # ------------------------------------------------------------------------------
 # VPC Configuration
 # ------------------------------------------------------------------------------
@@ -51,18 +101,47 @@ variable "availability_zones" {
   default     = ["us-east-1a", "us-east-1b", "us-east-1c"]
 }
 
+variable "single_az_mode" {
+  type        = bool
+  description = "Cost-sensitive dev: one NAT gateway, one firewall/interface endpoint zone and a single-AZ database pinned to the first availability zone (overrides rds_multi_az). Fails the plan in production"
+  default     = false
+}
+
 variable "enable_nat_gateway" {
   type        = bool
-  description = "Enable NAT gateway for private subnet internet access"
+  description = "Enable NAT gateway for private subnet internet access (ignored when nat_gateway_mode is set)"
   default     = true
 }
 
+variable "nat_gateway_mode" {
+  type        = string
+  description = "NAT gateways for private subnet egress: per_az, single (one shared gateway) or none (no default route; S3, RDS and Bedrock only through VPC endpoints). Empty follows enable_nat_gateway"
+  default     = ""
+
+  validation {
+    condition     = contains(["", "per_az", "single", "none"], var.nat_gateway_mode)
+    error_message = "nat_gateway_mode must be per_az, single, none or empty."
+  }
+}
+
 variable "enable_vpc_endpoints" {
   type        = bool
   description = "Enable VPC endpoints for S3, RDS, Bedrock"
   default     = true
 }
 
+variable "enable_network_firewall" {
+  type        = bool
+  description = "Send private subnet egress through a Network Firewall that only allows allowed_aws_service_domains (requires a NAT gateway)"
+  default     = false
+}
+
+variable "allowed_aws_service_domains" {
+  type        = list(string)
+  description = "AWS API domains reachable through the network firewall, for services without an interface endpoint in the region"
+  default     = []
+}
+
 # ------------------------------------------------------------------------------
 # Networking Configuration
 # ------------------------------------------------------------------------------
@@ -75,6 +154,36 @@ variable "railway_ip_ranges" {
   # Example: ["52.1.2.3/32", "52.4.5.6/32"]
 }
 
+variable "enable_waf" {
+  type        = bool
+  description = "Create a WAF web ACL that allows only railway_ip_ranges and blocks all other requests"
+  default     = false
+}
+
+variable "waf_protected_resource_arns" {
+  type        = list(string)
+  description = "Regional resource ARNs (ALB, API Gateway stage) protected by the WAF web ACL"
+  default     = []
+}
+
+variable "enable_privatelink" {
+  type        = bool
+  description = "Expose the service to partners through a PrivateLink endpoint service fronted by an internal NLB"
+  default     = false
+}
+
+variable "privatelink_allowed_principal_arns" {
+  type        = list(string)
+  description = "Partner AWS principal ARNs allowed to request connections to the PrivateLink endpoint service"
+  default     = []
+}
+
+variable "privatelink_target_ip_addresses" {
+  type        = list(string)
+  description = "Private IPv4 addresses behind the PrivateLink NLB"
+  default     = []
+}
+
 # ------------------------------------------------------------------------------
 # KMS Configuration
 # ------------------------------------------------------------------------------
@@ -85,6 +194,48 @@ variable "enable_key_rotation" {
   default     = true
 }
 
+variable "enable_ebs_encryption_by_default" {
+  type        = bool
+  description = "Enable account-level EBS encryption by default with the master key, so any EC2 volume in the region is encrypted"
+  default     = false
+}
+
+variable "create_log_key" {
+  type        = bool
+  description = "Encrypt CloudTrail, Config delivery and the CloudTrail log group with a dedicated log key instead of the master data key"
+  default     = false
+}
+
+variable "use_separate_bucket_keys" {
+  type        = bool
+  description = "Encrypt the backups bucket and RDS (storage and automated backups) with a dedicated backup key instead of the documents key; changing it on an existing stack fails the plan unless allow_rds_kms_key_replacement is set, because RDS replaces the instance"
+  default     = false
+}
+
+variable "allow_rds_kms_key_replacement" {
+  type        = bool
+  description = "Acknowledge that changing use_separate_bucket_keys on an existing stack destroys the primary RDS instance and recreates it empty (see the RDS module README for the snapshot migration)"
+  default     = false
+}
+
+variable "kms_key_administrator_arns" {
+  type        = list(string)
+  description = "IAM principals that manage the master key but cannot encrypt or decrypt with it; setting it requires kms_key_user_arns"
+  default     = []
+}
+
+variable "kms_key_user_arns" {
+  type        = list(string)
+  description = "IAM principals that encrypt and decrypt with the master key but cannot manage it; once set, other IAM principals can no longer use the key (include the application and deployment roles)"
+  default     = []
+}
+
+variable "enable_aws_backup" {
+  type        = bool
+  description = "Grant the AWS Backup service principal use of the master key for copying encrypted RDS snapshots"
+  default     = false
+}
+
 # ------------------------------------------------------------------------------
 # S3 Configuration
 # ------------------------------------------------------------------------------
@@ -95,6 +246,72 @@ variable "enable_lifecycle_policies" {
   default     = true
 }
 
+variable "enable_intelligent_tiering" {
+  type        = bool
+  description = "Tier documents with S3 Intelligent-Tiering (including archive tiers) instead of fixed IA/Glacier transitions"
+  default     = false
+}
+
+variable "create_canary_bucket" {
+  type        = bool
+  description = "Deploy a canary S3 bucket that alarms on any object read (logged via CloudTrail data events)"
+  default     = false
+}
+
+variable "create_quarantine_bucket" {
+  type        = bool
+  description = "Deploy a quarantine S3 bucket for objects flagged by Macie/GuardDuty, accessible only to the quarantine role"
+  default     = false
+}
+
+variable "quarantine_role_arn" {
+  type        = string
+  description = "Existing security role granted exclusive quarantine object access (leave empty to create one)"
+  default     = ""
+}
+
+variable "enable_quarantine_automation" {
+  type        = bool
+  description = "Move objects flagged by high-severity Macie/GuardDuty findings into the quarantine bucket and alert SNS (requires create_quarantine_bucket)"
+  default     = false
+}
+
+variable "restrict_bedrock_egress" {
+  type        = bool
+  description = "Only let the app reach Bedrock and other AWS APIs through VPC interface endpoints (no HTTPS egress to the internet)"
+  default     = true
+}
+
+variable "s3_deny_unencrypted_uploads" {
+  type        = bool
+  description = "Reject S3 uploads whose encryption headers select SSE-S3 or a key other than the bucket's authorized KMS keys; uploads without headers use the bucket default key"
+  default     = false
+}
+
+variable "enable_s3_eventbridge_notifications" {
+  type        = bool
+  description = "Send every object create, delete and restore event on the documents and backups buckets to EventBridge for the centralized audit pipeline"
+  default     = false
+}
+
+variable "documents_secondary_kms_key_arn" {
+  type        = string
+  description = "Second KMS key ARN authorized on the documents bucket for staged key migration (optional)"
+  default     = ""
+}
+
+variable "documents_active_kms_key" {
+  type        = string
+  description = "Key used for new documents writes: primary (master key) or secondary (documents_secondary_kms_key_arn)"
+  default     = "primary"
+}
+
+variable "enable_s3_request_metrics" {
+  type        = bool
+  description = "Enable request metrics on the documents bucket with 4xx/5xx alarms notifying the critical alarm topic"
+  default     = false
+}
+
 variable "documents_bucket_name" {
   type        = string
   description = "Override default documents bucket name (leave empty for auto-generated name)"
@@ -122,18 +339,90 @@ variable "rds_allocated_storage" {
   }
 }
 
+variable "expected_connections" {
+  type        = number
+  description = "Expected peak concurrent database connections, used for rds_sizing_recommendation"
+  default     = 50
+}
+
+variable "expected_storage_gb" {
+  type        = number
+  description = "Expected database size in GB, used for rds_sizing_recommendation"
+  default     = 10
+}
+
+variable "rds_tenancy" {
+  type        = string
+  description = "RDS instance tenancy (default or dedicated); dedicated fails the plan because RDS for PostgreSQL does not offer it"
+  default     = "default"
+}
+
 variable "rds_multi_az" {
   type        = bool
   description = "Enable Multi-AZ deployment for RDS (recommended for staging and production)"
   default     = false
 }
 
+variable "rds_master_password_length" {
+  type        = number
+  description = "Length of the generated RDS master password (minimum 16; always includes every character class)"
+  default     = 32
+}
+
+variable "rds_ca_cert_identifier" {
+  type        = string
+  description = "Certificate authority for the RDS server certificates (empty keeps the RDS default); see rds_ca_rotation_needed"
+  default     = ""
+}
+
+variable "rds_engine_type" {
+  type        = string
+  description = "Database engine: postgres (single RDS instance) or aurora-postgresql (Aurora cluster with writer and readers)"
+  default     = "postgres"
+}
+
+variable "aurora_reader_count" {
+  type        = number
+  description = "Aurora reader instances alongside the writer when rds_engine_type is aurora-postgresql"
+  default     = 1
+}
+
 variable "enable_read_replica" {
   type        = bool
   description = "Enable read replica for RDS (production only)"
   default     = false
 }
 
+variable "enable_reporting_replica" {
+  type        = bool
+  description = "Enable a read-only reporting replica in a separate AZ with its own security group"
+  default     = false
+}
+
+variable "reporting_allowed_security_group_ids" {
+  type        = list(string)
+  description = "Security group IDs of reporting/analytics clients allowed to reach the reporting replica"
+  default     = []
+}
+
+variable "enable_rds_proxy" {
+  type        = bool
+  description = "Deploy RDS Proxy for connection pooling in front of the primary instance"
+  default     = false
+}
+
+variable "proxy_require_tls" {
+  type        = bool
+  description = "Require TLS for client connections to RDS Proxy"
+  default     = true
+}
+
+variable "proxy_connection_borrow_timeout" {
+  type        = number
+  description = "Seconds a client waits to borrow a pooled RDS Proxy connection"
+  default     = 120
+}
+
 variable "backup_retention_days" {
   type        = number
   description = "Automated backup retention period in days"
@@ -145,12 +434,126 @@ variable "backup_retention_days" {
   }
 }
 
+variable "snapshot_copy_account_id" {
+  type        = string
+  description = "Isolated backup account ID that receives copies of every RDS snapshot (leave empty to disable)"
+  default     = ""
+}
+
+variable "enable_rds_scheduling" {
+  type        = bool
+  description = "Stop RDS overnight and start it before business hours to save cost (never applied in production)"
+  default     = false
+}
+
+variable "rds_stop_schedule" {
+  type        = string
+  description = "EventBridge schedule (UTC) that stops RDS when enable_rds_scheduling is true"
+  default     = "cron(0 1 ? * * *)"
+}
+
+variable "rds_start_schedule" {
+  type        = string
+  description = "EventBridge schedule (UTC) that starts RDS when enable_rds_scheduling is true"
+  default     = "cron(0 12 ? * MON-FRI *)"
+}
+
+variable "enable_data_masking" {
+  type        = bool
+  description = "Mask PHI in production snapshots restored into dev or staging before the app can reach them (rejected in production)"
+  default     = false
+}
+
+variable "data_masking_rules" {
+  type = list(object({
+    table    = string
+    column   = string
+    strategy = string
+  }))
+  description = "PHI columns anonymized in restored databases: table, column and strategy (redact, null, hash, email, phone or date_year)"
+  default     = []
+}
+
+variable "data_masking_driver_layer_arns" {
+  type        = list(string)
+  description = "Lambda layers providing the pg8000 PostgreSQL driver for the masking function"
+  default     = []
+}
+
 variable "deletion_protection" {
   type        = bool
   description = "Enable deletion protection for RDS (recommended for production)"
   default     = false
 }
 
+variable "allow_destroy" {
+  type        = bool
+  description = "Test teardown escape hatch: relax destroy guards on buckets, RDS and KMS keys (never enable in production)"
+  default     = false
+}
+
+# ------------------------------------------------------------------------------
+# IAM Configuration
+# ------------------------------------------------------------------------------
+
+variable "app_require_secure_transport" {
+  type        = bool
+  description = "Require TLS (aws:SecureTransport) on the app role's S3 and KMS access"
+  default     = true
+}
+
+variable "manage_account_password_policy" {
+  type        = bool
+  description = "Manage the account-wide IAM password policy (enable in only one workspace per AWS account)"
+  default     = false
+}
+
+variable "app_restrict_s3_to_vpc_endpoint" {
+  type        = bool
+  description = "Only allow the app role's S3 requests through the S3 VPC endpoint (requires enable_vpc_endpoints; leave false for apps running outside the VPC)"
+  default     = false
+}
+
+variable "app_enable_presigned_urls" {
+  type        = bool
+  description = "Let the app serve documents through pre-signed GET URLs that are TLS-only and expire within app_presigned_url_max_expiry_seconds"
+  default     = false
+}
+
+variable "app_presigned_url_max_expiry_seconds" {
+  type        = number
+  description = "Longest a pre-signed document URL stays usable; passed to the app as PRESIGNED_URL_MAX_EXPIRY_SECONDS"
+  default     = 300
+}
+
+variable "app_deny_unapproved_kms_keys" {
+  type        = bool
+  description = "Deny the app role encrypting under any KMS key other than the master key (and its replica), including per-tenant keys"
+  default     = false
+}
+
+# ------------------------------------------------------------------------------
+# CloudTrail Configuration
+# ------------------------------------------------------------------------------
+
+variable "enable_cloudtrail_data_events" {
+  type        = bool
+  description = "Record S3 object-level data events for the documents and backups (PHI) buckets"
+  default     = true
+}
+
+variable "enable_cloudtrail_cloudwatch_logs" {
+  type        = bool
+  description = "Also deliver CloudTrail events to a KMS-encrypted CloudWatch Logs group"
+  default     = false
+}
+
+variable "cloudtrail_log_retention_days" {
+  type        = number
+  description = "Retention (days) of the CloudTrail CloudWatch Logs group"
+  default     = 365
+}
+
 # ------------------------------------------------------------------------------
 # AWS Config Configuration
 # ------------------------------------------------------------------------------
@@ -161,10 +564,144 @@ variable "sns_alert_email" {
   default     = ""
 }
 
+variable "config_snapshot_bucket" {
+  type        = string
+  description = "Deliver AWS Config snapshots and history to this compliance bucket instead of the audit logs bucket (its policy must allow Config delivery)"
+  default     = ""
+}
+
+variable "enable_config_auto_remediation" {
+  type        = bool
+  description = "Automatically remediate S3 buckets that lose default encryption or their public access block, and publicly accessible RDS instances"
+  default     = false
+}
+
+variable "enable_config_aggregator" {
+  type        = bool
+  description = "Aggregate AWS Config compliance data from config_aggregator_account_ids into this account"
+  default     = false
+}
+
+variable "config_aggregator_account_ids" {
+  type        = list(string)
+  description = "Source account IDs for the Config aggregator"
+  default     = []
+}
+
+variable "config_aggregator_regions" {
+  type        = list(string)
+  description = "Source regions for the Config aggregator (empty aggregates all regions)"
+  default     = []
+}
+
+variable "config_aggregator_authorized_account_id" {
+  type        = string
+  description = "Central account allowed to aggregate this account's Config data (empty skips authorization)"
+  default     = ""
+}
+
+variable "config_aggregator_authorized_regions" {
+  type        = list(string)
+  description = "Regions of the central Config aggregator to authorize"
+  default     = []
+}
+
+# ------------------------------------------------------------------------------
+# Monitoring Configuration
+# ------------------------------------------------------------------------------
+
+variable "central_alarm_region" {
+  type        = string
+  description = "Region of a central SNS topic that also receives critical alarm notifications (leave empty to disable)"
+  default     = ""
+}
+
+variable "acm_certificate_arns" {
+  type        = list(string)
+  description = "ACM certificates in aws_region (e.g. for the Railway custom domain) that get an expiry alarm"
+  default     = []
+}
+
+variable "cert_expiry_warning_days" {
+  type        = number
+  description = "Days before an ACM certificate or the RDS CA expires at which the expiry alarms notify the alarm topic"
+  default     = 30
+}
+
+variable "enable_dashboard" {
+  type        = bool
+  description = "Create the stack health CloudWatch dashboard (RDS, S3 and GuardDuty widgets)"
+  default     = true
+}
+
+variable "enable_siem_forwarding" {
+  type        = bool
+  description = "Forward Config and GuardDuty findings to an external SIEM through a KMS-encrypted Firehose stream"
+  default     = false
+}
+
+variable "siem_destination" {
+  type        = string
+  description = "SIEM delivery target: s3 (findings written to the audit logs bucket under siem-findings/) or http_endpoint"
+  default     = "s3"
+}
+
+variable "siem_http_endpoint_url" {
+  type        = string
+  description = "HTTPS URL of the SIEM ingestion endpoint (required when siem_destination is http_endpoint)"
+  default     = ""
+}
+
+variable "siem_http_endpoint_access_key" {
+  type        = string
+  description = "Access key or token for the SIEM HTTP endpoint"
+  default     = ""
+  sensitive   = true
+}
+
+variable "enable_xray_tracing" {
+  type        = bool
+  description = "Encrypt X-Ray trace storage with the master KMS key and add an environment sampling rule (one stack per account and region)"
+  default     = false
+}
+
+variable "xray_sampling_rate" {
+  type        = number
+  description = "Fraction of requests X-Ray traces after the per-second reservoir"
+  default     = 0.05
+}
+
+# ------------------------------------------------------------------------------
+# Railway Integration
+# ------------------------------------------------------------------------------
+
+variable "write_env_file" {
+  type        = bool
+  description = "Write a dotenv file of connection settings for Railway (secrets as SSM parameter paths)"
+  default     = false
+}
+
+variable "env_file_path" {
+  type        = string
+  description = "Destination of the dotenv file (defaults to .env.railway.<environment> in the root module)"
+  default     = ""
+}
+
 # ------------------------------------------------------------------------------
 # Common Tags
 # ------------------------------------------------------------------------------
 
+variable "cost_center" {
+  type        = string
+  description = "Cost center applied as the CostCenter tag on all resources for billing attribution (falls back to tags[\"CostCenter\"])"
+  default     = ""
+
+  validation {
+    condition     = can(regex("^[A-Za-z0-9 _.:/=+@-]{0,256}$", var.cost_center))
+    error_message = "cost_center must be a valid AWS tag value (max 256 characters)."
+  }
+}
+
 variable "tags" {
   type        = map(string)
   description = "Additional resource tags to apply to all resources"
@@ -22,6 +22,16 @@ terraform {
 provider "aws" {
   region = var.aws_region
 
+  # Per-environment account isolation: credentials assume a role in the
+  # target account instead of deploying into the caller's own account
+  dynamic "assume_role" {
+    for_each = var.assume_role_arn == "" ? [] : [var.assume_role_arn]
+    content {
+      role_arn     = assume_role.value
+      session_name = "terraform-${var.environment}"
+    }
+  }
+
   # Default tags applied to all AWS resources
   default_tags {
     tags = {
@@ -30,3 +40,57 @@ provider "aws" {
     }
   }
 }
+
+# ------------------------------------------------------------------------------
+# AWS Provider Configuration - Replica Region
+# ------------------------------------------------------------------------------
+# Aliased provider passed to modules that create multi-region resources.
+# Falls back to the primary region when replica_region is unset so the
+# alias is always configured; modules gate replica resources on their flags.
+
+provider "aws" {
+  alias  = "replica"
+  region = coalesce(var.replica_region, var.aws_region)
+
+  dynamic "assume_role" {
+    for_each = var.assume_role_arn == "" ? [] : [var.assume_role_arn]
+    content {
+      role_arn     = assume_role.value
+      session_name = "terraform-${var.environment}"
+    }
+  }
+
+  default_tags {
+    tags = {
+      ManagedBy = "Terraform"
+      Project   = "HIPAA-Compliant-Document-Management"
+    }
+  }
+}
+
+# ------------------------------------------------------------------------------
+# AWS Provider Configuration - Central Alarm Region
+# ------------------------------------------------------------------------------
+# Aliased provider for the central alarm topic. Falls back to the primary
+# region when central_alarm_region is unset; the monitoring module only
+# creates the central topic when the variable is set.
+
+provider "aws" {
+  alias  = "central"
+  region = coalesce(var.central_alarm_region, var.aws_region)
+
+  dynamic "assume_role" {
+    for_each = var.assume_role_arn == "" ? [] : [var.assume_role_arn]
+    content {
+      role_arn     = assume_role.value
+      session_name = "terraform-${var.environment}"
+    }
+  }
+
+  default_tags {
+    tags = {
+      ManagedBy = "Terraform"
+      Project   = "HIPAA-Compliant-Document-Management"
+    }
+  }
+}